@@ -0,0 +1,46 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer}, nil
+}
+
+func (w *csvWriter) write(rows [][]interface{}) error {
+	record := make([]string, 0)
+
+	for _, row := range rows {
+		record = record[:0]
+		for _, value := range row {
+			record = append(record, fmt.Sprint(value))
+		}
+
+		if err := w.writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	// flushed daily so that the file can be followed while the simulation runs
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+func (w *csvWriter) close() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
@@ -0,0 +1,180 @@
+// Package export writes the daily metrics of a simulation to files that
+// analysis tools load directly. Every row holds the metrics of one
+// jurisdiction on one day, and the columns are named after the json tags
+// of messaging.Metrics.
+package export
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/messaging"
+)
+
+const CSVFormat Format = "csv"
+const ParquetFormat Format = "parquet"
+
+type Format string
+
+type MetricsFile struct {
+	file   *os.File
+	writer rowWriter
+	failed bool
+}
+
+// rowWriter writes rows whose values are ints or strings, in the order of
+// the columns it was created with
+type rowWriter interface {
+	write(rows [][]interface{}) error
+	close() error
+}
+
+// the jurisdiction columns are placed after the day, followed by the
+// remaining fields of messaging.Metrics
+var jurisdiction_columns = []string{"jurisdiction_id", "jurisdiction_name", "jurisdiction_level"}
+
+// ParseFormat accepts the format names used in the environment. An empty
+// name means CSV.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case "":
+		return CSVFormat, nil
+	case CSVFormat, ParquetFormat:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown metrics format %q", name)
+	}
+}
+
+func (format Format) Extension() string {
+	return "." + string(format)
+}
+
+// NewMetricsFile creates the file at path, replacing any existing file
+func NewMetricsFile(path string, format Format) (*MetricsFile, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var writer rowWriter
+	switch format {
+	case CSVFormat:
+		writer, err = newCSVWriter(file, columns())
+	case ParquetFormat:
+		writer, err = newParquetWriter(file, columns())
+	default:
+		err = fmt.Errorf("unknown metrics format %q", format)
+	}
+
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &MetricsFile{file: file, writer: writer}, nil
+}
+
+func (metrics_file *MetricsFile) NewEventSubscriber() func(event *logger.Event) {
+	return messaging.NewMetricsAggregator(metrics_file.writeDay)
+}
+
+// Close completes the file. Call it once the simulation has ended.
+func (metrics_file *MetricsFile) Close() error {
+	if err := metrics_file.writer.close(); err != nil {
+		metrics_file.file.Close()
+		return err
+	}
+
+	return metrics_file.file.Close()
+}
+
+func (metrics_file *MetricsFile) writeDay(jurisdiction_metrics messaging.JuristictionMetrics) {
+	if metrics_file.failed {
+		return
+	}
+
+	// sorted so that files of the same run are identical
+	jur_ids := make([]string, 0, len(jurisdiction_metrics))
+	for jur_id := range jurisdiction_metrics {
+		jur_ids = append(jur_ids, jur_id)
+	}
+	slices.Sort(jur_ids)
+
+	rows := make([][]interface{}, 0, len(jur_ids))
+	for _, jur_id := range jur_ids {
+		rows = append(rows, row(jur_id, jurisdiction_metrics[jur_id]))
+	}
+
+	if err := metrics_file.writer.write(rows); err != nil {
+		slog.Error("failed to write metrics, stopping metrics export", "path", metrics_file.file.Name(), "error", err)
+		metrics_file.failed = true
+	}
+}
+
+func columns() []string {
+	fields := metricsFields()
+
+	columns := make([]string, 0, len(fields)+len(jurisdiction_columns))
+	columns = append(columns, fields[0].name)
+	columns = append(columns, jurisdiction_columns...)
+	for _, field := range fields[1:] {
+		columns = append(columns, field.name)
+	}
+
+	return columns
+}
+
+func row(jur_id string, metrics *messaging.Metrics) []interface{} {
+	var name, level string
+	if jur := metrics.Jurisdiction(); jur != nil && jur.Feature != nil {
+		name, level = jur.Feature.Name(), jur.Feature.Level()
+	}
+
+	fields := metricsFields()
+	value := reflect.ValueOf(metrics).Elem()
+
+	row := make([]interface{}, 0, len(fields)+len(jurisdiction_columns))
+	row = append(row, int(value.Field(fields[0].index).Int()))
+	row = append(row, jur_id, name, level)
+	for _, field := range fields[1:] {
+		row = append(row, value.Field(field.index).Interface())
+	}
+
+	return row
+}
+
+type metricsField struct {
+	name  string
+	index int
+	kind  reflect.Kind // int for counts, float64 for rates
+}
+
+// metricsFields lists the serialized fields of messaging.Metrics in the
+// order they are declared, which starts with the day
+func metricsFields() []metricsField {
+	t := reflect.TypeOf(messaging.Metrics{})
+
+	fields := make([]metricsField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if !t.Field(i).IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		fields = append(fields, metricsField{name, i, t.Field(i).Type.Kind()})
+	}
+
+	return fields
+}
+
+func isFloatColumn(fields []metricsField, column string) bool {
+	return slices.ContainsFunc(fields, func(field metricsField) bool {
+		return field.name == column && field.kind == reflect.Float64
+	})
+}
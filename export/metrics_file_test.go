@@ -0,0 +1,84 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/messaging"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeMetricsFile(t *testing.T, format Format) string {
+	path := filepath.Join(t.TempDir(), "metrics"+format.Extension())
+
+	metrics_file, err := NewMetricsFile(path, format)
+	if err != nil {
+		t.Fatalf("Test failed because the metrics file couldn't be created: %s", err)
+	}
+
+	metrics_file.writeDay(messaging.JuristictionMetrics{
+		"GLOBAL":    &messaging.Metrics{Day: 1, NewInfections: 3, TotalCases: 1, ComplianceRate: 0.5},
+		"E02000001": &messaging.Metrics{Day: 1, NewInfections: 2},
+	})
+	metrics_file.writeDay(messaging.JuristictionMetrics{
+		"GLOBAL": &messaging.Metrics{Day: 2, NewInfections: 5, TotalCases: 4},
+	})
+
+	if err := metrics_file.Close(); err != nil {
+		t.Fatalf("Test failed because the metrics file couldn't be completed: %s", err)
+	}
+
+	return path
+}
+
+func TestMetricsAreWrittenToCSV(t *testing.T) {
+	data, err := os.ReadFile(writeMetricsFile(t, CSVFormat))
+	if err != nil {
+		t.Fatalf("Test failed because the metrics file couldn't be read: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	assert.Len(t, lines, 4, "Expected a header and a row per day and jurisdiction")
+	assert.True(t, strings.HasPrefix(lines[0], "day,jurisdiction_id,jurisdiction_name,jurisdiction_level,new_infections,"), "Expected the header to name the columns")
+	assert.True(t, strings.HasSuffix(lines[0], ",new_cases,total_cases,turned_away,compliance_rate"), "Expected every metrics field to be a column")
+	assert.True(t, strings.HasPrefix(lines[1], "1,E02000001,,,2,"), "Expected the rows of a day to be sorted by jurisdiction")
+	assert.True(t, strings.HasSuffix(lines[2], ",0.5"), "Expected rates to be written as decimals")
+	assert.True(t, strings.HasPrefix(lines[3], "2,GLOBAL,,,5,"), "Expected the rows of later days to follow")
+}
+
+func TestMetricsAreWrittenToParquet(t *testing.T) {
+	type row struct {
+		Day            int64   `parquet:"day"`
+		JurisdictionId string  `parquet:"jurisdiction_id"`
+		NewInfections  int64   `parquet:"new_infections"`
+		TotalCases     int64   `parquet:"total_cases"`
+		ComplianceRate float64 `parquet:"compliance_rate"`
+	}
+
+	rows, err := parquet.ReadFile[row](writeMetricsFile(t, ParquetFormat))
+	if err != nil {
+		t.Fatalf("Test failed because the metrics file couldn't be read: %s", err)
+	}
+
+	assert.Equal(t, []row{
+		{Day: 1, JurisdictionId: "E02000001", NewInfections: 2},
+		{Day: 1, JurisdictionId: "GLOBAL", NewInfections: 3, TotalCases: 1, ComplianceRate: 0.5},
+		{Day: 2, JurisdictionId: "GLOBAL", NewInfections: 5, TotalCases: 4},
+	}, rows, "Expected a row per day and jurisdiction")
+}
+
+func TestParquetRejectsUnsupportedValues(t *testing.T) {
+	var file strings.Builder
+
+	writer, err := newParquetWriter(&file, []string{"day", "jurisdiction_id"})
+	if err != nil {
+		t.Fatalf("Test failed because the parquet writer couldn't be created: %s", err)
+	}
+
+	err = writer.write([][]interface{}{{true, "GLOBAL"}})
+	assert.EqualError(t, err, "can't write bool values of column day", "Expected values of unsupported types to fail rather than be written as zeros")
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type parquetWriter struct {
+	writer  *parquet.Writer
+	columns []string
+
+	// position of every column in the schema, which orders columns by name
+	indices []int
+}
+
+func newParquetWriter(w io.Writer, columns []string) (*parquetWriter, error) {
+	fields := metricsFields()
+
+	group := make(parquet.Group, len(columns))
+	for _, column := range columns {
+		switch {
+		case slices.Contains(jurisdiction_columns, column):
+			group[column] = parquet.String()
+		case isFloatColumn(fields, column):
+			group[column] = parquet.Leaf(parquet.DoubleType)
+		default:
+			group[column] = parquet.Int(64)
+		}
+	}
+
+	schema := parquet.NewSchema("metrics", group)
+
+	schema_columns := schema.Columns()
+	indices := make([]int, len(columns))
+	for i, column := range columns {
+		indices[i] = slices.IndexFunc(schema_columns, func(path []string) bool {
+			return len(path) == 1 && path[0] == column
+		})
+	}
+
+	return &parquetWriter{
+		writer:  parquet.NewWriter(w, schema, parquet.Compression(&parquet.Snappy)),
+		columns: columns,
+		indices: indices,
+	}, nil
+}
+
+func (w *parquetWriter) write(rows [][]interface{}) error {
+	parquet_rows := make([]parquet.Row, 0, len(rows))
+
+	for _, row := range rows {
+		parquet_row := make(parquet.Row, len(row))
+		for i, value := range row {
+			var parquet_value parquet.Value
+			switch value := value.(type) {
+			case string:
+				parquet_value = parquet.ByteArrayValue([]byte(value))
+			case int:
+				parquet_value = parquet.Int64Value(int64(value))
+			case float64:
+				parquet_value = parquet.DoubleValue(value)
+			default:
+				return fmt.Errorf("can't write %T values of column %s", value, w.columns[i])
+			}
+
+			index := w.indices[i]
+			parquet_row[index] = parquet_value.Level(0, 0, index)
+		}
+
+		parquet_rows = append(parquet_rows, parquet_row)
+	}
+
+	_, err := w.writer.WriteRows(parquet_rows)
+
+	return err
+}
+
+func (w *parquetWriter) close() error {
+	return w.writer.Close()
+}
@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"sync"
@@ -56,7 +56,7 @@ func fetchFeatures(gis_codes []string) ([]*Feature, error) {
 	// Collect errors
 	if len(errors) > 0 {
 		for err := range errors {
-			fmt.Println(err) // Log errors (optional)
+			slog.Error("failed to fetch feature", "error", err)
 		}
 		return nil, fmt.Errorf("some fetch operations failed, check logs")
 	}
@@ -70,8 +70,7 @@ func fetchFeatures(gis_codes []string) ([]*Feature, error) {
 }
 
 func fetchFeature(gisCode string) (*Feature, error) {
-	// debugging
-	log.Printf("fetching geo data for MSOA code: %s\n", gisCode)
+	slog.Debug("fetching geo data", "gis_code", gisCode)
 
 	url := fmt.Sprintf("https://findthatpostcode.uk/areas/%s.geojson", gisCode)
 	client := &http.Client{Timeout: 10 * time.Second}
@@ -99,7 +98,7 @@ func fetchFeature(gisCode string) (*Feature, error) {
 		return nil, fmt.Errorf("failed to parse JSON: %v", err)
 	}
 
-	log.Printf("successfully fetched MSOA data MSOA code: %s\n", gisCode)
+	slog.Debug("fetched geo data", "gis_code", gisCode)
 
 	return &data.Features[0], nil
 }
@@ -121,8 +120,11 @@ func saveJson(data interface{}, filename string) error {
 	return nil
 }
 
-func GenerateFeatures(path string) {
-	msoas := loadMSOAs()
+func GenerateFeatures(path string) error {
+	msoas, err := loadMSOAs()
+	if err != nil {
+		return err
+	}
 
 	gis_codes := make([]string, 0, len(msoas))
 	for _, msoa := range msoas {
@@ -131,8 +133,7 @@ func GenerateFeatures(path string) {
 
 	features, err := fetchFeatures(gis_codes)
 	if err != nil {
-		fmt.Printf("Error fetching data for msoas: %v\n", err)
-		return
+		return fmt.Errorf("failed to fetch msoa features: %w", err)
 	}
 
 	parent_map := make(map[string]interface{}, 0)
@@ -142,7 +143,7 @@ func GenerateFeatures(path string) {
 
 		parent := feature.ParentCode()
 		if parent != "" {
-			log.Printf("found parent for %s: %s", feature.Code(), parent)
+			slog.Debug("found parent feature", "gis_code", feature.Code(), "parent", parent)
 			parent_map[parent] = nil
 		}
 	}
@@ -154,8 +155,7 @@ func GenerateFeatures(path string) {
 
 	parent_features, err := fetchFeatures(parents)
 	if err != nil {
-		fmt.Printf("Error fetching data for msoas: %v\n", err)
-		return
+		return fmt.Errorf("failed to fetch parent features: %w", err)
 	}
 
 	for _, feature := range parent_features {
@@ -166,5 +166,5 @@ func GenerateFeatures(path string) {
 		features = append(features, feature)
 	}
 
-	saveJson(features, path)
+	return saveJson(features, path)
 }
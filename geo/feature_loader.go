@@ -3,19 +3,19 @@ package geo
 import (
 	_ "embed"
 	"encoding/json"
-	"log"
+	"fmt"
 )
 
 //go:embed features.json
 var feature_file string
 
-func LoadFeatures() []*Feature {
+func LoadFeatures() ([]*Feature, error) {
 	var features []*Feature
 
 	err := json.Unmarshal([]byte(feature_file), &features)
 	if err != nil {
-		log.Fatalf("failed to load features: %s\n", err)
+		return nil, fmt.Errorf("failed to load features: %w", err)
 	}
 
-	return features
+	return features, nil
 }
@@ -3,5 +3,7 @@ package geo
 import "testing"
 
 func TestFeatureLoader(t *testing.T) {
-	LoadFeatures()
+	if _, err := LoadFeatures(); err != nil {
+		t.Fatal(err)
+	}
 }
@@ -3,7 +3,7 @@ package geo
 import (
 	_ "embed"
 	"encoding/csv"
-	"log"
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -11,18 +11,18 @@ import (
 //go:embed msoa.csv
 var msoa_file string
 
-func loadMSOAs() []*MSOA {
+func loadMSOAs() ([]*MSOA, error) {
 	reader := csv.NewReader(strings.NewReader(msoa_file))
 	records, err := reader.ReadAll()
 	if err != nil {
-		log.Fatalf("failed to load msoas: %s\n", err)
+		return nil, fmt.Errorf("failed to load msoas: %w", err)
 	}
 
 	var msoas []*MSOA
 	for _, record := range records {
 		pop_density, err := strconv.ParseFloat(record[2], 64)
 		if err != nil {
-			log.Fatalf("invalid population density in msoa source: %v", err)
+			return nil, fmt.Errorf("invalid population density in msoa source: %w", err)
 		}
 
 		msoas = append(msoas, &MSOA{
@@ -32,5 +32,5 @@ func loadMSOAs() []*MSOA {
 		})
 	}
 
-	return msoas
+	return msoas, nil
 }
@@ -1,7 +1,7 @@
 package geo
 
 import (
-	"log"
+	"fmt"
 	"time"
 
 	"golang.org/x/exp/rand"
@@ -13,8 +13,11 @@ type MSOASampler struct {
 	rng          *rand.Rand
 }
 
-func NewMSOASampler() *MSOASampler {
-	msoas := loadMSOAs()
+func NewMSOASampler() (*MSOASampler, error) {
+	msoas, err := loadMSOAs()
+	if err != nil {
+		return nil, err
+	}
 
 	total_weight := 0.0
 	for _, msoa := range msoas {
@@ -25,10 +28,10 @@ func NewMSOASampler() *MSOASampler {
 		msoas:        msoas,
 		total_weight: total_weight,
 		rng:          rand.New(rand.NewSource(uint64(time.Now().UnixNano()))),
-	}
+	}, nil
 }
 
-func (sampler *MSOASampler) Sample() *MSOA {
+func (sampler *MSOASampler) Sample() (*MSOA, error) {
 	random_weight := sampler.rng.Float64() * sampler.total_weight
 
 	// Find the corresponding item
@@ -36,12 +39,11 @@ func (sampler *MSOASampler) Sample() *MSOA {
 	for _, msoa := range sampler.msoas {
 		current_weight += msoa.PopulationDensity
 		if random_weight <= current_weight {
-			return msoa
+			return msoa, nil
 		}
 	}
 
-	log.Fatalln("this should be unreachable")
-	return nil
+	return nil, fmt.Errorf("no msoa sampled for weight %f of %f", random_weight, sampler.total_weight)
 }
 
 // SampleMSOA randomly selects a MSOA based on population density.
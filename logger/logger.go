@@ -1,42 +1,181 @@
 package logger
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 type Logger struct {
-	events   chan Event
-	channels []chan *Event
+	events      chan Event
+	channels    []chan *Event
+	closed      bool
+	mu          sync.RWMutex
+	subscribers sync.WaitGroup
+	done        chan struct{}
+
+	// queue depths, see QueueDepths and Backlog
+	pending        []*atomic.Int64
+	pending_mu     sync.Mutex
+	waiting_to_log atomic.Int64
+
+	// events logged by subscribers, see LogFollowUp
+	followups       []Event
+	followups_mu    sync.Mutex
+	followups_ready chan struct{}
 }
 
-func NewLogger() Logger {
-	return Logger{
+func NewLogger() *Logger {
+	return &Logger{
 		events:   make(chan Event),
 		channels: make([]chan *Event, 0),
+		done:     make(chan struct{}),
+
+		followups_ready: make(chan struct{}, 1),
 	}
 }
 
+// Log queues an event for broadcasting. Events logged after Close are dropped.
 func (logger *Logger) Log(event Event) {
+	logger.mu.RLock()
+	defer logger.mu.RUnlock()
+
+	if logger.closed {
+		return
+	}
+
+	logger.waiting_to_log.Add(1)
+	defer logger.waiting_to_log.Add(-1)
+
 	logger.events <- event
 }
 
+// LogFollowUp queues an event logged by a subscriber while it handles another
+// event. Log could deadlock there, as the broadcast may be waiting to hand the
+// subscriber its next event. Follow-up events are broadcast before Close
+// returns, even when they are queued while closing.
+func (logger *Logger) LogFollowUp(event Event) {
+	logger.followups_mu.Lock()
+	logger.followups = append(logger.followups, event)
+	logger.followups_mu.Unlock()
+
+	select {
+	case logger.followups_ready <- struct{}{}:
+	default:
+	}
+}
+
 func (logger *Logger) Subscribe(subscriber func(event *Event)) {
 	channel := make(chan *Event)
+	pending := new(atomic.Int64)
 
 	logger.channels = append(logger.channels, channel)
+	logger.subscribers.Add(1)
+
+	logger.pending_mu.Lock()
+	logger.pending = append(logger.pending, pending)
+	logger.pending_mu.Unlock()
 
 	go func() {
-		for {
-			event := <-channel
+		defer logger.subscribers.Done()
+
+		for event := range channel {
+			// nil events only show that the previous event was handled
+			if event == nil {
+				continue
+			}
+
 			subscriber(event)
+			pending.Add(-1)
 		}
 	}()
 }
 
 func (logger *Logger) Broadcast() {
-	for {
-		event := <-logger.events
+	for is_open := true; is_open; {
+		select {
+		case event, ok := <-logger.events:
+			if ok {
+				logger.broadcast(event)
+			} else {
+				is_open = false
+			}
+		case <-logger.followups_ready:
+			logger.broadcastFollowUps()
+		}
+	}
 
+	// subscribers may still log follow-ups while handling the last events.
+	// once every subscriber took a nil event they are done with the others
+	for {
 		for _, channel := range logger.channels {
-			func(channel chan *Event) {
-				channel <- &event
-			}(channel)
+			channel <- nil
+		}
+
+		if !logger.broadcastFollowUps() {
+			break
 		}
 	}
+
+	for _, channel := range logger.channels {
+		close(channel)
+	}
+
+	close(logger.done)
+}
+
+func (logger *Logger) broadcast(event Event) {
+	for i, channel := range logger.channels {
+		logger.pending[i].Add(1)
+		channel <- &event
+	}
+}
+
+// broadcastFollowUps reports whether there were any follow-ups to broadcast
+func (logger *Logger) broadcastFollowUps() bool {
+	logger.followups_mu.Lock()
+	followups := logger.followups
+	logger.followups = nil
+	logger.followups_mu.Unlock()
+
+	for _, event := range followups {
+		logger.broadcast(event)
+	}
+
+	return len(followups) > 0
+}
+
+// QueueDepths reports, per subscriber in the order they subscribed, how
+// many events are waiting for or being handled by the subscriber. Events
+// are handed over one at a time, so a subscriber that is stuck shows a
+// depth that doesn't go back to 0.
+func (logger *Logger) QueueDepths() []int64 {
+	logger.pending_mu.Lock()
+	defer logger.pending_mu.Unlock()
+
+	depths := make([]int64, len(logger.pending))
+	for i, pending := range logger.pending {
+		depths[i] = pending.Load()
+	}
+
+	return depths
+}
+
+// Backlog reports how many Log calls are blocked until the subscribers
+// catch up.
+func (logger *Logger) Backlog() int64 {
+	return logger.waiting_to_log.Load()
+}
+
+// Close stops accepting new events and blocks until every event already
+// logged has been handled by every subscriber. Broadcast must be running.
+func (logger *Logger) Close() {
+	logger.mu.Lock()
+	if !logger.closed {
+		logger.closed = true
+		close(logger.events)
+	}
+	logger.mu.Unlock()
+
+	<-logger.done
+	logger.subscribers.Wait()
 }
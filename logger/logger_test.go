@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseFlushesSubscribers(t *testing.T) {
+	logger := NewLogger()
+
+	received := make([]EventType, 0)
+	logger.Subscribe(func(event *Event) {
+		received = append(received, event.Type)
+	})
+
+	go logger.Broadcast()
+
+	logger.Log(Event{Type: "first"})
+	logger.Log(Event{Type: "second"})
+	logger.Close()
+
+	// events logged after closing are dropped rather than blocking forever
+	logger.Log(Event{Type: "third"})
+
+	assert.Equal(t, []EventType{"first", "second"}, received, "Expected every event logged before Close to reach the subscriber")
+}
+
+func TestQueueDepthsShowBlockedSubscribers(t *testing.T) {
+	logger := NewLogger()
+
+	release := make(chan struct{})
+	handling := make(chan struct{})
+
+	logger.Subscribe(func(event *Event) {})
+	logger.Subscribe(func(event *Event) {
+		handling <- struct{}{}
+		<-release
+	})
+
+	go logger.Broadcast()
+	go logger.Log(Event{Type: "first"})
+
+	<-handling
+
+	assert.Equal(t, int64(1), logger.QueueDepths()[1], "Expected the event to be pending for the blocked subscriber")
+
+	close(release)
+	logger.Close()
+
+	assert.Equal(t, []int64{0, 0}, logger.QueueDepths(), "Expected no pending events once every subscriber caught up")
+	assert.Equal(t, int64(0), logger.Backlog(), "Expected no blocked Log calls once every subscriber caught up")
+}
+
+func TestCloseBroadcastsFollowUpsOfTheLastEvents(t *testing.T) {
+	logger := NewLogger()
+
+	received := make([]EventType, 0)
+	logger.Subscribe(func(event *Event) {
+		received = append(received, event.Type)
+	})
+	logger.Subscribe(func(event *Event) {
+		if event.Type == "last" {
+			logger.LogFollowUp(Event{Type: "follow-up"})
+		}
+	})
+
+	go logger.Broadcast()
+
+	logger.Log(Event{Type: "last"})
+	logger.Close()
+
+	assert.Equal(t, []EventType{"last", "follow-up"}, received, "Expected follow-ups logged while closing to reach every subscriber")
+}
@@ -2,46 +2,185 @@ package main
 
 import (
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/CoralCoralCoralCoral/simulation-engine/export"
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/manager"
 	"github.com/CoralCoralCoralCoral/simulation-engine/messaging"
 	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/CoralCoralCoralCoral/simulation-engine/recording"
+	"github.com/CoralCoralCoralCoral/simulation-engine/telemetry"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
-	"github.com/rabbitmq/amqp091-go"
 )
 
 func main() {
 	loadDevEnvIfSet()
+	configureLogging()
 
-	rmq_conn, err := amqp091.Dial(os.Getenv("RMQ_URI"))
+	transport, err := newTransport()
 	if err != nil {
-		log.Fatalf("couldn't create connection to rabbit: %s", err)
+		fatal("couldn't create transport", "error", err)
 	}
-	defer rmq_conn.Close()
+	defer transport.Close()
 
-	init_rx := messaging.NewInitRx(rmq_conn)
-	init_rx.OnReceive(func(api_id uuid.UUID, config model.Config) {
-		sim := model.NewSimulation(config, model.NewDefaultEntityGenerator())
+	sim_manager := manager.NewSimulationManager(loadLimits(), func(api_id uuid.UUID, sim *model.Simulation) {
+		config := sim.Config()
+		sim_log := slog.With("api_id", api_id, "sim_id", config.Id)
 
-		event_tx := messaging.NewEventTx(rmq_conn, api_id, sim.Id())
+		event_tx := messaging.NewEventTx(transport, api_id, &config)
 		defer event_tx.Close()
 
 		sim.Subscribe(event_tx.NewEventSubscriber())
 
-		metrics_tx := messaging.NewMetricsTx(rmq_conn, api_id, sim.Id())
+		metrics_tx := messaging.NewMetricsTx(transport, api_id, &config)
 		defer metrics_tx.Close()
 
 		sim.Subscribe(metrics_tx.NewEventSubscriber())
 
-		command_rx := messaging.NewCommandRx(rmq_conn, sim.Id())
+		if dir := os.Getenv("METRICS_DIR"); dir != "" {
+			metrics_file, err := newMetricsFile(dir, sim.Id())
+			if err != nil {
+				sim_log.Error("couldn't export metrics", "error", err)
+			} else {
+				defer metrics_file.Close()
+				sim.Subscribe(metrics_file.NewEventSubscriber())
+			}
+		}
+
+		if dir := os.Getenv("RECORDING_DIR"); dir != "" {
+			recorder, err := recording.NewRecorder(filepath.Join(dir, sim.Id().String()+".jsonl.gz"), &config)
+			if err != nil {
+				sim_log.Error("couldn't record simulation", "error", err)
+			} else {
+				defer recorder.Close()
+				sim.Subscribe(recorder.NewEventSubscriber())
+			}
+		}
+
+		defer telemetry.Track(sim)()
+
+		command_rx := transport.NewCommandReceiver(sim.Id())
 		defer command_rx.Close()
 
-		go command_rx.OnReceive(sim.SendCommand)
+		go command_rx.OnReceive(telemetry.TimeCommands(sim))
 
 		sim.Start()
 	})
+
+	if addr := os.Getenv("PROMETHEUS_ADDR"); addr != "" {
+		go func() {
+			slog.Error("metrics endpoint stopped", "addr", addr, "error", telemetry.Serve(addr))
+		}()
+	}
+
+	manager_rx := transport.NewManagerCommandReceiver()
+	defer manager_rx.Close()
+
+	go manager_rx.OnRequest(sim_manager.HandleCommand)
+
+	init_rx := transport.NewInitReceiver()
+	defer init_rx.Close()
+
+	init_rx.OnReceive(func(api_id uuid.UUID, config model.Config) {
+		// invalid configs are turned away before they take a place in the queue
+		if err := config.Validate(); err != nil {
+			slog.Warn("invalid config", "api_id", api_id, "sim_id", config.Id, "error", err)
+
+			event_tx := messaging.NewEventTx(transport, api_id, &config)
+			defer event_tx.Close()
+
+			event_tx.Send(&logger.Event{
+				Type: model.SimulationInitFailed,
+				Payload: model.SimulationInitFailedPayload{
+					SimulationId: config.Id,
+					Errors:       model.SplitErrors(err),
+				},
+			})
+
+			return
+		}
+
+		status, err := sim_manager.Submit(api_id, config)
+		if status == manager.Admitted {
+			return
+		}
+
+		slog.Warn("simulation not admitted", "api_id", api_id, "sim_id", config.Id, "status", status, "reason", err)
+
+		event_type := manager.SimulationQueued
+		if status == manager.Rejected {
+			event_type = manager.SimulationRejected
+		}
+
+		event_tx := messaging.NewEventTx(transport, api_id, &config)
+		defer event_tx.Close()
+
+		event_tx.Send(&logger.Event{
+			Type: event_type,
+			Payload: manager.AdmissionPayload{
+				SimulationId: config.Id,
+				Reason:       err.Error(),
+			},
+		})
+	})
+}
+
+// newTransport connects to the transports named by the comma separated
+// TRANSPORT environment variable, e.g. "amqp,grpc". RabbitMQ is used when
+// it is unset.
+func newTransport() (messaging.Transport, error) {
+	names := strings.Split(os.Getenv("TRANSPORT"), ",")
+
+	transports := make([]messaging.Transport, 0, len(names))
+	for _, name := range names {
+		transport, err := newNamedTransport(strings.TrimSpace(name))
+		if err != nil {
+			for _, opened := range transports {
+				opened.Close()
+			}
+
+			return nil, err
+		}
+
+		transports = append(transports, transport)
+	}
+
+	if len(transports) == 1 {
+		return transports[0], nil
+	}
+
+	return messaging.NewMultiTransport(transports...), nil
+}
+
+func newNamedTransport(name string) (messaging.Transport, error) {
+	switch name {
+	case "", "amqp":
+		return messaging.NewAMQPTransport(os.Getenv("RMQ_URI"))
+	case "http":
+		return messaging.NewHTTPTransport(envString("HTTP_ADDR", ":8080")), nil
+	case "grpc":
+		return messaging.NewGRPCTransport(envString("GRPC_ADDR", ":50051"))
+	default:
+		return nil, fmt.Errorf("unknown transport %q", name)
+	}
+}
+
+// newMetricsFile creates a metrics file named after the simulation in the
+// format named by METRICS_FORMAT, CSV by default
+func newMetricsFile(dir string, sim_id uuid.UUID) (*export.MetricsFile, error) {
+	format, err := export.ParseFormat(os.Getenv("METRICS_FORMAT"))
+	if err != nil {
+		return nil, err
+	}
+
+	return export.NewMetricsFile(filepath.Join(dir, sim_id.String()+format.Extension()), format)
 }
 
 func loadDevEnvIfSet() {
@@ -49,9 +188,68 @@ func loadDevEnvIfSet() {
 	flag.Parse()
 
 	if *dev {
-		log.Println("running in dev environment")
+		slog.Info("running in dev environment")
 		if err := godotenv.Load(); err != nil {
-			log.Fatal("Error loading .env file")
+			fatal("couldn't load .env file", "error", err)
 		}
 	}
 }
+
+// configureLogging sets up the default logger from LOG_LEVEL (debug, info,
+// warn or error, info by default) and LOG_FORMAT (text or json, text by
+// default)
+func configureLogging() {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(envString("LOG_LEVEL", "info"))); err != nil {
+		fatal("invalid value for LOG_LEVEL", "error", err)
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	switch format := envString("LOG_FORMAT", "text"); format {
+	case "text":
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, options)))
+	case "json":
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, options)))
+	default:
+		fatal("invalid value for LOG_FORMAT", "format", format)
+	}
+}
+
+// fatal logs msg as an error and exits
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// loadLimits reads the simulation admission limits from the environment.
+// Unset variables leave the corresponding limit disabled.
+func loadLimits() manager.Limits {
+	return manager.Limits{
+		MaxConcurrent: int(envInt("MAX_SIMULATIONS", 0)),
+		MaxQueued:     int(envInt("MAX_QUEUED_SIMULATIONS", 0)),
+		MemoryBudget:  envInt("SIMULATION_MEMORY_BUDGET_MB", 0) << 20,
+	}
+}
+
+func envString(name string, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+
+	return fallback
+}
+
+func envInt(name string, fallback int64) int64 {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		fatal("invalid value", "name", name, "error", err)
+	}
+
+	return parsed
+}
@@ -0,0 +1,14 @@
+package manager
+
+import (
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/google/uuid"
+)
+
+const SimulationQueued logger.EventType = "simulation_queued"
+const SimulationRejected logger.EventType = "simulation_rejected"
+
+type AdmissionPayload struct {
+	SimulationId uuid.UUID `json:"simulation_id"`
+	Reason       string    `json:"reason"`
+}
@@ -0,0 +1,261 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+)
+
+const Admitted AdmissionStatus = "admitted"
+const Queued AdmissionStatus = "queued"
+const Rejected AdmissionStatus = "rejected"
+
+// queued simulations are reported with this state until they start
+const QueuedState model.SimulationState = "queued"
+
+type AdmissionStatus string
+
+// Limits bound the simulations a manager runs at once. Zero values mean
+// no limit, except MaxQueued where zero means nothing is queued.
+type Limits struct {
+	MaxConcurrent int
+	MaxQueued     int
+	MemoryBudget  int64 // bytes
+}
+
+type SimulationManager struct {
+	limits      Limits
+	run         func(api_id uuid.UUID, sim *model.Simulation)
+	mu          sync.Mutex
+	simulations map[uuid.UUID]*managedSimulation
+	queue       []*managedSimulation
+	memory      int64
+}
+
+type managedSimulation struct {
+	api_id     uuid.UUID
+	config     model.Config
+	sim        *model.Simulation
+	memory     int64
+	state      model.SimulationState
+	epoch      int64
+	queued_at  time.Time
+	started_at time.Time
+}
+
+type SimulationInfo struct {
+	Id              uuid.UUID             `json:"id"`
+	ApiId           uuid.UUID             `json:"api_id"`
+	State           model.SimulationState `json:"state"`
+	Epoch           int64                 `json:"epoch"`
+	NumAgents       int64                 `json:"num_agents"`
+	EstimatedMemory int64                 `json:"estimated_memory"`
+	QueuedAt        time.Time             `json:"queued_at"`
+	StartedAt       *time.Time            `json:"started_at,omitempty"`
+}
+
+// NewSimulationManager creates a manager that calls run on its own goroutine
+// for every admitted simulation. run is expected to block until the
+// simulation has ended, typically by calling sim.Start.
+func NewSimulationManager(limits Limits, run func(api_id uuid.UUID, sim *model.Simulation)) *SimulationManager {
+	return &SimulationManager{
+		limits:      limits,
+		run:         run,
+		simulations: make(map[uuid.UUID]*managedSimulation),
+		queue:       make([]*managedSimulation, 0),
+	}
+}
+
+// Submit admits a simulation if it fits within the limits, queues it if it
+// will fit once others finish, and rejects it otherwise. The returned error
+// explains why a simulation was queued or rejected.
+func (manager *SimulationManager) Submit(api_id uuid.UUID, config model.Config) (AdmissionStatus, error) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	if _, ok := manager.simulations[config.Id]; ok {
+		return Rejected, fmt.Errorf("simulation %s already exists", config.Id)
+	}
+
+	managed := &managedSimulation{
+		api_id:    api_id,
+		config:    config,
+		memory:    model.EstimateMemory(&config),
+		state:     QueuedState,
+		queued_at: time.Now(),
+	}
+
+	if manager.limits.MemoryBudget > 0 && managed.memory > manager.limits.MemoryBudget {
+		return Rejected, fmt.Errorf("simulation needs an estimated %d MB which exceeds the memory budget of %d MB", managed.memory>>20, manager.limits.MemoryBudget>>20)
+	}
+
+	if len(manager.queue) == 0 {
+		if err := manager.fits(managed); err == nil {
+			manager.start(managed)
+			return Admitted, nil
+		}
+	}
+
+	if len(manager.queue) >= manager.limits.MaxQueued {
+		return Rejected, errors.New("too many simulations are running or queued")
+	}
+
+	manager.queue = append(manager.queue, managed)
+	manager.simulations[config.Id] = managed
+
+	return Queued, fmt.Errorf("waiting for capacity, position %d in queue", len(manager.queue))
+}
+
+// Kill quits a running simulation or removes a queued one.
+func (manager *SimulationManager) Kill(sim_id uuid.UUID) error {
+	manager.mu.Lock()
+
+	managed, ok := manager.simulations[sim_id]
+	if !ok {
+		manager.mu.Unlock()
+		return fmt.Errorf("simulation %s not found", sim_id)
+	}
+
+	if managed.sim == nil {
+		for idx, candidate := range manager.queue {
+			if candidate == managed {
+				manager.queue = append(manager.queue[:idx], manager.queue[idx+1:]...)
+				break
+			}
+		}
+
+		delete(manager.simulations, sim_id)
+		manager.mu.Unlock()
+
+		return nil
+	}
+
+	manager.mu.Unlock()
+
+	// a quit command would block until the simulation is initialized
+	managed.sim.Cancel()
+
+	return nil
+}
+
+func (manager *SimulationManager) List() []SimulationInfo {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	infos := make([]SimulationInfo, 0, len(manager.simulations))
+	for id, managed := range manager.simulations {
+		info := SimulationInfo{
+			Id:              id,
+			ApiId:           managed.api_id,
+			State:           managed.state,
+			Epoch:           managed.epoch,
+			NumAgents:       managed.config.NumAgents,
+			EstimatedMemory: managed.memory,
+			QueuedAt:        managed.queued_at,
+		}
+
+		if managed.sim != nil {
+			started_at := managed.started_at
+			info.StartedAt = &started_at
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// HandleCommand answers the manager level commands received on the command exchange.
+func (manager *SimulationManager) HandleCommand(command model.Command) model.QueryResponse {
+	response := model.QueryResponse{Type: command.Type}
+
+	switch command.Type {
+	case model.ListSimulations:
+		response.Payload = manager.List()
+	case model.KillSimulation:
+		payload, ok := command.Payload.(*model.QueryEntityPayload)
+		if !ok {
+			response.Error = "expected a simulation id"
+			break
+		}
+
+		if err := manager.Kill(payload.Id); err != nil {
+			response.Error = err.Error()
+		}
+	default:
+		response.Error = fmt.Sprintf("unsupported command type %s", command.Type)
+	}
+
+	return response
+}
+
+func (manager *SimulationManager) fits(managed *managedSimulation) error {
+	if manager.limits.MaxConcurrent > 0 && manager.running() >= manager.limits.MaxConcurrent {
+		return errors.New("maximum number of concurrent simulations reached")
+	}
+
+	if manager.limits.MemoryBudget > 0 && manager.memory+managed.memory > manager.limits.MemoryBudget {
+		return errors.New("memory budget exhausted")
+	}
+
+	return nil
+}
+
+func (manager *SimulationManager) running() int {
+	return len(manager.simulations) - len(manager.queue)
+}
+
+// start must be called with the lock held
+func (manager *SimulationManager) start(managed *managedSimulation) {
+	sim := model.NewSimulation(managed.config, model.NewDefaultEntityGenerator())
+
+	managed.sim = &sim
+	managed.state = model.Initializing
+	managed.started_at = time.Now()
+
+	manager.simulations[managed.config.Id] = managed
+	manager.memory += managed.memory
+
+	sim.Subscribe(func(event *logger.Event) {
+		switch payload := event.Payload.(type) {
+		case model.SimulationStateUpdatePayload:
+			manager.mu.Lock()
+			managed.state = payload.State
+			managed.epoch = payload.Epoch
+			manager.mu.Unlock()
+		case model.EpochEndPayload:
+			manager.mu.Lock()
+			managed.epoch = payload.Epoch
+			manager.mu.Unlock()
+		}
+	})
+
+	go func() {
+		manager.run(managed.api_id, managed.sim)
+		manager.finish(managed)
+	}()
+}
+
+// finish releases the resources of an ended simulation and starts as many
+// queued simulations as now fit
+func (manager *SimulationManager) finish(managed *managedSimulation) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+
+	delete(manager.simulations, managed.config.Id)
+	manager.memory -= managed.memory
+
+	slog.Info("simulation ended", "sim_id", managed.config.Id, "running", manager.running(), "queued", len(manager.queue))
+
+	for len(manager.queue) > 0 && manager.fits(manager.queue[0]) == nil {
+		next := manager.queue[0]
+		manager.queue = manager.queue[1:]
+		manager.start(next)
+	}
+}
@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmissionControl(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan uuid.UUID, 3)
+
+	manager := NewSimulationManager(Limits{MaxConcurrent: 1, MaxQueued: 1}, func(api_id uuid.UUID, sim *model.Simulation) {
+		started <- sim.Id()
+		<-release
+	})
+
+	first := model.Config{Id: uuid.New(), NumAgents: 1000}
+	second := model.Config{Id: uuid.New(), NumAgents: 1000}
+	third := model.Config{Id: uuid.New(), NumAgents: 1000}
+
+	status, _ := manager.Submit(uuid.New(), first)
+	assert.Equal(t, Admitted, status, "Expected the first simulation to be admitted")
+
+	status, _ = manager.Submit(uuid.New(), second)
+	assert.Equal(t, Queued, status, "Expected the second simulation to be queued")
+
+	status, _ = manager.Submit(uuid.New(), third)
+	assert.Equal(t, Rejected, status, "Expected the third simulation to be rejected")
+
+	assert.Len(t, manager.List(), 2, "Expected the running and queued simulations to be listed")
+	assert.Equal(t, first.Id, <-started, "Expected the admitted simulation to start")
+
+	// finishing the running simulation frees capacity for the queued one
+	release <- struct{}{}
+
+	select {
+	case id := <-started:
+		assert.Equal(t, second.Id, id, "Expected the queued simulation to start")
+	case <-time.After(time.Second):
+		t.Fatal("Test failed because the queued simulation never started")
+	}
+}
+
+func TestMemoryBudgetRejectsOversizedSimulations(t *testing.T) {
+	manager := NewSimulationManager(Limits{MemoryBudget: 1 << 20, MaxQueued: 10}, func(api_id uuid.UUID, sim *model.Simulation) {})
+
+	status, err := manager.Submit(uuid.New(), model.Config{Id: uuid.New(), NumAgents: 150000, HealthcareSpaceCapacityMean: 173})
+
+	assert.Equal(t, Rejected, status, "Expected a simulation larger than the whole budget to be rejected")
+	assert.Error(t, err)
+}
+
+func TestKillQueuedSimulation(t *testing.T) {
+	manager := NewSimulationManager(Limits{MaxConcurrent: 1, MaxQueued: 1}, func(api_id uuid.UUID, sim *model.Simulation) {
+		select {}
+	})
+
+	manager.Submit(uuid.New(), model.Config{Id: uuid.New()})
+
+	queued := model.Config{Id: uuid.New()}
+	manager.Submit(uuid.New(), queued)
+
+	response := manager.HandleCommand(model.Command{
+		Type:    model.KillSimulation,
+		Payload: &model.QueryEntityPayload{Id: queued.Id},
+	})
+
+	assert.Empty(t, response.Error, "Expected the queued simulation to be killed")
+	assert.Len(t, manager.List(), 1, "Expected only the running simulation to remain")
+}
+
+func TestKillDoesNotWaitForInitialization(t *testing.T) {
+	initializing := make(chan struct{})
+	ended := make(chan struct{})
+
+	manager := NewSimulationManager(Limits{}, func(api_id uuid.UUID, sim *model.Simulation) {
+		// stands in for a slow initialization, during which no commands are read
+		<-initializing
+		sim.Start()
+		close(ended)
+	})
+
+	config := model.DefaultConfig()
+	config.Id = uuid.New()
+	config.NumAgents = 1000
+
+	status, _ := manager.Submit(uuid.New(), config)
+	assert.Equal(t, Admitted, status)
+
+	killed := make(chan error)
+	go func() {
+		killed <- manager.Kill(config.Id)
+	}()
+
+	select {
+	case err := <-killed:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Test failed because killing an initializing simulation blocked")
+	}
+
+	close(initializing)
+
+	select {
+	case <-ended:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Test failed because the killed simulation kept running after initialization")
+	}
+}
@@ -0,0 +1,135 @@
+package messaging
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+)
+
+const ms_per_hour int64 = 60 * 60 * 1000
+
+// AgentEventBatch is the payload of an AgentEventsNotification: the agent
+// events of one simulated hour and how many were dropped by the rate limit
+type AgentEventBatch struct {
+	Epoch   int64           `json:"epoch"`
+	Time    time.Time       `json:"time"`
+	Events  []*logger.Event `json:"events"`
+	Dropped int64           `json:"dropped"`
+}
+
+// agentStream samples and buffers agent events as configured by a
+// model.AgentStreamConfig
+type agentStream struct {
+	config  model.AgentStreamConfig
+	hour    int64
+	pending []*logger.Event
+	dropped int64
+}
+
+func newAgentStream(config model.AgentStreamConfig) *agentStream {
+	return &agentStream{
+		config:  config,
+		pending: make([]*logger.Event, 0),
+	}
+}
+
+// configure replaces the stream settings. events already buffered are
+// still sent at the end of the hour.
+func (stream *agentStream) configure(config model.AgentStreamConfig) {
+	stream.config = config
+}
+
+func (stream *agentStream) add(event *logger.Event) {
+	if !stream.config.Enabled {
+		return
+	}
+
+	var id uuid.UUID
+	var jur *model.Jurisdiction
+
+	switch payload := event.Payload.(type) {
+	case model.AgentStateUpdatePayload:
+		id, jur = payload.Id, payload.Jurisdiction()
+	case model.AgentLocationUpdatePayload:
+		id, jur = payload.Id, payload.Jurisdiction()
+	default:
+		return
+	}
+
+	if !stream.wants(id, jur) {
+		return
+	}
+
+	if stream.config.MaxEventsPerHour > 0 && int64(len(stream.pending)) >= stream.config.MaxEventsPerHour {
+		stream.dropped += 1
+		return
+	}
+
+	stream.pending = append(stream.pending, event)
+}
+
+// endEpoch returns the batch of the hour that just ended, or nil if the
+// hour isn't over yet or there is nothing to send
+func (stream *agentStream) endEpoch(payload model.EpochEndPayload) *AgentEventBatch {
+	hour := payload.Epoch * payload.TimeStep / ms_per_hour
+	if hour == stream.hour {
+		return nil
+	}
+
+	stream.hour = hour
+
+	return stream.flush(payload.Epoch, payload.Time)
+}
+
+func (stream *agentStream) flush(epoch int64, time time.Time) *AgentEventBatch {
+	if len(stream.pending) == 0 && stream.dropped == 0 {
+		return nil
+	}
+
+	batch := &AgentEventBatch{
+		Epoch:   epoch,
+		Time:    time,
+		Events:  stream.pending,
+		Dropped: stream.dropped,
+	}
+
+	stream.pending = make([]*logger.Event, 0)
+	stream.dropped = 0
+
+	return batch
+}
+
+func (stream *agentStream) wants(id uuid.UUID, jur *model.Jurisdiction) bool {
+	if stream.config.JurisdictionId != "" && !withinJurisdiction(jur, stream.config.JurisdictionId) {
+		return false
+	}
+
+	return sampled(id, stream.config.SampleRate)
+}
+
+// sampled hashes the agent id so that the same agents are picked for the
+// whole run, whichever events they produce
+func sampled(id uuid.UUID, rate float64) bool {
+	if rate <= 0 || rate >= 1 {
+		return true
+	}
+
+	hash := fnv.New64a()
+	hash.Write(id[:])
+
+	return float64(hash.Sum64()) < rate*math.MaxUint64
+}
+
+func withinJurisdiction(jur *model.Jurisdiction, jur_id string) bool {
+	for ; jur != nil; jur = jur.Parent() {
+		if jur.Id == jur_id {
+			return true
+		}
+	}
+
+	return false
+}
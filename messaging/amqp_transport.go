@@ -0,0 +1,73 @@
+package messaging
+
+import (
+	"fmt"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/telemetry"
+	"github.com/google/uuid"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+type AMQPTransport struct {
+	conn *Connection
+}
+
+type amqpPublisher struct {
+	ch          *Channel
+	routing_key string
+	encoding    Encoding
+}
+
+func NewAMQPTransport(uri string) (*AMQPTransport, error) {
+	conn, err := Dial(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AMQPTransport{conn}, nil
+}
+
+func (transport *AMQPTransport) NewInitReceiver() InitReceiver {
+	return NewInitRx(transport.conn)
+}
+
+func (transport *AMQPTransport) NewCommandReceiver(sim_id uuid.UUID) CommandReceiver {
+	return NewCommandRx(transport.conn, sim_id)
+}
+
+func (transport *AMQPTransport) NewManagerCommandReceiver() CommandReceiver {
+	return NewManagerCommandRx(transport.conn)
+}
+
+func (transport *AMQPTransport) NewPublisher(api_id, sim_id uuid.UUID, encoding Encoding) Publisher {
+	return &amqpPublisher{
+		ch:          transport.conn.Publisher(declareNotificationExchange),
+		routing_key: fmt.Sprintf("%s.%s", api_id, sim_id),
+		encoding:    encoding,
+	}
+}
+
+func (transport *AMQPTransport) Close() error {
+	return transport.conn.Close()
+}
+
+func (publisher *amqpPublisher) Publish(notification Notification) error {
+	body, err := publisher.encoding.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to %s serialize notification: %w", publisher.encoding, err)
+	}
+
+	err = publisher.ch.Publish(NOTIFICATION_EXCHANGE, publisher.routing_key, amqp091.Publishing{
+		ContentType: publisher.encoding.ContentType(),
+		Body:        body,
+	})
+	if err != nil {
+		telemetry.PublishFailed("amqp")
+	}
+
+	return err
+}
+
+func (publisher *amqpPublisher) Close() {
+	publisher.ch.Close()
+}
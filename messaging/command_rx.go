@@ -3,7 +3,7 @@ package messaging
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/model"
 	"github.com/google/uuid"
@@ -11,81 +11,138 @@ import (
 )
 
 type CommandRx struct {
-	ch       *amqp091.Channel
-	messages <-chan amqp091.Delivery
+	ch  *Channel
+	log *slog.Logger
 }
 
-func NewCommandRx(conn *amqp091.Connection, sim_id uuid.UUID) *CommandRx {
-	ch, err := conn.Channel()
-	failOnError(err, "failed to create channel")
+// manager commands are addressed to this routing key instead of a simulation id
+const MANAGER_ROUTING_KEY = "manager"
 
-	err = ch.ExchangeDeclare(COMMAND_EXCHANGE, "topic", false, true, false, false, nil)
-	failOnError(err, "failed to create exchange")
+func NewCommandRx(conn *Connection, sim_id uuid.UUID) *CommandRx {
+	return newCommandRx(conn, sim_id.String(), fmt.Sprintf("*.%s", sim_id.String()), false, slog.With("sim_id", sim_id))
+}
 
-	queue_name := sim_id.String()
-	routing_key := fmt.Sprintf("*.%s", sim_id.String())
+// NewManagerCommandRx consumes commands addressed to the simulation manager.
+// Every engine instance gets its own exclusive queue, so each of them sees
+// (and answers) every manager command.
+func NewManagerCommandRx(conn *Connection) *CommandRx {
+	return newCommandRx(conn, "", fmt.Sprintf("*.%s", MANAGER_ROUTING_KEY), true, slog.Default())
+}
 
-	_, err = ch.QueueDeclare(
-		queue_name, // Queue name
-		false,      // Durable (survives broker restarts)
-		false,      // Auto-delete
-		false,      // Exclusive
-		false,      // No-wait
-		nil,        // Arguments
-	)
+func newCommandRx(conn *Connection, queue_name, routing_key string, exclusive bool, log *slog.Logger) *CommandRx {
+	ch := conn.Consumer(func(ch *amqp091.Channel) (<-chan amqp091.Delivery, error) {
+		err := ch.ExchangeDeclare(COMMAND_EXCHANGE, "topic", false, true, false, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exchange: %w", err)
+		}
 
-	if err != nil {
-		log.Fatalf("Failed to declare command queue for simulation %s: %s", sim_id.String(), err)
-	}
+		queue, err := ch.QueueDeclare(
+			queue_name, // Queue name (empty lets the broker generate one)
+			false,      // Durable (survives broker restarts)
+			exclusive,  // Auto-delete
+			exclusive,  // Exclusive
+			false,      // No-wait
+			nil,        // Arguments
+		)
 
-	err = ch.QueueBind(
-		queue_name,       // Queue name
-		routing_key,      // Routing key (matches all messages intended for the simulation with id = sim_id)
-		COMMAND_EXCHANGE, // Exchange name
-		false,            // No-wait
-		nil,              // Arguments
-	)
+		if err != nil {
+			return nil, fmt.Errorf("failed to declare command queue %s: %w", queue_name, err)
+		}
 
-	if err != nil {
-		log.Fatalf("Failed to bind command queue to routing key: %s", err)
-	}
+		err = ch.QueueBind(
+			queue.Name,       // Queue name
+			routing_key,      // Routing key (matches all messages intended for the consumer)
+			COMMAND_EXCHANGE, // Exchange name
+			false,            // No-wait
+			nil,              // Arguments
+		)
 
-	messages, err := ch.Consume(
-		queue_name, // Queue name
-		"",         // Consumer tag
-		false,      // Auto-acknowledge (set to false for manual acks)
-		false,      // Exclusive
-		false,      // No-local
-		false,      // No-wait
-		nil,        // Arguments
-	)
-
-	failOnError(err, "failed to consume from command channel")
-
-	rx := CommandRx{
-		ch,
-		messages,
-	}
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind command queue to routing key: %w", err)
+		}
 
-	return &rx
+		return ch.Consume(
+			queue.Name, // Queue name
+			"",         // Consumer tag
+			false,      // Auto-acknowledge (set to false for manual acks)
+			false,      // Exclusive
+			false,      // No-local
+			false,      // No-wait
+			nil,        // Arguments
+		)
+	})
+
+	return &CommandRx{ch, log}
 }
 
-func (rx *CommandRx) OnReceive(handler func(command model.Command)) {
-	for msg := range rx.messages {
+// OnReceive passes commands to handler and queries to query_handler. The
+// answer to a query is published to the queue named in the message's ReplyTo
+// property, tagged with the message's CorrelationId.
+func (rx *CommandRx) OnReceive(handler func(command model.Command), query_handler func(command model.Command) model.QueryResponse) {
+	for msg := range rx.ch.Deliveries() {
 		var command model.Command
 		err := json.Unmarshal(msg.Body, &command)
 
 		if err != nil {
-			log.Println("failed to parse command message")
+			rx.log.Warn("failed to parse command message", "error", err)
+			msg.Ack(false)
 			continue
 		}
 
-		handler(command)
+		if command.IsQuery() {
+			rx.reply(&msg, query_handler(command))
+		} else {
+			handler(command)
+		}
 
 		msg.Ack(false) // Acknowledge message
 	}
 }
 
+// OnRequest passes every command to handler and publishes its response, as
+// OnReceive does for queries.
+func (rx *CommandRx) OnRequest(handler func(command model.Command) model.QueryResponse) {
+	for msg := range rx.ch.Deliveries() {
+		var command model.Command
+		err := json.Unmarshal(msg.Body, &command)
+
+		if err != nil {
+			rx.log.Warn("failed to parse command message", "error", err)
+			msg.Ack(false)
+			continue
+		}
+
+		rx.reply(&msg, handler(command))
+
+		msg.Ack(false) // Acknowledge message
+	}
+}
+
+func (rx *CommandRx) reply(msg *amqp091.Delivery, response model.QueryResponse) {
+	if msg.ReplyTo == "" {
+		rx.log.Warn("dropping response to query without a reply_to queue", "command_type", response.Type)
+		return
+	}
+
+	body, err := json.Marshal(NewNotification(QueryResponseNotification, response))
+
+	if err != nil {
+		rx.log.Error("failed to json serialize response", "command_type", response.Type, "error", err)
+		return
+	}
+
+	// the default exchange routes directly to the named queue
+	err = rx.ch.Publish("", msg.ReplyTo, amqp091.Publishing{
+		ContentType:   "application/json",
+		CorrelationId: msg.CorrelationId,
+		Body:          body,
+	})
+
+	if err != nil {
+		rx.log.Error("failed to publish response", "command_type", response.Type, "error", err)
+	}
+}
+
 func (rx *CommandRx) Close() {
 	rx.ch.Close()
 }
@@ -0,0 +1,51 @@
+package messaging
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAcknowledger struct {
+	mu    sync.Mutex
+	acked []uint64
+}
+
+func (ack *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	ack.mu.Lock()
+	defer ack.mu.Unlock()
+
+	ack.acked = append(ack.acked, tag)
+
+	return nil
+}
+
+func (ack *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	return nil
+}
+
+func (ack *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return nil
+}
+
+func TestCommandRxAcknowledgesMalformedCommands(t *testing.T) {
+	acknowledger := &fakeAcknowledger{}
+	deliveries := make(chan amqp091.Delivery, 2)
+	deliveries <- amqp091.Delivery{Acknowledger: acknowledger, DeliveryTag: 1, Body: []byte("not json")}
+	deliveries <- amqp091.Delivery{Acknowledger: acknowledger, DeliveryTag: 2, Body: []byte(`{"type": "pause"}`)}
+	close(deliveries)
+
+	rx := &CommandRx{&Channel{deliveries: deliveries}, slog.Default()}
+
+	handled := 0
+	rx.OnReceive(func(command model.Command) {
+		handled += 1
+	}, nil)
+
+	assert.Equal(t, 1, handled, "Expected only the well formed command to be handled")
+	assert.Equal(t, []uint64{1, 2}, acknowledger.acked, "Expected malformed commands to be acknowledged")
+}
@@ -0,0 +1,406 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const min_reconnect_delay = 500 * time.Millisecond
+const max_reconnect_delay = 30 * time.Second
+
+// outgoing messages buffered per channel while the broker is unreachable.
+// once full, the oldest messages are dropped
+const max_buffered_publishings = 10000
+
+var ErrConnectionClosed = errors.New("connection closed")
+
+// amqpConnection and amqpChannel are the parts of amqp091 connections and
+// channels that reconnecting relies on, so that tests can fake the broker
+type amqpConnection interface {
+	Channel() (amqpChannel, error)
+	NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error
+	Close() error
+}
+
+type amqpChannel interface {
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp091.Publishing) error
+	NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error
+	Close() error
+}
+
+type dialer func(uri string) (amqpConnection, error)
+
+type brokerConnection struct {
+	*amqp091.Connection
+}
+
+func (conn brokerConnection) Channel() (amqpChannel, error) {
+	ch, err := conn.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+func dialBroker(uri string) (amqpConnection, error) {
+	conn, err := amqp091.Dial(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return brokerConnection{conn}, nil
+}
+
+// Connection wraps an AMQP connection and re-dials it with backoff whenever
+// the broker goes away. Channels opened through it are re-opened (and their
+// exchanges and queues re-declared) once the connection is back.
+type Connection struct {
+	uri       string
+	dial      dialer
+	mu        sync.Mutex
+	conn      amqpConnection
+	connected chan struct{} // closed while conn is usable
+	closed    bool
+}
+
+// Channel is an AMQP channel that survives reconnects. Messages published
+// while it is down are buffered and sent once it is re-opened.
+type Channel struct {
+	conn       *Connection
+	setup      func(ch amqpChannel) (<-chan amqp091.Delivery, error)
+	mu         sync.Mutex
+	ch         amqpChannel
+	pending    []publishing
+	closed     bool
+	done       chan struct{} // closed by Close
+	deliveries chan amqp091.Delivery
+}
+
+type publishing struct {
+	exchange    string
+	routing_key string
+	msg         amqp091.Publishing
+}
+
+func Dial(uri string) (*Connection, error) {
+	return dial(uri, dialBroker)
+}
+
+func dial(uri string, dial_ dialer) (*Connection, error) {
+	conn, err := dial_(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	connected := make(chan struct{})
+	close(connected)
+
+	c := &Connection{
+		uri:       uri,
+		dial:      dial_,
+		conn:      conn,
+		connected: connected,
+	}
+
+	go c.maintain(conn)
+
+	return c, nil
+}
+
+// Publisher opens a channel for publishing. setup declares whatever the
+// channel publishes to and runs again after every reconnect.
+func (c *Connection) Publisher(setup func(ch *amqp091.Channel) error) *Channel {
+	return c.open(func(ch amqpChannel) (<-chan amqp091.Delivery, error) {
+		return nil, setup(ch.(*amqp091.Channel))
+	})
+}
+
+// Consumer opens a channel for consuming. setup declares the queue, starts
+// consuming from it and runs again after every reconnect. Deliveries from
+// every incarnation of the channel arrive on Deliveries.
+func (c *Connection) Consumer(setup func(ch *amqp091.Channel) (<-chan amqp091.Delivery, error)) *Channel {
+	return c.open(func(ch amqpChannel) (<-chan amqp091.Delivery, error) {
+		return setup(ch.(*amqp091.Channel))
+	})
+}
+
+func (c *Connection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+
+	if c.conn == nil {
+		// wake up channels waiting for a reconnect so they can give up
+		close(c.connected)
+		return nil
+	}
+
+	return c.conn.Close()
+}
+
+// open takes setups on amqpChannel, which are *amqp091.Channel for
+// connections made by Dial
+func (c *Connection) open(setup func(ch amqpChannel) (<-chan amqp091.Delivery, error)) *Channel {
+	ch := &Channel{
+		conn:       c,
+		setup:      setup,
+		pending:    make([]publishing, 0),
+		done:       make(chan struct{}),
+		deliveries: make(chan amqp091.Delivery),
+	}
+
+	ready := make(chan struct{})
+	go ch.maintain(ready)
+
+	// wait for the first attempt so that queues exist before we return
+	<-ready
+
+	return ch
+}
+
+// maintain waits for conn to close and re-dials until it succeeds or the
+// connection is closed on purpose
+func (c *Connection) maintain(conn amqpConnection) {
+	for {
+		err := <-conn.NotifyClose(make(chan *amqp091.Error, 1))
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			return
+		}
+
+		slog.Warn("lost connection to rabbit", "error", err)
+		c.conn = nil
+		c.connected = make(chan struct{})
+		c.mu.Unlock()
+
+		conn = c.redial()
+		if conn == nil {
+			return
+		}
+	}
+}
+
+func (c *Connection) redial() amqpConnection {
+	for delay := min_reconnect_delay; ; delay = min(delay*2, max_reconnect_delay) {
+		time.Sleep(delay)
+
+		conn, err := c.dial(c.uri)
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+
+			if conn != nil {
+				conn.Close()
+			}
+
+			return nil
+		}
+
+		if err != nil {
+			c.mu.Unlock()
+			slog.Warn("failed to reconnect to rabbit", "retry_in", min(delay*2, max_reconnect_delay), "error", err)
+			continue
+		}
+
+		slog.Info("reconnected to rabbit")
+		c.conn = conn
+		close(c.connected)
+		c.mu.Unlock()
+
+		return conn
+	}
+}
+
+// channel blocks until a channel can be opened or the connection or done is
+// closed
+func (c *Connection) channel(done <-chan struct{}) (amqpChannel, error) {
+	for {
+		c.mu.Lock()
+		conn, connected, closed := c.conn, c.connected, c.closed
+		c.mu.Unlock()
+
+		if closed {
+			return nil, ErrConnectionClosed
+		}
+
+		if conn == nil {
+			select {
+			case <-connected:
+			case <-done:
+				return nil, ErrConnectionClosed
+			}
+
+			continue
+		}
+
+		ch, err := conn.Channel()
+		if err == nil {
+			return ch, nil
+		}
+
+		// the connection is going away but maintain hasn't noticed yet
+		time.Sleep(min_reconnect_delay)
+	}
+}
+
+func (ch *Channel) Deliveries() <-chan amqp091.Delivery {
+	return ch.deliveries
+}
+
+// Publish sends a message, or buffers it if the channel is currently down.
+// Only messages that can't be buffered are reported as errors.
+func (ch *Channel) Publish(exchange, routing_key string, msg amqp091.Publishing) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.closed {
+		return ErrConnectionClosed
+	}
+
+	ch.pending = append(ch.pending, publishing{exchange, routing_key, msg})
+
+	if len(ch.pending) > max_buffered_publishings {
+		slog.Warn("dropping buffered message, buffer full", "routing_key", ch.pending[0].routing_key)
+		ch.pending = ch.pending[1:]
+	}
+
+	ch.flush()
+
+	return nil
+}
+
+func (ch *Channel) Close() error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if !ch.closed {
+		ch.closed = true
+		close(ch.done)
+	}
+
+	if ch.ch == nil {
+		return nil
+	}
+
+	return ch.ch.Close()
+}
+
+// flush must be called with the lock held
+func (ch *Channel) flush() {
+	if ch.ch == nil {
+		return
+	}
+
+	for len(ch.pending) > 0 {
+		next := ch.pending[0]
+
+		err := ch.ch.PublishWithContext(context.Background(),
+			next.exchange,    // exchange
+			next.routing_key, // routing key
+			false,            // mandatory
+			false,            // immediate
+			next.msg,
+		)
+
+		if err != nil {
+			slog.Warn("failed to publish message, buffering until reconnected", "error", err)
+			return
+		}
+
+		ch.pending = ch.pending[1:]
+	}
+}
+
+// maintain (re)opens the underlying channel every time it closes until the
+// channel or its connection is closed on purpose
+func (ch *Channel) maintain(ready chan struct{}) {
+	defer close(ch.deliveries)
+
+	for delay := min_reconnect_delay; ; {
+		amqp_ch, err := ch.conn.channel(ch.done)
+		if err != nil {
+			closeOnce(ready)
+			return
+		}
+
+		deliveries, err := ch.setup(amqp_ch)
+		if err != nil {
+			slog.Warn("failed to set up channel", "retry_in", delay, "error", err)
+			amqp_ch.Close()
+			closeOnce(ready)
+
+			time.Sleep(delay)
+			delay = min(delay*2, max_reconnect_delay)
+
+			continue
+		}
+
+		delay = min_reconnect_delay
+		closed := amqp_ch.NotifyClose(make(chan *amqp091.Error, 1))
+
+		ch.mu.Lock()
+		if ch.closed {
+			ch.mu.Unlock()
+			amqp_ch.Close()
+			closeOnce(ready)
+
+			return
+		}
+
+		ch.ch = amqp_ch
+		ch.flush()
+		ch.mu.Unlock()
+
+		closeOnce(ready)
+
+		if deliveries != nil {
+			for delivery := range deliveries {
+				// nobody may be reading once the channel is closed
+				select {
+				case ch.deliveries <- delivery:
+				case <-ch.done:
+					return
+				}
+			}
+
+			// consumption can stop without the channel closing, e.g. when the
+			// queue is deleted. closing the channel sets everything up again
+			amqp_ch.Close()
+		}
+
+		err = <-closed
+
+		ch.mu.Lock()
+		ch.ch = nil
+		is_closed := ch.closed
+		ch.mu.Unlock()
+
+		if is_closed {
+			return
+		}
+
+		slog.Warn("channel closed, re-opening", "error", err)
+	}
+}
+
+func closeOnce(ready chan struct{}) {
+	select {
+	case <-ready:
+	default:
+		close(ready)
+	}
+}
@@ -0,0 +1,343 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBroker hands out fake connections that tests can drop
+type fakeBroker struct {
+	mu          sync.Mutex
+	unreachable bool
+	connections []*fakeConnection
+}
+
+func (broker *fakeBroker) dial(uri string) (amqpConnection, error) {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	if broker.unreachable {
+		return nil, errors.New("broker unreachable")
+	}
+
+	conn := &fakeConnection{}
+	broker.connections = append(broker.connections, conn)
+
+	return conn, nil
+}
+
+func (broker *fakeBroker) setUnreachable(unreachable bool) {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	broker.unreachable = unreachable
+}
+
+// channel returns the idx-th channel opened on the idx-th connection, nil
+// until it has been opened
+func (broker *fakeBroker) channel(conn_idx, ch_idx int) *fakeChannel {
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+
+	if conn_idx >= len(broker.connections) {
+		return nil
+	}
+
+	conn := broker.connections[conn_idx]
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if ch_idx >= len(conn.channels) {
+		return nil
+	}
+
+	return conn.channels[ch_idx]
+}
+
+func (broker *fakeBroker) drop(conn_idx int) {
+	broker.mu.Lock()
+	conn := broker.connections[conn_idx]
+	broker.mu.Unlock()
+
+	conn.shutdown(amqp091.ErrClosed)
+}
+
+type fakeConnection struct {
+	mu       sync.Mutex
+	closed   bool
+	notify   []chan *amqp091.Error
+	channels []*fakeChannel
+}
+
+func (conn *fakeConnection) Channel() (amqpChannel, error) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.closed {
+		return nil, amqp091.ErrClosed
+	}
+
+	ch := &fakeChannel{deliveries: make(chan amqp091.Delivery)}
+	conn.channels = append(conn.channels, ch)
+
+	return ch, nil
+}
+
+func (conn *fakeConnection) NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.closed {
+		close(receiver)
+	} else {
+		conn.notify = append(conn.notify, receiver)
+	}
+
+	return receiver
+}
+
+func (conn *fakeConnection) Close() error {
+	conn.shutdown(nil)
+	return nil
+}
+
+// shutdown closes the connection and its channels, reporting err to
+// listeners unless the connection was closed on purpose
+func (conn *fakeConnection) shutdown(err *amqp091.Error) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.closed {
+		return
+	}
+
+	conn.closed = true
+
+	for _, ch := range conn.channels {
+		ch.shutdown(err)
+	}
+
+	notifyClosed(conn.notify, err)
+}
+
+type fakeChannel struct {
+	mu         sync.Mutex
+	closed     bool
+	notify     []chan *amqp091.Error
+	published  []string
+	deliveries chan amqp091.Delivery
+}
+
+func (ch *fakeChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp091.Publishing) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.closed {
+		return amqp091.ErrClosed
+	}
+
+	ch.published = append(ch.published, string(msg.Body))
+
+	return nil
+}
+
+func (ch *fakeChannel) NotifyClose(receiver chan *amqp091.Error) chan *amqp091.Error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.closed {
+		close(receiver)
+	} else {
+		ch.notify = append(ch.notify, receiver)
+	}
+
+	return receiver
+}
+
+func (ch *fakeChannel) Close() error {
+	ch.shutdown(nil)
+	return nil
+}
+
+func (ch *fakeChannel) shutdown(err *amqp091.Error) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	if ch.closed {
+		return
+	}
+
+	ch.closed = true
+	close(ch.deliveries)
+
+	notifyClosed(ch.notify, err)
+}
+
+func (ch *fakeChannel) publishedBodies() []string {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	return append([]string{}, ch.published...)
+}
+
+// notifyClosed mimics amqp091, which only reports errors of connections and
+// channels that weren't closed on purpose
+func notifyClosed(receivers []chan *amqp091.Error, err *amqp091.Error) {
+	for _, receiver := range receivers {
+		if err != nil {
+			receiver <- err
+		}
+
+		close(receiver)
+	}
+}
+
+func publisherSetup(ch amqpChannel) (<-chan amqp091.Delivery, error) {
+	return nil, nil
+}
+
+func consumerSetup(ch amqpChannel) (<-chan amqp091.Delivery, error) {
+	return ch.(*fakeChannel).deliveries, nil
+}
+
+func publish(t *testing.T, ch *Channel, body string) {
+	if err := ch.Publish("", "test", amqp091.Publishing{Body: []byte(body)}); err != nil {
+		t.Fatalf("Test failed because the message couldn't be published: %s", err)
+	}
+}
+
+func receive(t *testing.T, ch *Channel) string {
+	select {
+	case delivery := <-ch.Deliveries():
+		return string(delivery.Body)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test failed because nothing was delivered")
+		return ""
+	}
+}
+
+func TestChannelFlushesBufferedPublishesAfterReconnecting(t *testing.T) {
+	broker := &fakeBroker{}
+
+	conn, err := dial("amqp://test", broker.dial)
+	if err != nil {
+		t.Fatalf("Test failed because the broker couldn't be dialed: %s", err)
+	}
+	defer conn.Close()
+
+	ch := conn.open(publisherSetup)
+	defer ch.Close()
+
+	publish(t, ch, "before")
+	broker.drop(0)
+	publish(t, ch, "while down")
+
+	assert.Eventually(t, func() bool {
+		reopened := broker.channel(1, 0)
+		return reopened != nil && len(reopened.publishedBodies()) > 0
+	}, 5*time.Second, 10*time.Millisecond, "Expected the channel to be re-opened on a new connection")
+
+	assert.Equal(t, []string{"before"}, broker.channel(0, 0).publishedBodies())
+	assert.Equal(t, []string{"while down"}, broker.channel(1, 0).publishedBodies(), "Expected messages published while down to be sent after reconnecting")
+}
+
+func TestChannelDropsTheOldestBufferedPublishesWhenFull(t *testing.T) {
+	broker := &fakeBroker{}
+
+	conn, err := dial("amqp://test", broker.dial)
+	if err != nil {
+		t.Fatalf("Test failed because the broker couldn't be dialed: %s", err)
+	}
+	defer conn.Close()
+
+	ch := conn.open(publisherSetup)
+	defer ch.Close()
+
+	broker.setUnreachable(true)
+	broker.drop(0)
+
+	assert.Eventually(t, func() bool {
+		ch.mu.Lock()
+		defer ch.mu.Unlock()
+
+		return ch.ch == nil
+	}, 5*time.Second, 10*time.Millisecond, "Expected the channel to notice the dropped connection")
+
+	for i := 0; i <= max_buffered_publishings; i++ {
+		publish(t, ch, fmt.Sprint(i))
+	}
+
+	broker.setUnreachable(false)
+
+	assert.Eventually(t, func() bool {
+		reopened := broker.channel(1, 0)
+		return reopened != nil && len(reopened.publishedBodies()) >= max_buffered_publishings
+	}, 10*time.Second, 10*time.Millisecond, "Expected the buffered messages to be sent once the broker is back")
+
+	published := broker.channel(1, 0).publishedBodies()
+	if assert.Len(t, published, max_buffered_publishings, "Expected the buffer to be capped") {
+		assert.Equal(t, "1", published[0], "Expected the oldest message to be dropped")
+		assert.Equal(t, fmt.Sprint(max_buffered_publishings), published[len(published)-1])
+	}
+}
+
+func TestConsumerKeepsDeliveringAfterReconnecting(t *testing.T) {
+	broker := &fakeBroker{}
+
+	conn, err := dial("amqp://test", broker.dial)
+	if err != nil {
+		t.Fatalf("Test failed because the broker couldn't be dialed: %s", err)
+	}
+	defer conn.Close()
+
+	ch := conn.open(consumerSetup)
+	defer ch.Close()
+
+	go func() {
+		broker.channel(0, 0).deliveries <- amqp091.Delivery{Body: []byte("first")}
+	}()
+	assert.Equal(t, "first", receive(t, ch))
+
+	broker.drop(0)
+
+	assert.Eventually(t, func() bool {
+		return broker.channel(1, 0) != nil
+	}, 5*time.Second, 10*time.Millisecond, "Expected the consumer to be set up again on a new connection")
+
+	go func() {
+		broker.channel(1, 0).deliveries <- amqp091.Delivery{Body: []byte("second")}
+	}()
+	assert.Equal(t, "second", receive(t, ch), "Expected deliveries of the new channel to arrive on the same Deliveries")
+}
+
+func TestClosingConsumerStopsWaitingForAReader(t *testing.T) {
+	broker := &fakeBroker{}
+
+	conn, err := dial("amqp://test", broker.dial)
+	if err != nil {
+		t.Fatalf("Test failed because the broker couldn't be dialed: %s", err)
+	}
+	defer conn.Close()
+
+	ch := conn.open(consumerSetup)
+
+	// taken by the channel, which then waits for someone to read it
+	broker.channel(0, 0).deliveries <- amqp091.Delivery{Body: []byte("unread")}
+
+	ch.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	select {
+	case _, ok := <-ch.Deliveries():
+		assert.False(t, ok, "Expected closing the channel to drop the unread delivery and stop forwarding")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test failed because Deliveries was never closed")
+	}
+}
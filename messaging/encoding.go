@@ -0,0 +1,79 @@
+package messaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/geo"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const JSONEncoding Encoding = "json"
+const MessagePackEncoding Encoding = "msgpack"
+
+// Encoding is the wire format of the notifications sent to a subscriber.
+// MessagePack uses the same field names as JSON but encodes uuids as 16
+// byte binaries and times as MessagePack timestamps.
+type Encoding string
+
+func init() {
+	// features only know how to encode themselves as GeoJSON, so re-encode
+	// that document rather than the go-geom internals
+	msgpack.Register(geo.Feature{}, func(enc *msgpack.Encoder, v reflect.Value) error {
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+
+		var feature interface{}
+		if err := json.Unmarshal(data, &feature); err != nil {
+			return err
+		}
+
+		return enc.Encode(feature)
+	}, nil)
+}
+
+// ParseEncoding accepts the encoding names used in configs and query
+// strings. An empty name means JSON.
+func ParseEncoding(name string) (Encoding, error) {
+	switch encoding := Encoding(name); encoding {
+	case "":
+		return JSONEncoding, nil
+	case JSONEncoding, MessagePackEncoding:
+		return encoding, nil
+	default:
+		return "", fmt.Errorf("unknown encoding %q", name)
+	}
+}
+
+// ContentType is the MIME type announced alongside encoded messages
+func (encoding Encoding) ContentType() string {
+	switch encoding {
+	case MessagePackEncoding:
+		return "application/msgpack"
+	default:
+		return "application/json"
+	}
+}
+
+func (encoding Encoding) Marshal(v interface{}) ([]byte, error) {
+	switch encoding {
+	case MessagePackEncoding:
+		var buf bytes.Buffer
+
+		enc := msgpack.NewEncoder(&buf)
+		enc.SetCustomStructTag("json")
+		enc.UseCompactInts(true)
+
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	default:
+		return json.Marshal(v)
+	}
+}
@@ -0,0 +1,36 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMessagePackEncodingUsesJSONFieldNames(t *testing.T) {
+	id := uuid.New()
+
+	data, err := MessagePackEncoding.Marshal(NewNotification(EventNotification, &logger.Event{
+		Type:    model.AgentStateUpdate,
+		Payload: model.AgentStateUpdatePayload{Id: id, State: model.Infected},
+	}))
+	if err != nil {
+		t.Fatalf("Test failed due to the following encoding error: %s", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := msgpack.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Test failed due to the following decoding error: %s", err)
+	}
+
+	event := decoded["payload"].(map[string]interface{})
+	payload := event["payload"].(map[string]interface{})
+
+	assert.Equal(t, string(EventNotification), decoded["type"], "Expected the envelope to use the json field names")
+	assert.Equal(t, string(model.Infected), payload["state"], "Expected the payload to use the json field names")
+	assert.Equal(t, id[:], payload["id"], "Expected uuids to be encoded as 16 bytes")
+	assert.Equal(t, "application/msgpack", MessagePackEncoding.ContentType(), "Expected the content type to name the encoding")
+}
@@ -1,71 +1,117 @@
 package messaging
 
 import (
-	"context"
-	"encoding/json"
-	"fmt"
+	"log/slog"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
 	"github.com/CoralCoralCoralCoral/simulation-engine/model"
 	"github.com/google/uuid"
-	"github.com/rabbitmq/amqp091-go"
 )
 
 type EventTx struct {
-	api_id uuid.UUID
-	sim_id uuid.UUID
-	ch     *amqp091.Channel
+	publisher Publisher
+	batch     bool
+	pending   []*logger.Event
+	agents    *agentStream
+	log       *slog.Logger
 }
 
-func NewEventTx(conn *amqp091.Connection, api_id, sim_id uuid.UUID) *EventTx {
-	ch, err := conn.Channel()
-	failOnError(err, "failed to create channel")
-
-	err = ch.ExchangeDeclare(NOTIFICATION_EXCHANGE, "topic", false, true, false, false, nil)
-	failOnError(err, "failed to create exchange")
-
+// NewEventTx publishes the events of the simulation configured by config
+// with the encoding and batching it asks for.
+func NewEventTx(transport Transport, api_id uuid.UUID, config *model.Config) *EventTx {
 	return &EventTx{
-		api_id,
-		sim_id,
-		ch,
+		publisher: transport.NewPublisher(api_id, config.Id, configEncoding(config)),
+		batch:     config.BatchEvents,
+		pending:   make([]*logger.Event, 0),
+		agents:    newAgentStream(config.AgentStream),
+		log:       slog.With("api_id", api_id, "sim_id", config.Id),
 	}
 }
 
 func (tx *EventTx) NewEventSubscriber() func(event *logger.Event) {
 	return func(event *logger.Event) {
+		// agent events of the last hour go out before the simulation ends
+		if payload, ok := event.Payload.(model.SimulationEndedPayload); ok {
+			tx.sendAgentEvents(tx.agents.flush(payload.Epoch, payload.Time))
+		}
+
 		switch event.Type {
-		case model.SimulationInitialized, model.PolicyUpdate, model.CommandProcessed, model.BudgetUpdate:
-			tx.send(event)
+		case model.SimulationInitialized, model.SimulationInitFailed, model.SimulationStateUpdate, model.SimulationEnded, model.PolicyUpdate, model.CommandProcessed, model.BudgetUpdate:
+			if tx.batch {
+				tx.pending = append(tx.pending, event)
+			} else {
+				tx.Send(event)
+			}
+		case model.AgentStateUpdate, model.AgentLocationUpdate:
+			tx.agents.add(event)
 		default:
 			// ignore other types of events
 		}
+
+		switch payload := event.Payload.(type) {
+		case model.CommandProcessedPayload:
+			if config, ok := payload.Command.Payload.(*model.AgentStreamConfig); ok {
+				tx.agents.configure(*config)
+			}
+
+			// a paused simulation runs no epochs, so commands and state
+			// changes are flushed right away
+			tx.flush()
+		case model.SimulationStateUpdatePayload:
+			tx.flush()
+		case model.EpochEndPayload:
+			tx.flush()
+			tx.sendAgentEvents(tx.agents.endEpoch(payload))
+		case model.SimulationEndedPayload:
+			// nothing is logged after the simulation ends, so flush then as well
+			tx.flush()
+		}
 	}
 }
 
 func (tx *EventTx) Close() {
-	tx.ch.Close()
+	tx.publisher.Close()
+}
+
+func (tx *EventTx) Send(event *logger.Event) {
+	err := tx.publisher.Publish(NewNotification(EventNotification, event))
+
+	if err != nil {
+		tx.log.Error("failed to publish event", "event_type", event.Type, "error", err)
+	}
+}
+
+func (tx *EventTx) flush() {
+	if len(tx.pending) == 0 {
+		return
+	}
+
+	err := tx.publisher.Publish(NewNotification(EventBatchNotification, tx.pending))
+	if err != nil {
+		tx.log.Error("failed to publish batch of events", "events", len(tx.pending), "error", err)
+	}
+
+	tx.pending = make([]*logger.Event, 0)
+}
+
+func (tx *EventTx) sendAgentEvents(batch *AgentEventBatch) {
+	if batch == nil {
+		return
+	}
+
+	err := tx.publisher.Publish(NewNotification(AgentEventsNotification, batch))
+	if err != nil {
+		tx.log.Error("failed to publish batch of agent events", "events", len(batch.Events), "error", err)
+	}
 }
 
-func (tx *EventTx) send(event *logger.Event) {
-	routing_key := fmt.Sprintf("%s.%s", tx.api_id, tx.sim_id)
-
-	body, err := json.Marshal(
-		Notification{
-			Type:    EventNotification,
-			Payload: event,
-		},
-	)
-	failOnError(err, "failed to json serialize event")
-
-	err = tx.ch.PublishWithContext(context.Background(),
-		NOTIFICATION_EXCHANGE, // exchange
-		routing_key,           // routing key
-		false,                 // mandatory
-		false,                 // immediate
-		amqp091.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		})
-
-	failOnError(err, "Failed to publish message")
+// configEncoding falls back to JSON for encodings it doesn't know
+func configEncoding(config *model.Config) Encoding {
+	encoding, err := ParseEncoding(config.NotificationEncoding)
+	if err != nil {
+		slog.Warn("falling back to default encoding", "sim_id", config.Id, "encoding", JSONEncoding, "error", err)
+		return JSONEncoding
+	}
+
+	return encoding
 }
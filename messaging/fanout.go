@@ -0,0 +1,93 @@
+package messaging
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// notifications queued per subscriber before the subscriber is dropped
+const subscriber_buffer = 256
+
+// fanout delivers the notifications of a MemoryTransport to the network
+// clients of the HTTP and gRPC transports. subscribers that can't keep up
+// are dropped rather than slowing down the simulation.
+type fanout struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber receives the notifications matching its ids. nil ids match
+// every simulation. send is closed once the subscriber is removed.
+type subscriber struct {
+	api_id uuid.UUID
+	sim_id uuid.UUID
+	send   chan AddressedNotification
+}
+
+func newFanout(notifications <-chan AddressedNotification) *fanout {
+	f := &fanout{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+
+	go f.run(notifications)
+
+	return f
+}
+
+func (f *fanout) subscribe(api_id, sim_id uuid.UUID) *subscriber {
+	sub := &subscriber{
+		api_id: api_id,
+		sim_id: sim_id,
+		send:   make(chan AddressedNotification, subscriber_buffer),
+	}
+
+	f.mu.Lock()
+	f.subscribers[sub] = struct{}{}
+	f.mu.Unlock()
+
+	return sub
+}
+
+func (f *fanout) unsubscribe(sub *subscriber) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.subscribers[sub]; ok {
+		delete(f.subscribers, sub)
+		close(sub.send)
+	}
+}
+
+func (f *fanout) run(notifications <-chan AddressedNotification) {
+	for notification := range notifications {
+		f.mu.Lock()
+		for sub := range f.subscribers {
+			if !sub.wants(&notification) {
+				continue
+			}
+
+			select {
+			case sub.send <- notification:
+			default:
+				slog.Warn("dropping subscriber that can't keep up", "api_id", sub.api_id, "sim_id", sub.sim_id)
+				delete(f.subscribers, sub)
+				close(sub.send)
+			}
+		}
+		f.mu.Unlock()
+	}
+}
+
+func (sub *subscriber) wants(notification *AddressedNotification) bool {
+	if sub.sim_id != uuid.Nil && sub.sim_id != notification.SimId {
+		return false
+	}
+
+	if sub.api_id != uuid.Nil && sub.api_id != notification.ApiId {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,806 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/manager"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	pb "github.com/CoralCoralCoralCoral/simulation-engine/proto/simulationpb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func configFromProto(config *pb.Config) (model.Config, error) {
+	id, err := parseOptionalUUID(config.Id)
+	if err != nil {
+		return model.Config{}, fmt.Errorf("invalid config id: %w", err)
+	}
+
+	// unset fields keep the value of the preset
+	result := model.PresetOrDefault(config.Preset)
+	result.Id = id
+
+	setOptional(&result.TimeStep, config.TimeStep)
+	setOptional(&result.NumAgents, config.NumAgents)
+
+	setOptional(&result.MaxDays, config.MaxDays)
+	setOptional(&result.StopWhenNoInfections, config.StopWhenNoInfections)
+
+	result.NotificationEncoding = config.NotificationEncoding
+	setOptional(&result.BatchEvents, config.BatchEvents)
+	if config.AgentStream != nil {
+		result.AgentStream = agentStreamFromProto(config.AgentStream)
+	}
+
+	setOptional(&result.ComplianceProbability, config.ComplianceProbability)
+	setOptional(&result.SeeksTreatmentProbability, config.SeeksTreatmentProbability)
+	setOptional(&result.MaskFiltrationEfficiencyMean, config.MaskFiltrationEfficiencyMean)
+	setOptional(&result.MaskFiltrationEfficiencySd, config.MaskFiltrationEfficiencySd)
+	setOptional(&result.PulmonaryVentilationRateMean, config.PulmonaryVentilationRateMean)
+	setOptional(&result.PulmonaryVentilationRateSd, config.PulmonaryVentilationRateSd)
+
+	setOptional(&result.KeyWorkerProbability, config.KeyWorkerProbability)
+	setOptional(&result.RemoteWorkerProbability, config.RemoteWorkerProbability)
+
+	setOptional(&result.ComplianceFatigue, config.ComplianceFatigue)
+	setOptional(&result.RiskPerception, config.RiskPerception)
+	setOptional(&result.RiskMemory, config.RiskMemory)
+
+	setOptional(&result.IncubationPeriodMean, config.IncubationPeriodMean)
+	setOptional(&result.IncubationPeriodSd, config.IncubationPeriodSd)
+	setOptional(&result.RecoveryPeriodMean, config.RecoveryPeriodMean)
+	setOptional(&result.RecoveryPeriodSd, config.RecoveryPeriodSd)
+	setOptional(&result.ImmunityPeriodMean, config.ImmunityPeriodMean)
+	setOptional(&result.ImmunityPeriodSd, config.ImmunityPeriodSd)
+	setOptional(&result.PrehospitalizationPeriodMean, config.PrehospitalizationPeriodMean)
+	setOptional(&result.PrehospitalizationPeriodSd, config.PrehospitalizationPeriodSd)
+	setOptional(&result.HospitalizationPeriodMean, config.HospitalizationPeriodMean)
+	setOptional(&result.HospitalizationPeriodSd, config.HospitalizationPeriodSd)
+	setOptional(&result.QuantaEmissionRateMean, config.QuantaEmissionRateMean)
+	setOptional(&result.QuantaEmissionRateSd, config.QuantaEmissionRateSd)
+	setOptional(&result.HospitalizationProbability, config.HospitalizationProbability)
+	setOptional(&result.DeathProbability, config.DeathProbability)
+	setOptional(&result.AsymptomaticProbability, config.AsymptomaticProbability)
+
+	if len(config.InfectiousnessCurve) > 0 {
+		result.InfectiousnessCurve = infectiousnessFromProto(config.InfectiousnessCurve)
+	}
+	setOptional(&result.AsymptomaticInfectiousness, config.AsymptomaticInfectiousness)
+
+	setOptional(&result.ImmunityProtection, config.ImmunityProtection)
+	setOptional(&result.SeverityProtection, config.SeverityProtection)
+
+	setOptional(&result.ViralDecayRate, config.ViralDecayRate)
+	setOptional(&result.DepositionRate, config.DepositionRate)
+	setOptional(&result.FiltrationRate, config.FiltrationRate)
+
+	setOptional(&result.HouseholdCapacityMean, config.HouseholdCapacityMean)
+	setOptional(&result.HouseholdCapacitySd, config.HouseholdCapacitySd)
+	setOptional(&result.HouseholdAirChangeRateMean, config.HouseholdAirChangeRateMean)
+	setOptional(&result.HouseholdAirChangeRateSd, config.HouseholdAirChangeRateSd)
+	setOptional(&result.HouseholdVolumeMean, config.HouseholdVolumeMean)
+	setOptional(&result.HouseholdVolumeSd, config.HouseholdVolumeSd)
+
+	setOptional(&result.OfficeCapacityMean, config.OfficeCapacityMean)
+	setOptional(&result.OfficeCapacitySd, config.OfficeCapacitySd)
+	setOptional(&result.OfficeAirChangeRateMean, config.OfficeAirChangeRateMean)
+	setOptional(&result.OfficeAirChangeRateSd, config.OfficeAirChangeRateSd)
+	setOptional(&result.OfficeVolumeMean, config.OfficeVolumeMean)
+	setOptional(&result.OfficeVolumeSd, config.OfficeVolumeSd)
+
+	setOptional(&result.SocialSpaceCapacityMean, config.SocialSpaceCapacityMean)
+	setOptional(&result.SocialSpaceCapacitySd, config.SocialSpaceCapacitySd)
+	setOptional(&result.SocialSpaceAirChangeRateMean, config.SocialSpaceAirChangeRateMean)
+	setOptional(&result.SocialSpaceAirChangeRateSd, config.SocialSpaceAirChangeRateSd)
+	setOptional(&result.SocialSpaceVolumeMean, config.SocialSpaceVolumeMean)
+	setOptional(&result.SocialSpaceVolumeSd, config.SocialSpaceVolumeSd)
+
+	setOptional(&result.HealthcareSpaceCapacityMean, config.HealthcareSpaceCapacityMean)
+	setOptional(&result.HealthcareSpaceCapacitySd, config.HealthcareSpaceCapacitySd)
+	setOptional(&result.HealthcareSpaceAirChangeRateMean, config.HealthcareSpaceAirChangeRateMean)
+	setOptional(&result.HealthcareSpaceAirChangeRateSd, config.HealthcareSpaceAirChangeRateSd)
+	setOptional(&result.HealthcareSpaceVolumeMean, config.HealthcareSpaceVolumeMean)
+	setOptional(&result.HealthcareSpaceVolumeSd, config.HealthcareSpaceVolumeSd)
+	setOptional(&result.TestCapacityMean, config.TestCapacityMean)
+	setOptional(&result.TestCapacitySd, config.TestCapacitySd)
+	setOptional(&result.TestSensitivity, config.TestSensitivity)
+	setOptional(&result.TestSpecificity, config.TestSpecificity)
+
+	setOptional(&result.TransitCapacityMean, config.TransitCapacityMean)
+	setOptional(&result.TransitCapacitySd, config.TransitCapacitySd)
+	setOptional(&result.TransitAirChangeRateMean, config.TransitAirChangeRateMean)
+	setOptional(&result.TransitAirChangeRateSd, config.TransitAirChangeRateSd)
+	setOptional(&result.TransitVolumeMean, config.TransitVolumeMean)
+	setOptional(&result.TransitVolumeSd, config.TransitVolumeSd)
+	setOptional(&result.TransitSpeed, config.TransitSpeed)
+	setOptional(&result.TransitWaitTime, config.TransitWaitTime)
+
+	setOptional(&result.OutdoorCapacityMean, config.OutdoorCapacityMean)
+	setOptional(&result.OutdoorCapacitySd, config.OutdoorCapacitySd)
+	setOptional(&result.OutdoorAirChangeRateMean, config.OutdoorAirChangeRateMean)
+	setOptional(&result.OutdoorAirChangeRateSd, config.OutdoorAirChangeRateSd)
+	setOptional(&result.OutdoorVolumeMean, config.OutdoorVolumeMean)
+	setOptional(&result.OutdoorVolumeSd, config.OutdoorVolumeSd)
+	setOptional(&result.OutdoorVisitProbability, config.OutdoorVisitProbability)
+
+	if len(config.Distributions) > 0 {
+		result.Distributions = distributionsFromProto(config.Distributions)
+	}
+
+	return result, nil
+}
+
+// setOptional overwrites field with the value of an optional proto field that was set
+func setOptional[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+func optional[T any](value T) *T {
+	return &value
+}
+
+func commandFromProto(command *pb.Command) (model.Command, error) {
+	result := model.Command{
+		Version: int(command.Version),
+		Type:    model.CommandType(command.Type),
+	}
+
+	switch {
+	case result.Version == 0:
+		result.Version = 1
+	case result.Version > model.ProtocolVersion:
+		return model.Command{}, fmt.Errorf("unsupported protocol version %d, expected at most %d", result.Version, model.ProtocolVersion)
+	}
+
+	switch payload := command.Payload.(type) {
+	case nil:
+	case *pb.Command_ApplyPolicyUpdate:
+		update := payload.ApplyPolicyUpdate
+
+		result.Payload = &model.ApplyPolicyUpdatePayload{
+			JurisdictionId:         update.JurisdictionId,
+			IsMaskMandate:          update.IsMaskMandate,
+			IsSelfIsolationMandate: update.IsSelfIsolationMandate,
+			IsSelfReportingMandate: update.IsSelfReportingMandate,
+			IsLockdown:             update.IsLockdown,
+			IsWorkFromHomeMandate:  update.IsWorkFromHomeMandate,
+			TestStrategy:           (*model.TestStrategy)(update.TestStrategy),
+			TestCapacityMultiplier: update.TestCapacityMultiplier,
+			ComplianceProbability:  update.ComplianceProbability,
+			ComplianceFatigue:      update.ComplianceFatigue,
+			RiskPerception:         update.RiskPerception,
+			VentilationImprovement: ventilationImprovementFromProto(update.VentilationImprovement),
+			IsTransitMaskMandate:   update.IsTransitMaskMandate,
+			TransitCapacityCap:     update.TransitCapacityCap,
+			OutdoorCapacityCap:     update.OutdoorCapacityCap,
+			VenueCapacityCap:       update.VenueCapacityCap,
+			ClosedSpaces:           closedSpacesFromProto(update.ClosedSpaces),
+		}
+	case *pb.Command_SetSpeed:
+		result.Payload = &model.SetSpeedPayload{Speed: payload.SetSpeed.Speed}
+	case *pb.Command_Step:
+		result.Payload = &model.StepPayload{Epochs: payload.Step.Epochs}
+	case *pb.Command_RunUntil:
+		result.Payload = &model.RunUntilPayload{Day: payload.RunUntil.Day}
+	case *pb.Command_QueryJurisdiction:
+		result.Payload = &model.QueryJurisdictionPayload{JurisdictionId: payload.QueryJurisdiction.JurisdictionId}
+	case *pb.Command_QueryEntity:
+		id, err := uuid.Parse(payload.QueryEntity.Id)
+		if err != nil {
+			return model.Command{}, fmt.Errorf("invalid id: %w", err)
+		}
+
+		result.Payload = &model.QueryEntityPayload{Id: id}
+	case *pb.Command_SetAgentStream:
+		stream := agentStreamFromProto(payload.SetAgentStream)
+		result.Payload = &stream
+	default:
+		return model.Command{}, fmt.Errorf("unsupported payload %T", payload)
+	}
+
+	return result, nil
+}
+
+func commandToProto(command model.Command) *pb.Command {
+	result := &pb.Command{
+		Version: int32(command.Version),
+		Type:    string(command.Type),
+	}
+
+	switch payload := command.Payload.(type) {
+	case *model.ApplyPolicyUpdatePayload:
+		result.Payload = &pb.Command_ApplyPolicyUpdate{ApplyPolicyUpdate: &pb.ApplyPolicyUpdatePayload{
+			JurisdictionId:         payload.JurisdictionId,
+			IsMaskMandate:          payload.IsMaskMandate,
+			IsSelfIsolationMandate: payload.IsSelfIsolationMandate,
+			IsSelfReportingMandate: payload.IsSelfReportingMandate,
+			IsLockdown:             payload.IsLockdown,
+			IsWorkFromHomeMandate:  payload.IsWorkFromHomeMandate,
+			TestStrategy:           (*string)(payload.TestStrategy),
+			TestCapacityMultiplier: payload.TestCapacityMultiplier,
+			ComplianceProbability:  payload.ComplianceProbability,
+			ComplianceFatigue:      payload.ComplianceFatigue,
+			RiskPerception:         payload.RiskPerception,
+			VentilationImprovement: ventilationImprovementToProto(payload.VentilationImprovement),
+			IsTransitMaskMandate:   payload.IsTransitMaskMandate,
+			TransitCapacityCap:     payload.TransitCapacityCap,
+			OutdoorCapacityCap:     payload.OutdoorCapacityCap,
+			VenueCapacityCap:       payload.VenueCapacityCap,
+			ClosedSpaces:           closedSpacesToProto(payload.ClosedSpaces),
+		}}
+	case *model.SetSpeedPayload:
+		result.Payload = &pb.Command_SetSpeed{SetSpeed: &pb.SetSpeedPayload{Speed: payload.Speed}}
+	case *model.StepPayload:
+		result.Payload = &pb.Command_Step{Step: &pb.StepPayload{Epochs: payload.Epochs}}
+	case *model.RunUntilPayload:
+		result.Payload = &pb.Command_RunUntil{RunUntil: &pb.RunUntilPayload{Day: payload.Day}}
+	case *model.QueryJurisdictionPayload:
+		result.Payload = &pb.Command_QueryJurisdiction{QueryJurisdiction: &pb.QueryJurisdictionPayload{JurisdictionId: payload.JurisdictionId}}
+	case *model.QueryEntityPayload:
+		result.Payload = &pb.Command_QueryEntity{QueryEntity: &pb.QueryEntityPayload{Id: payload.Id.String()}}
+	case *model.AgentStreamConfig:
+		result.Payload = &pb.Command_SetAgentStream{SetAgentStream: agentStreamToProto(*payload)}
+	}
+
+	return result
+}
+
+func configToProto(config *model.Config) *pb.Config {
+	return &pb.Config{
+		Id:     config.Id.String(),
+		Preset: config.Preset,
+
+		TimeStep:  optional(config.TimeStep),
+		NumAgents: optional(config.NumAgents),
+
+		MaxDays:              optional(config.MaxDays),
+		StopWhenNoInfections: optional(config.StopWhenNoInfections),
+
+		NotificationEncoding: config.NotificationEncoding,
+		BatchEvents:          optional(config.BatchEvents),
+		AgentStream:          agentStreamToProto(config.AgentStream),
+
+		ComplianceProbability:        optional(config.ComplianceProbability),
+		SeeksTreatmentProbability:    optional(config.SeeksTreatmentProbability),
+		MaskFiltrationEfficiencyMean: optional(config.MaskFiltrationEfficiencyMean),
+		MaskFiltrationEfficiencySd:   optional(config.MaskFiltrationEfficiencySd),
+		PulmonaryVentilationRateMean: optional(config.PulmonaryVentilationRateMean),
+		PulmonaryVentilationRateSd:   optional(config.PulmonaryVentilationRateSd),
+
+		KeyWorkerProbability:    optional(config.KeyWorkerProbability),
+		RemoteWorkerProbability: optional(config.RemoteWorkerProbability),
+
+		ComplianceFatigue: optional(config.ComplianceFatigue),
+		RiskPerception:    optional(config.RiskPerception),
+		RiskMemory:        optional(config.RiskMemory),
+
+		IncubationPeriodMean:         optional(config.IncubationPeriodMean),
+		IncubationPeriodSd:           optional(config.IncubationPeriodSd),
+		RecoveryPeriodMean:           optional(config.RecoveryPeriodMean),
+		RecoveryPeriodSd:             optional(config.RecoveryPeriodSd),
+		ImmunityPeriodMean:           optional(config.ImmunityPeriodMean),
+		ImmunityPeriodSd:             optional(config.ImmunityPeriodSd),
+		PrehospitalizationPeriodMean: optional(config.PrehospitalizationPeriodMean),
+		PrehospitalizationPeriodSd:   optional(config.PrehospitalizationPeriodSd),
+		HospitalizationPeriodMean:    optional(config.HospitalizationPeriodMean),
+		HospitalizationPeriodSd:      optional(config.HospitalizationPeriodSd),
+		QuantaEmissionRateMean:       optional(config.QuantaEmissionRateMean),
+		QuantaEmissionRateSd:         optional(config.QuantaEmissionRateSd),
+		HospitalizationProbability:   optional(config.HospitalizationProbability),
+		DeathProbability:             optional(config.DeathProbability),
+		AsymptomaticProbability:      optional(config.AsymptomaticProbability),
+
+		InfectiousnessCurve:        infectiousnessToProto(config.InfectiousnessCurve),
+		AsymptomaticInfectiousness: optional(config.AsymptomaticInfectiousness),
+
+		ImmunityProtection: optional(config.ImmunityProtection),
+		SeverityProtection: optional(config.SeverityProtection),
+
+		ViralDecayRate: optional(config.ViralDecayRate),
+		DepositionRate: optional(config.DepositionRate),
+		FiltrationRate: optional(config.FiltrationRate),
+
+		HouseholdCapacityMean:      optional(config.HouseholdCapacityMean),
+		HouseholdCapacitySd:        optional(config.HouseholdCapacitySd),
+		HouseholdAirChangeRateMean: optional(config.HouseholdAirChangeRateMean),
+		HouseholdAirChangeRateSd:   optional(config.HouseholdAirChangeRateSd),
+		HouseholdVolumeMean:        optional(config.HouseholdVolumeMean),
+		HouseholdVolumeSd:          optional(config.HouseholdVolumeSd),
+
+		OfficeCapacityMean:      optional(config.OfficeCapacityMean),
+		OfficeCapacitySd:        optional(config.OfficeCapacitySd),
+		OfficeAirChangeRateMean: optional(config.OfficeAirChangeRateMean),
+		OfficeAirChangeRateSd:   optional(config.OfficeAirChangeRateSd),
+		OfficeVolumeMean:        optional(config.OfficeVolumeMean),
+		OfficeVolumeSd:          optional(config.OfficeVolumeSd),
+
+		SocialSpaceCapacityMean:      optional(config.SocialSpaceCapacityMean),
+		SocialSpaceCapacitySd:        optional(config.SocialSpaceCapacitySd),
+		SocialSpaceAirChangeRateMean: optional(config.SocialSpaceAirChangeRateMean),
+		SocialSpaceAirChangeRateSd:   optional(config.SocialSpaceAirChangeRateSd),
+		SocialSpaceVolumeMean:        optional(config.SocialSpaceVolumeMean),
+		SocialSpaceVolumeSd:          optional(config.SocialSpaceVolumeSd),
+
+		HealthcareSpaceCapacityMean:      optional(config.HealthcareSpaceCapacityMean),
+		HealthcareSpaceCapacitySd:        optional(config.HealthcareSpaceCapacitySd),
+		HealthcareSpaceAirChangeRateMean: optional(config.HealthcareSpaceAirChangeRateMean),
+		HealthcareSpaceAirChangeRateSd:   optional(config.HealthcareSpaceAirChangeRateSd),
+		HealthcareSpaceVolumeMean:        optional(config.HealthcareSpaceVolumeMean),
+		HealthcareSpaceVolumeSd:          optional(config.HealthcareSpaceVolumeSd),
+		TestCapacityMean:                 optional(config.TestCapacityMean),
+		TestCapacitySd:                   optional(config.TestCapacitySd),
+		TestSensitivity:                  optional(config.TestSensitivity),
+		TestSpecificity:                  optional(config.TestSpecificity),
+
+		TransitCapacityMean:      optional(config.TransitCapacityMean),
+		TransitCapacitySd:        optional(config.TransitCapacitySd),
+		TransitAirChangeRateMean: optional(config.TransitAirChangeRateMean),
+		TransitAirChangeRateSd:   optional(config.TransitAirChangeRateSd),
+		TransitVolumeMean:        optional(config.TransitVolumeMean),
+		TransitVolumeSd:          optional(config.TransitVolumeSd),
+		TransitSpeed:             optional(config.TransitSpeed),
+		TransitWaitTime:          optional(config.TransitWaitTime),
+
+		OutdoorCapacityMean:      optional(config.OutdoorCapacityMean),
+		OutdoorCapacitySd:        optional(config.OutdoorCapacitySd),
+		OutdoorAirChangeRateMean: optional(config.OutdoorAirChangeRateMean),
+		OutdoorAirChangeRateSd:   optional(config.OutdoorAirChangeRateSd),
+		OutdoorVolumeMean:        optional(config.OutdoorVolumeMean),
+		OutdoorVolumeSd:          optional(config.OutdoorVolumeSd),
+		OutdoorVisitProbability:  optional(config.OutdoorVisitProbability),
+
+		Distributions: distributionsToProto(config.Distributions),
+	}
+}
+
+func infectiousnessFromProto(points []*pb.InfectiousnessPoint) model.InfectiousnessCurve {
+	if len(points) == 0 {
+		return nil
+	}
+
+	curve := make(model.InfectiousnessCurve, 0, len(points))
+	for _, point := range points {
+		curve = append(curve, model.InfectiousnessPoint{Time: point.GetTime(), Level: point.GetLevel()})
+	}
+
+	return curve
+}
+
+func infectiousnessToProto(curve model.InfectiousnessCurve) []*pb.InfectiousnessPoint {
+	points := make([]*pb.InfectiousnessPoint, 0, len(curve))
+	for _, point := range curve {
+		points = append(points, &pb.InfectiousnessPoint{Time: point.Time, Level: point.Level})
+	}
+
+	return points
+}
+
+func distributionsFromProto(distributions map[string]*pb.Distribution) map[model.Parameter]model.Distribution {
+	if len(distributions) == 0 {
+		return nil
+	}
+
+	result := make(map[model.Parameter]model.Distribution, len(distributions))
+	for parameter, distribution := range distributions {
+		result[model.Parameter(parameter)] = model.Distribution{
+			Type:  model.DistributionType(distribution.GetType()),
+			Mean:  distribution.GetMean(),
+			Sd:    distribution.GetSd(),
+			Shape: distribution.GetShape(),
+			Scale: distribution.GetScale(),
+			Min:   distribution.GetMin(),
+			Max:   distribution.GetMax(),
+			Value: distribution.GetValue(),
+		}
+	}
+
+	return result
+}
+
+func distributionsToProto(distributions map[model.Parameter]model.Distribution) map[string]*pb.Distribution {
+	result := make(map[string]*pb.Distribution, len(distributions))
+	for parameter, distribution := range distributions {
+		result[string(parameter)] = &pb.Distribution{
+			Type:  string(distribution.Type),
+			Mean:  distribution.Mean,
+			Sd:    distribution.Sd,
+			Shape: distribution.Shape,
+			Scale: distribution.Scale,
+			Min:   distribution.Min,
+			Max:   distribution.Max,
+			Value: distribution.Value,
+		}
+	}
+
+	return result
+}
+
+func agentStreamFromProto(config *pb.AgentStreamConfig) model.AgentStreamConfig {
+	return model.AgentStreamConfig{
+		Enabled:          config.GetEnabled(),
+		SampleRate:       config.GetSampleRate(),
+		JurisdictionId:   config.GetJurisdictionId(),
+		MaxEventsPerHour: config.GetMaxEventsPerHour(),
+	}
+}
+
+func agentStreamToProto(config model.AgentStreamConfig) *pb.AgentStreamConfig {
+	return &pb.AgentStreamConfig{
+		Enabled:          config.Enabled,
+		SampleRate:       config.SampleRate,
+		JurisdictionId:   config.JurisdictionId,
+		MaxEventsPerHour: config.MaxEventsPerHour,
+	}
+}
+
+func queryResponseToProto(response model.QueryResponse) *pb.CommandResponse {
+	result := &pb.CommandResponse{
+		Type:  string(response.Type),
+		Error: response.Error,
+	}
+
+	switch payload := response.Payload.(type) {
+	case nil:
+	case model.PolicyUpdatePayload:
+		result.Payload = &pb.CommandResponse_Policy{Policy: policyUpdateToProto(payload)}
+	case model.BudgetUpdatePayload:
+		result.Payload = &pb.CommandResponse_Budget{Budget: &pb.BudgetUpdatePayload{CurrentBudget: payload.CurrentBudget}}
+	case model.EpochEndPayload:
+		result.Payload = &pb.CommandResponse_Time{Time: epochEndToProto(payload)}
+	case model.PopulationQueryResult:
+		counts := make(map[string]int64, len(payload.Counts))
+		for state, count := range payload.Counts {
+			counts[string(state)] = count
+		}
+
+		result.Payload = &pb.CommandResponse_Population{Population: &pb.PopulationQueryResult{
+			JurisdictionId: payload.JurisdictionId,
+			Total:          payload.Total,
+			Counts:         counts,
+		}}
+	case model.SpaceQueryResult:
+		result.Payload = &pb.CommandResponse_Space{Space: &pb.SpaceQueryResult{
+			Id:                   payload.Id.String(),
+			Type:                 string(payload.Type),
+			JurisdictionId:       payload.JurisdictionId,
+			Volume:               payload.Volume,
+			AirChangeRate:        payload.AirChangeRate,
+			TotalInfectiousDoses: payload.TotalInfectiousDoses,
+			Occupants:            uuidStrings(payload.Occupants),
+		}}
+	case model.AgentQueryResult:
+		result.Payload = &pb.CommandResponse_Agent{Agent: &pb.AgentQueryResult{
+			Id:                  payload.Id.String(),
+			State:               string(payload.State),
+			StateChangeEpoch:    payload.StateChangeEpoch,
+			LocationId:          payload.LocationId.String(),
+			LocationType:        string(payload.LocationType),
+			HouseholdId:         payload.HouseholdId.String(),
+			OfficeId:            payload.OfficeId.String(),
+			JurisdictionId:      payload.JurisdictionId,
+			HasInfectionProfile: payload.HasInfectionProfile,
+			HasSelfReported:     payload.HasSelfReported,
+			InfectionCount:      payload.InfectionCount,
+			Occupation:          string(payload.Occupation),
+		}}
+	case []manager.SimulationInfo:
+		simulations := make([]*pb.SimulationInfo, 0, len(payload))
+		for _, info := range payload {
+			simulation := &pb.SimulationInfo{
+				Id:              info.Id.String(),
+				ApiId:           info.ApiId.String(),
+				State:           string(info.State),
+				Epoch:           info.Epoch,
+				NumAgents:       info.NumAgents,
+				EstimatedMemory: info.EstimatedMemory,
+				QueuedAt:        timestamppb.New(info.QueuedAt),
+			}
+
+			if info.StartedAt != nil {
+				simulation.StartedAt = timestamppb.New(*info.StartedAt)
+			}
+
+			simulations = append(simulations, simulation)
+		}
+
+		result.Payload = &pb.CommandResponse_Simulations{Simulations: &pb.SimulationList{Simulations: simulations}}
+	default:
+		slog.Warn("no protobuf message for response", "command_type", response.Type, "payload_type", fmt.Sprintf("%T", payload))
+	}
+
+	return result
+}
+
+func eventToProto(notification *AddressedNotification, event *logger.Event) *pb.Event {
+	result := &pb.Event{
+		Version:      model.ProtocolVersion,
+		ApiId:        notification.ApiId.String(),
+		SimulationId: notification.SimId.String(),
+		Type:         string(event.Type),
+	}
+
+	switch payload := event.Payload.(type) {
+	case nil:
+	case model.SimulationInitializedPayload:
+		jurisdictions := make([]*pb.Jurisdiction, 0, len(payload.Jurisdictions))
+		for _, jur := range payload.Jurisdictions {
+			jurisdictions = append(jurisdictions, jurisdictionToProto(&jur))
+		}
+
+		result.Payload = &pb.Event_SimulationInitialized{SimulationInitialized: &pb.SimulationInitializedPayload{
+			Jurisdictions: jurisdictions,
+			Config:        configToProto(&payload.Config),
+		}}
+	case model.SimulationInitFailedPayload:
+		result.Payload = &pb.Event_SimulationInitFailed{SimulationInitFailed: &pb.SimulationInitFailedPayload{
+			SimulationId: payload.SimulationId.String(),
+			Errors:       payload.Errors,
+		}}
+	case model.SimulationStateUpdatePayload:
+		result.Payload = &pb.Event_SimulationStateUpdate{SimulationStateUpdate: &pb.SimulationStateUpdatePayload{
+			Epoch:         payload.Epoch,
+			State:         string(payload.State),
+			PreviousState: string(payload.PreviousState),
+			Reason:        payload.Reason,
+		}}
+	case model.SimulationEndedPayload:
+		result.Payload = &pb.Event_SimulationEnded{SimulationEnded: &pb.SimulationEndedPayload{
+			Epoch:  payload.Epoch,
+			Time:   timestamppb.New(payload.Time),
+			State:  string(payload.State),
+			Reason: payload.Reason,
+		}}
+	case model.EpochEndPayload:
+		result.Payload = &pb.Event_EpochEnd{EpochEnd: epochEndToProto(payload)}
+	case model.CommandProcessedPayload:
+		result.Payload = &pb.Event_CommandProcessed{CommandProcessed: &pb.CommandProcessedPayload{
+			Epoch:   payload.Epoch,
+			Command: commandToProto(payload.Command),
+		}}
+	case model.AgentStateUpdatePayload:
+		result.Payload = &pb.Event_AgentStateUpdate{AgentStateUpdate: &pb.AgentStateUpdatePayload{
+			Epoch:               payload.Epoch,
+			Id:                  payload.Id.String(),
+			State:               string(payload.State),
+			PreviousState:       string(payload.PreviousState),
+			HasInfectionProfile: payload.HasInfectionProfile,
+			InfectionCount:      payload.InfectionCount,
+		}}
+	case model.AgentLocationUpdatePayload:
+		result.Payload = &pb.Event_AgentLocationUpdate{AgentLocationUpdate: &pb.AgentLocationUpdatePayload{
+			Epoch:              payload.Epoch,
+			Id:                 payload.Id.String(),
+			LocationId:         payload.LocationId.String(),
+			PreviousLocationId: payload.PreviousLocationId.String(),
+		}}
+	case model.SpaceOccupancyUpdatePayload:
+		occupants := make([]*pb.SpaceOccupancyUpdatePayload_Occupant, 0, len(payload.Occupants))
+		for _, occupant := range payload.Occupants {
+			occupants = append(occupants, &pb.SpaceOccupancyUpdatePayload_Occupant{
+				Id:    occupant.Id.String(),
+				State: string(occupant.State),
+			})
+		}
+
+		result.Payload = &pb.Event_SpaceOccupancyUpdate{SpaceOccupancyUpdate: &pb.SpaceOccupancyUpdatePayload{
+			Epoch:     payload.Epoch,
+			Id:        payload.Id.String(),
+			Occupants: occupants,
+
+			DoseConcentration: payload.DoseConcentration,
+		}}
+	case model.SpaceTestingUpdatePayload:
+		result.Payload = &pb.Event_SpaceTestingUpdate{SpaceTestingUpdate: &pb.SpaceTestingUpdatePayload{
+			Epoch:     payload.Epoch,
+			Positives: payload.Positives,
+			Negatives: payload.Negatives,
+			Backlog:   payload.Backlog,
+			Capacity:  payload.Capacity,
+		}}
+	case model.PolicyUpdatePayload:
+		result.Payload = &pb.Event_PolicyUpdate{PolicyUpdate: policyUpdateToProto(payload)}
+	case model.BudgetUpdatePayload:
+		result.Payload = &pb.Event_BudgetUpdate{BudgetUpdate: &pb.BudgetUpdatePayload{CurrentBudget: payload.CurrentBudget}}
+	case model.CaseDetectedPayload:
+		result.Payload = &pb.Event_CaseDetected{CaseDetected: &pb.CaseDetectedPayload{
+			Epoch:          payload.Epoch,
+			SampleEpoch:    payload.SampleEpoch,
+			JurisdictionId: payload.JurisdictionId,
+		}}
+	case model.AgentTurnedAwayPayload:
+		result.Payload = &pb.Event_AgentTurnedAway{AgentTurnedAway: &pb.AgentTurnedAwayPayload{
+			Epoch:          payload.Epoch,
+			Id:             payload.Id.String(),
+			SpaceId:        payload.SpaceId.String(),
+			SpaceType:      string(payload.SpaceType),
+			Reason:         string(payload.Reason),
+			JurisdictionId: payload.JurisdictionId,
+		}}
+	case model.AgentWorkedFromHomePayload:
+		result.Payload = &pb.Event_AgentWorkedFromHome{AgentWorkedFromHome: &pb.AgentWorkedFromHomePayload{
+			Epoch:         payload.Epoch,
+			Id:            payload.Id.String(),
+			Occupation:    string(payload.Occupation),
+			NextMoveEpoch: payload.NextMoveEpoch,
+		}}
+	case model.ComplianceUpdatePayload:
+		result.Payload = &pb.Event_ComplianceUpdate{ComplianceUpdate: &pb.ComplianceUpdatePayload{
+			Epoch:          payload.Epoch,
+			JurisdictionId: payload.JurisdictionId,
+			ComplianceRate: payload.ComplianceRate,
+			RecentRisk:     payload.RecentRisk,
+		}}
+	case manager.AdmissionPayload:
+		result.Payload = &pb.Event_Admission{Admission: &pb.AdmissionPayload{
+			SimulationId: payload.SimulationId.String(),
+			Reason:       payload.Reason,
+		}}
+	default:
+		slog.Warn("no protobuf message for event", "event_type", event.Type, "payload_type", fmt.Sprintf("%T", payload))
+	}
+
+	return result
+}
+
+func metricsToProto(notification *AddressedNotification, jurisdiction_metrics JuristictionMetrics) *pb.MetricsUpdate {
+	jurisdictions := make(map[string]*pb.Metrics, len(jurisdiction_metrics))
+	for jur_id, metrics := range jurisdiction_metrics {
+		jurisdictions[jur_id] = &pb.Metrics{
+			Day: int64(metrics.Day),
+
+			NewInfections:          int64(metrics.NewInfections),
+			NewReinfections:        int64(metrics.NewReinfections),
+			NewHospitalizations:    int64(metrics.NewHospitalizations),
+			NewRecoveries:          int64(metrics.NewRecoveries),
+			NewDeaths:              int64(metrics.NewDeaths),
+			InfectedPopulation:     int64(metrics.InfectedPopulation),
+			InfectiousPopulation:   int64(metrics.InfectiousPopulation),
+			HospitalizedPopulation: int64(metrics.HospitalizedPopulation),
+			ImmunePopulation:       int64(metrics.ImmunePopulation),
+			DeadPopulation:         int64(metrics.DeadPopulation),
+
+			NewTests:           int64(metrics.NewTests),
+			NewPositiveTests:   int64(metrics.NewPositiveTests),
+			TotalTests:         int64(metrics.TotalTests),
+			TotalPositiveTests: int64(metrics.TotalPositiveTests),
+			TestBacklog:        int64(metrics.TestBacklog),
+			TestCapacity:       int64(metrics.TestCapacity),
+
+			NewCases:   int64(metrics.NewCases),
+			TotalCases: int64(metrics.TotalCases),
+
+			TurnedAway: int64(metrics.TurnedAway),
+
+			ComplianceRate: metrics.ComplianceRate,
+		}
+	}
+
+	return &pb.MetricsUpdate{
+		Version:       model.ProtocolVersion,
+		ApiId:         notification.ApiId.String(),
+		SimulationId:  notification.SimId.String(),
+		Jurisdictions: jurisdictions,
+	}
+}
+
+func jurisdictionToProto(jur *model.Jurisdiction) *pb.Jurisdiction {
+	result := &pb.Jurisdiction{Id: jur.Id}
+
+	if jur.Policy != nil {
+		result.Policy = policyToProto(jur.Policy)
+	}
+
+	if jur.Feature != nil {
+		feature, err := json.Marshal(jur.Feature)
+		if err != nil {
+			slog.Warn("failed to encode feature", "jurisdiction_id", jur.Id, "error", err)
+		}
+
+		result.Feature = feature
+	}
+
+	return result
+}
+
+func policyUpdateToProto(payload model.PolicyUpdatePayload) *pb.PolicyUpdatePayload {
+	return &pb.PolicyUpdatePayload{
+		JurisdictionId: payload.JurisdictionId,
+		Policy:         policyToProto(&payload.Policy),
+	}
+}
+
+func policyToProto(policy *model.Policy) *pb.Policy {
+	return &pb.Policy{
+		IsMaskMandate:          policy.IsMaskMandate,
+		IsSelfIsolationMandate: policy.IsSelfIsolationMandate,
+		IsSelfReportingMandate: policy.IsSelfReportingMandate,
+		IsLockdown:             policy.IsLockdown,
+		IsWorkFromHomeMandate:  policy.IsWorkFromHomeMandate,
+		TestStrategy:           string(policy.TestStrategy),
+		TestCapacityMultiplier: policy.TestCapacityMultiplier,
+		ComplianceProbability:  policy.ComplianceProbability,
+		ComplianceFatigue:      policy.ComplianceFatigue,
+		RiskPerception:         policy.RiskPerception,
+		VentilationImprovement: ventilationImprovementToProto(policy.VentilationImprovement),
+		IsTransitMaskMandate:   policy.IsTransitMaskMandate,
+		TransitCapacityCap:     policy.TransitCapacityCap,
+		OutdoorCapacityCap:     policy.OutdoorCapacityCap,
+		VenueCapacityCap:       policy.VenueCapacityCap,
+		ClosedSpaces:           closedSpacesToProto(policy.ClosedSpaces),
+	}
+}
+
+func closedSpacesFromProto(closed_spaces map[string]bool) map[model.SpaceType]bool {
+	if len(closed_spaces) == 0 {
+		return nil
+	}
+
+	result := make(map[model.SpaceType]bool, len(closed_spaces))
+	for space_type, is_closed := range closed_spaces {
+		result[model.SpaceType(space_type)] = is_closed
+	}
+
+	return result
+}
+
+func closedSpacesToProto(closed_spaces map[model.SpaceType]bool) map[string]bool {
+	result := make(map[string]bool, len(closed_spaces))
+	for space_type, is_closed := range closed_spaces {
+		result[string(space_type)] = is_closed
+	}
+
+	return result
+}
+
+func ventilationImprovementFromProto(improvement map[string]float64) map[model.SpaceType]float64 {
+	if len(improvement) == 0 {
+		return nil
+	}
+
+	result := make(map[model.SpaceType]float64, len(improvement))
+	for space_type, rate := range improvement {
+		result[model.SpaceType(space_type)] = rate
+	}
+
+	return result
+}
+
+func ventilationImprovementToProto(improvement map[model.SpaceType]float64) map[string]float64 {
+	result := make(map[string]float64, len(improvement))
+	for space_type, rate := range improvement {
+		result[string(space_type)] = rate
+	}
+
+	return result
+}
+
+func epochEndToProto(payload model.EpochEndPayload) *pb.EpochEndPayload {
+	return &pb.EpochEndPayload{
+		Epoch:    payload.Epoch,
+		TimeStep: payload.TimeStep,
+		Time:     timestamppb.New(payload.Time),
+	}
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+
+	return result
+}
+
+// parseOptionalUUID treats an empty string as the nil uuid
+func parseOptionalUUID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(id)
+}
@@ -0,0 +1,239 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	pb "github.com/CoralCoralCoralCoral/simulation-engine/proto/simulationpb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCTransport serves the SimulationService defined in proto/simulation.proto.
+// Like the HTTP transport it is backed by a MemoryTransport, so the
+// simulation manager doesn't need to know which of them it is driven by.
+type GRPCTransport struct {
+	*MemoryTransport
+
+	server        *grpc.Server
+	listener      net.Listener
+	notifications *fanout
+}
+
+type grpcService struct {
+	pb.UnimplementedSimulationServiceServer
+
+	transport *GRPCTransport
+}
+
+func NewGRPCTransport(addr string) (*GRPCTransport, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	memory := NewMemoryTransport()
+
+	transport := &GRPCTransport{
+		MemoryTransport: memory,
+		server:          grpc.NewServer(),
+		listener:        listener,
+		notifications:   newFanout(memory.Listen()),
+	}
+
+	pb.RegisterSimulationServiceServer(transport.server, &grpcService{transport: transport})
+
+	go func() {
+		slog.Info("listening for grpc requests", "addr", listener.Addr())
+
+		if err := transport.server.Serve(listener); err != nil {
+			slog.Error("grpc server stopped", "error", err)
+		}
+	}()
+
+	return transport, nil
+}
+
+// Addr is the address the server listens on, which is useful when it was
+// started on port 0
+func (transport *GRPCTransport) Addr() net.Addr {
+	return transport.listener.Addr()
+}
+
+func (transport *GRPCTransport) Close() error {
+	transport.MemoryTransport.Close()
+	transport.server.Stop()
+
+	return nil
+}
+
+func (service *grpcService) StartSimulation(ctx context.Context, request *pb.Config) (*pb.StartSimulationResponse, error) {
+	config, err := configFromProto(request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if config.Id == uuid.Nil {
+		config.Id = uuid.New()
+	}
+
+	// the api id scopes notifications the same way the AMQP routing key does
+	api_id := uuid.New()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-api-id"); len(values) > 0 {
+			if parsed, err := uuid.Parse(values[0]); err == nil {
+				api_id = parsed
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, command_timeout)
+	defer cancel()
+
+	if err := service.transport.SendInitContext(ctx, api_id, config); err != nil {
+		return nil, status.Error(codes.Unavailable, "engine is not accepting simulations")
+	}
+
+	return &pb.StartSimulationResponse{
+		ApiId:        api_id.String(),
+		SimulationId: config.Id.String(),
+	}, nil
+}
+
+func (service *grpcService) SendCommand(ctx context.Context, request *pb.SendCommandRequest) (*pb.CommandResponse, error) {
+	sim_id, err := uuid.Parse(request.SimulationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid simulation id")
+	}
+
+	command, err := commandFromProto(request.GetCommand())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid command: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, command_timeout)
+	defer cancel()
+
+	if !command.IsQuery() {
+		if err := service.transport.SendCommandContext(ctx, sim_id, command); errors.Is(err, ErrUnknownSimulation) {
+			return nil, status.Error(codes.NotFound, "unknown simulation")
+		} else if err != nil {
+			return nil, status.Error(codes.DeadlineExceeded, "simulation is not accepting commands")
+		}
+
+		return &pb.CommandResponse{Type: string(command.Type)}, nil
+	}
+
+	response, err := service.transport.SendQueryContext(ctx, sim_id, command)
+	if errors.Is(err, ErrUnknownSimulation) {
+		return nil, status.Error(codes.NotFound, "unknown simulation")
+	} else if err != nil {
+		return nil, status.Error(codes.DeadlineExceeded, "simulation did not answer the query")
+	}
+
+	return queryResponseToProto(response), nil
+}
+
+func (service *grpcService) SendManagerCommand(ctx context.Context, request *pb.Command) (*pb.CommandResponse, error) {
+	command, err := commandFromProto(request)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid command: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, command_timeout)
+	defer cancel()
+
+	response, err := service.transport.SendManagerCommandContext(ctx, command)
+	if err != nil {
+		return nil, status.Error(codes.DeadlineExceeded, "manager did not answer the command")
+	}
+
+	return queryResponseToProto(response), nil
+}
+
+func (service *grpcService) Events(request *pb.SubscribeRequest, stream grpc.ServerStreamingServer[pb.Event]) error {
+	return service.stream(request, stream, func(notification *AddressedNotification) (bool, error) {
+		var events []*logger.Event
+
+		// batches are unpacked since the stream already delivers events efficiently
+		switch payload := notification.Notification.Payload.(type) {
+		case *logger.Event:
+			events = []*logger.Event{payload}
+		case []*logger.Event:
+			events = payload
+		case *AgentEventBatch:
+			events = payload.Events
+		default:
+			return false, nil
+		}
+
+		for _, event := range events {
+			if err := stream.Send(eventToProto(notification, event)); err != nil {
+				return false, err
+			}
+
+			// a stream of a single simulation has nothing left to deliver
+			if request.SimulationId != "" && event.Type == model.SimulationEnded {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+}
+
+func (service *grpcService) Metrics(request *pb.SubscribeRequest, stream grpc.ServerStreamingServer[pb.MetricsUpdate]) error {
+	return service.stream(request, stream, func(notification *AddressedNotification) (bool, error) {
+		metrics, ok := notification.Notification.Payload.(JuristictionMetrics)
+		if notification.Notification.Type != MetricsNotification || !ok {
+			return false, nil
+		}
+
+		return false, stream.Send(metricsToProto(notification, metrics))
+	})
+}
+
+// stream passes the notifications matching request to send until send
+// reports that it's done, the client goes away or the server shuts down
+func (service *grpcService) stream(request *pb.SubscribeRequest, stream grpc.ServerStream, send func(notification *AddressedNotification) (bool, error)) error {
+	api_id, err := parseOptionalUUID(request.ApiId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid api id")
+	}
+
+	sim_id, err := parseOptionalUUID(request.SimulationId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid simulation id")
+	}
+
+	sub := service.transport.notifications.subscribe(api_id, sim_id)
+	defer service.transport.notifications.unsubscribe(sub)
+
+	// tell the client that nothing published from now on will be missed
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case notification, ok := <-sub.send:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "client can't keep up with the notifications")
+			}
+
+			done, err := send(&notification)
+			if err != nil || done {
+				return err
+			}
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
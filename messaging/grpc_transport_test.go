@@ -0,0 +1,161 @@
+package messaging
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	pb "github.com/CoralCoralCoralCoral/simulation-engine/proto/simulationpb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func newGRPCTestClient(t *testing.T) (*GRPCTransport, pb.SimulationServiceClient) {
+	transport, err := NewGRPCTransport("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Test failed because the server couldn't start: %s", err)
+	}
+	t.Cleanup(func() { transport.Close() })
+
+	conn, err := grpc.NewClient(transport.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Test failed because the client couldn't connect: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return transport, pb.NewSimulationServiceClient(conn)
+}
+
+func TestGRPCTransportStartsSimulationsAndStreamsEvents(t *testing.T) {
+	transport, client := newGRPCTestClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inits := make(chan model.Config, 1)
+	rx := transport.NewInitReceiver()
+	defer rx.Close()
+
+	go rx.OnReceive(func(api_id uuid.UUID, config model.Config) {
+		inits <- config
+	})
+
+	sim_id, api_id := uuid.New(), uuid.New()
+
+	events, err := client.Events(ctx, &pb.SubscribeRequest{SimulationId: sim_id.String()})
+	if err != nil {
+		t.Fatalf("Test failed because the event stream couldn't be opened: %s", err)
+	}
+
+	// headers are sent once the subscription is in place
+	events.Header()
+
+	started, err := client.StartSimulation(
+		metadata.AppendToOutgoingContext(ctx, "x-api-id", api_id.String()),
+		&pb.Config{Id: sim_id.String(), NumAgents: proto.Int64(1000), AsymptomaticProbability: proto.Float64(0)},
+	)
+	if err != nil {
+		t.Fatalf("Test failed because the simulation couldn't be started: %s", err)
+	}
+
+	config := <-inits
+
+	assert.Equal(t, api_id.String(), started.ApiId, "Expected the api id to be taken from the metadata")
+	assert.Equal(t, sim_id, config.Id, "Expected the config to reach the init receiver")
+	assert.Equal(t, int64(1000), config.NumAgents, "Expected the config to reach the init receiver")
+	assert.Equal(t, 0.0, config.AsymptomaticProbability, "Expected fields set to zero to stay zero")
+	assert.Equal(t, model.DefaultConfig().TimeStep, config.TimeStep, "Expected unset fields to take their default")
+
+	tx := NewEventTx(transport, api_id, &model.Config{Id: sim_id})
+	tx.Send(&logger.Event{
+		Type:    model.SimulationStateUpdate,
+		Payload: model.SimulationStateUpdatePayload{Epoch: 1, State: model.Running, PreviousState: model.Initializing},
+	})
+	tx.Send(&logger.Event{
+		Type:    model.SimulationEnded,
+		Payload: model.SimulationEndedPayload{Epoch: 2, State: model.Finished},
+	})
+
+	event, err := events.Recv()
+	if err != nil {
+		t.Fatalf("Test failed because no event was streamed: %s", err)
+	}
+
+	assert.Equal(t, string(model.SimulationStateUpdate), event.Type, "Expected the event type to be streamed")
+	assert.Equal(t, string(model.Running), event.GetSimulationStateUpdate().State, "Expected the payload to be converted")
+
+	event, _ = events.Recv()
+	assert.Equal(t, string(model.Finished), event.GetSimulationEnded().State, "Expected the payload to be converted")
+
+	_, err = events.Recv()
+	assert.Equal(t, io.EOF, err, "Expected the stream to end with its simulation")
+}
+
+func TestGRPCTransportAnswersQueries(t *testing.T) {
+	transport, client := newGRPCTestClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sim_id := uuid.New()
+	commands := make(chan model.Command, 1)
+
+	rx := transport.NewCommandReceiver(sim_id)
+	defer rx.Close()
+
+	go rx.OnReceive(
+		func(command model.Command) {
+			commands <- command
+		},
+		func(command model.Command) model.QueryResponse {
+			payload := command.Payload.(*model.QueryJurisdictionPayload)
+
+			return model.QueryResponse{
+				Type: command.Type,
+				Payload: model.PopulationQueryResult{
+					JurisdictionId: payload.JurisdictionId,
+					Total:          3,
+					Counts:         map[model.AgentState]int64{model.Susceptible: 3},
+				},
+			}
+		},
+	)
+
+	response, err := client.SendCommand(ctx, &pb.SendCommandRequest{
+		SimulationId: sim_id.String(),
+		Command: &pb.Command{
+			Type:    string(model.QueryPopulation),
+			Payload: &pb.Command_QueryJurisdiction{QueryJurisdiction: &pb.QueryJurisdictionPayload{JurisdictionId: "E02000001"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Test failed because the query wasn't answered: %s", err)
+	}
+
+	assert.Equal(t, "E02000001", response.GetPopulation().JurisdictionId, "Expected the query payload to reach the simulation")
+	assert.Equal(t, int64(3), response.GetPopulation().Counts[string(model.Susceptible)], "Expected the counts to be keyed by state")
+
+	is_lockdown := true
+	_, err = client.SendCommand(ctx, &pb.SendCommandRequest{
+		SimulationId: sim_id.String(),
+		Command: &pb.Command{
+			Type:    string(model.ApplyPolicyUpdate),
+			Payload: &pb.Command_ApplyPolicyUpdate{ApplyPolicyUpdate: &pb.ApplyPolicyUpdatePayload{IsLockdown: &is_lockdown}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Test failed because the command wasn't delivered: %s", err)
+	}
+
+	update := (<-commands).Payload.(*model.ApplyPolicyUpdatePayload)
+
+	assert.True(t, *update.IsLockdown, "Expected set fields to be applied")
+	assert.Nil(t, update.IsMaskMandate, "Expected unset fields to be left alone")
+}
@@ -0,0 +1,279 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// how long a command waits for its simulation to pick it up
+const command_timeout = 30 * time.Second
+
+// HTTPTransport serves the engine over HTTP so that it can run without
+// RabbitMQ. The request and notification bodies are the same JSON the
+// AMQP transport carries:
+//
+//	POST /simulations                         starts a simulation from a model.Config
+//	POST /simulations/{id}/commands           sends a model.Command (queries are answered in the response)
+//	GET  /simulations/{id}/notifications      websocket stream of the simulation's notifications
+//	GET  /notifications?api_id={id}           websocket stream of every notification (optionally for one api_id)
+//	POST /manager/commands                    sends a simulation manager command
+//
+// Websocket clients receive JSON text frames unless they ask for binary
+// MessagePack frames with ?encoding=msgpack.
+//
+// A client that needs every notification of a simulation can choose the
+// config id itself and connect the websocket before starting it.
+type HTTPTransport struct {
+	*MemoryTransport
+
+	server        *http.Server
+	upgrader      websocket.Upgrader
+	notifications *fanout
+}
+
+type StartSimulationResponse struct {
+	ApiId        uuid.UUID `json:"api_id"`
+	SimulationId uuid.UUID `json:"simulation_id"`
+}
+
+func NewHTTPTransport(addr string) *HTTPTransport {
+	memory := NewMemoryTransport()
+
+	transport := &HTTPTransport{
+		MemoryTransport: memory,
+		upgrader: websocket.Upgrader{
+			// the api is meant for local demos, so accept any front end
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		notifications: newFanout(memory.Listen()),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /simulations", transport.handleStartSimulation)
+	mux.HandleFunc("POST /simulations/{id}/commands", transport.handleCommand)
+	mux.HandleFunc("GET /simulations/{id}/notifications", transport.handleNotifications)
+	mux.HandleFunc("GET /notifications", transport.handleNotifications)
+	mux.HandleFunc("POST /manager/commands", transport.handleManagerCommand)
+
+	transport.server = &http.Server{
+		Addr:    addr,
+		Handler: allowCORS(mux),
+	}
+
+	go func() {
+		slog.Info("listening for http requests", "addr", addr)
+
+		if err := transport.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("http server stopped", "error", err)
+		}
+	}()
+
+	return transport
+}
+
+func (transport *HTTPTransport) Close() error {
+	transport.MemoryTransport.Close()
+
+	return transport.server.Close()
+}
+
+func (transport *HTTPTransport) handleStartSimulation(w http.ResponseWriter, r *http.Request) {
+	var config model.Config
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse config: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if config.Id == uuid.Nil {
+		config.Id = uuid.New()
+	}
+
+	// the api id scopes notifications the same way the AMQP routing key does
+	api_id, err := uuid.Parse(r.Header.Get("X-Api-Id"))
+	if err != nil {
+		api_id = uuid.New()
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), command_timeout)
+	defer cancel()
+
+	if err := transport.SendInitContext(ctx, api_id, config); err != nil {
+		http.Error(w, "engine is not accepting simulations", http.StatusServiceUnavailable)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, StartSimulationResponse{
+		ApiId:        api_id,
+		SimulationId: config.Id,
+	})
+}
+
+func (transport *HTTPTransport) handleCommand(w http.ResponseWriter, r *http.Request) {
+	sim_id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid simulation id", http.StatusBadRequest)
+		return
+	}
+
+	var command model.Command
+	if err := json.NewDecoder(r.Body).Decode(&command); err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse command: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), command_timeout)
+	defer cancel()
+
+	if !command.IsQuery() {
+		if err := transport.SendCommandContext(ctx, sim_id, command); errors.Is(err, ErrUnknownSimulation) {
+			http.Error(w, "unknown simulation", http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, "simulation is not accepting commands", http.StatusGatewayTimeout)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	response, err := transport.SendQueryContext(ctx, sim_id, command)
+	if errors.Is(err, ErrUnknownSimulation) {
+		http.Error(w, "unknown simulation", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "simulation did not answer the query", http.StatusGatewayTimeout)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewNotification(QueryResponseNotification, response))
+}
+
+func (transport *HTTPTransport) handleManagerCommand(w http.ResponseWriter, r *http.Request) {
+	var command model.Command
+	if err := json.NewDecoder(r.Body).Decode(&command); err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse command: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), command_timeout)
+	defer cancel()
+
+	response, err := transport.SendManagerCommandContext(ctx, command)
+	if err != nil {
+		http.Error(w, "manager did not answer the command", http.StatusGatewayTimeout)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewNotification(QueryResponseNotification, response))
+}
+
+func (transport *HTTPTransport) handleNotifications(w http.ResponseWriter, r *http.Request) {
+	var api_id, sim_id uuid.UUID
+
+	if id := r.PathValue("id"); id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			http.Error(w, "invalid simulation id", http.StatusBadRequest)
+			return
+		}
+
+		sim_id = parsed
+	}
+
+	if id := r.URL.Query().Get("api_id"); id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			http.Error(w, "invalid api id", http.StatusBadRequest)
+			return
+		}
+
+		api_id = parsed
+	}
+
+	encoding, err := ParseEncoding(r.URL.Query().Get("encoding"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// subscribe before upgrading, so that nothing published after the client
+	// sees the handshake complete is missed
+	sub := transport.notifications.subscribe(api_id, sim_id)
+
+	conn, err := transport.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied with an error
+		transport.notifications.unsubscribe(sub)
+		return
+	}
+
+	go readWebsocket(conn, func() { transport.notifications.unsubscribe(sub) })
+	writeWebsocket(conn, sub, encoding)
+}
+
+func writeWebsocket(conn *websocket.Conn, sub *subscriber, encoding Encoding) {
+	defer conn.Close()
+
+	message_type := websocket.TextMessage
+	if encoding == MessagePackEncoding {
+		message_type = websocket.BinaryMessage
+	}
+
+	for notification := range sub.send {
+		data, err := encoding.Marshal(notification.Notification)
+		if err != nil {
+			slog.Error("failed to serialize notification", "encoding", encoding, "api_id", notification.ApiId, "sim_id", notification.SimId, "error", err)
+			continue
+		}
+
+		if err := conn.WriteMessage(message_type, data); err != nil {
+			return
+		}
+	}
+
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// readWebsocket discards anything the client sends and calls done once it disconnects
+func readWebsocket(conn *websocket.Conn, done func()) {
+	defer done()
+
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Warn("failed to write http response", "error", err)
+	}
+}
+
+func allowCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Api-Id")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
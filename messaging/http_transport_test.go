@@ -0,0 +1,83 @@
+package messaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPTransportStartsSimulationsAndStreamsNotifications(t *testing.T) {
+	transport := NewHTTPTransport("127.0.0.1:0")
+	defer transport.Close()
+
+	server := httptest.NewServer(transport.server.Handler)
+	defer server.Close()
+
+	inits := make(chan model.Config, 1)
+	rx := transport.NewInitReceiver()
+	defer rx.Close()
+
+	go rx.OnReceive(func(api_id uuid.UUID, config model.Config) {
+		inits <- config
+	})
+
+	sim_id := uuid.New()
+
+	// connect before starting the simulation so that no notification is missed
+	ws_url := "ws" + strings.TrimPrefix(server.URL, "http") + "/simulations/" + sim_id.String() + "/notifications"
+	conn, _, err := websocket.DefaultDialer.Dial(ws_url, nil)
+	if err != nil {
+		t.Fatalf("Test failed because the websocket couldn't connect: %s", err)
+	}
+	defer conn.Close()
+
+	body, _ := json.Marshal(model.Config{Id: sim_id, NumAgents: 1000})
+	response, err := http.Post(server.URL+"/simulations", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Test failed due to the following request error: %s", err)
+	}
+
+	assert.Equal(t, http.StatusAccepted, response.StatusCode, "Expected the simulation to be accepted")
+
+	var started StartSimulationResponse
+	json.NewDecoder(response.Body).Decode(&started)
+	config := <-inits
+
+	assert.Equal(t, sim_id, started.SimulationId, "Expected the response to echo the simulation id")
+	assert.Equal(t, int64(1000), config.NumAgents, "Expected the config to reach the init receiver")
+
+	publisher := transport.NewPublisher(started.ApiId, sim_id, JSONEncoding)
+	publisher.Publish(Notification{Type: MetricsNotification, Payload: map[string]int{"day": 1}})
+
+	var notification map[string]interface{}
+	if err := conn.ReadJSON(&notification); err != nil {
+		t.Fatalf("Test failed because no notification was streamed: %s", err)
+	}
+
+	assert.Equal(t, string(MetricsNotification), notification["type"], "Expected the notification envelope to be streamed unchanged")
+}
+
+func TestHTTPTransportRejectsCommandsToUnknownSimulations(t *testing.T) {
+	transport := NewHTTPTransport("127.0.0.1:0")
+	defer transport.Close()
+
+	server := httptest.NewServer(transport.server.Handler)
+	defer server.Close()
+
+	body, _ := json.Marshal(model.Command{Type: model.Pause})
+	response, err := http.Post(server.URL+"/simulations/"+uuid.New().String()+"/commands", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Test failed due to the following request error: %s", err)
+	}
+
+	assert.Equal(t, http.StatusNotFound, response.StatusCode, "Expected commands to unknown simulations to be rejected right away")
+	assert.Empty(t, transport.commands, "Expected no command channel to be created for an unknown simulation")
+}
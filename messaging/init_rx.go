@@ -2,7 +2,8 @@ package messaging
 
 import (
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/model"
 	"github.com/google/uuid"
@@ -10,70 +11,63 @@ import (
 )
 
 type InitRx struct {
-	ch       *amqp091.Channel
-	messages <-chan amqp091.Delivery
+	ch *Channel
 }
 
-func NewInitRx(conn *amqp091.Connection) *InitRx {
-	ch, err := conn.Channel()
-	failOnError(err, "failed to create channel")
-
-	err = ch.ExchangeDeclare(INIT_EXCHANGE, "topic", false, true, false, false, nil)
-	failOnError(err, "failed to create exchange")
-
-	queue, err := ch.QueueDeclare(
-		INIT_EXCHANGE, // Queue name
-		false,         // Durable (survives broker restarts)
-		false,         // Auto-delete
-		false,         // Exclusive
-		false,         // No-wait
-		nil,           // Arguments
-	)
-
-	if err != nil {
-		failOnError(err, "failed to declare init-game queue")
-	}
-
-	err = ch.QueueBind(queue.Name, "#", INIT_EXCHANGE, false, nil)
-	failOnError(err, "failed to bind init-game queue to wildcard key on init-game exchange")
+func NewInitRx(conn *Connection) *InitRx {
+	ch := conn.Consumer(func(ch *amqp091.Channel) (<-chan amqp091.Delivery, error) {
+		err := ch.ExchangeDeclare(INIT_EXCHANGE, "topic", false, true, false, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exchange: %w", err)
+		}
 
-	// Set prefetch count to 1 for fair dispatch
-	err = ch.Qos(1, 0, false)
-	failOnError(err, "failed to set prefetch to 1")
+		queue, err := ch.QueueDeclare(
+			INIT_EXCHANGE, // Queue name
+			false,         // Durable (survives broker restarts)
+			false,         // Auto-delete
+			false,         // Exclusive
+			false,         // No-wait
+			nil,           // Arguments
+		)
 
-	messages, err := ch.Consume(
-		INIT_EXCHANGE, // Queue name
-		"",            // Consumer tag
-		false,         // Auto-acknowledge (set to false for manual acks)
-		false,         // Exclusive
-		false,         // No-local
-		false,         // No-wait
-		nil,           // Arguments
-	)
+		if err != nil {
+			return nil, fmt.Errorf("failed to declare init-game queue: %w", err)
+		}
 
-	failOnError(err, "failed to consume from init channel")
+		err = ch.QueueBind(queue.Name, "#", INIT_EXCHANGE, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind init-game queue to wildcard key on init-game exchange: %w", err)
+		}
 
-	rx := InitRx{
-		ch,
-		messages,
-	}
+		// Set prefetch count to 1 for fair dispatch
+		err = ch.Qos(1, 0, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set prefetch to 1: %w", err)
+		}
 
-	return &rx
+		return ch.Consume(
+			INIT_EXCHANGE, // Queue name
+			"",            // Consumer tag
+			false,         // Auto-acknowledge (set to false for manual acks)
+			false,         // Exclusive
+			false,         // No-local
+			false,         // No-wait
+			nil,           // Arguments
+		)
+	})
+
+	return &InitRx{ch}
 }
 
 func (rx *InitRx) OnReceive(handler func(api_id uuid.UUID, config model.Config)) {
 	defer rx.ch.Close()
 
-	// debugging
-	log.Println("listening for init messages")
-
-	for msg := range rx.messages {
-		// debugging
-		log.Println("received init message")
+	slog.Info("listening for init messages")
 
+	for msg := range rx.ch.Deliveries() {
 		api_id, err := extractApiId(&msg)
 		if err != nil {
-			log.Println("couldn't extract api_id from init message")
+			slog.Warn("couldn't extract api_id from init message", "error", err)
 			msg.Ack(false)
 			continue
 		}
@@ -82,13 +76,19 @@ func (rx *InitRx) OnReceive(handler func(api_id uuid.UUID, config model.Config))
 		err = json.Unmarshal(msg.Body, &config)
 
 		if err != nil {
-			log.Println("failed to parse init message")
+			slog.Warn("failed to parse init message", "api_id", api_id, "error", err)
 			msg.Ack(false)
 			continue
 		}
 
+		slog.Info("received init message", "api_id", api_id, "sim_id", config.Id)
+
 		go handler(api_id, config)
 
 		msg.Ack(false)
 	}
 }
+
+func (rx *InitRx) Close() {
+	rx.ch.Close()
+}
@@ -0,0 +1,289 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+)
+
+// MemoryTransport delivers messages over Go channels within the process. It
+// lets the engine be driven (and its notifications observed) without a broker.
+type MemoryTransport struct {
+	mu        sync.Mutex
+	inits     chan memoryInit
+	commands  map[uuid.UUID]chan memoryCommand
+	listeners []chan AddressedNotification
+	closed    chan struct{}
+}
+
+type AddressedNotification struct {
+	ApiId        uuid.UUID
+	SimId        uuid.UUID
+	Notification Notification
+}
+
+type memoryInit struct {
+	api_id uuid.UUID
+	config model.Config
+}
+
+type memoryCommand struct {
+	command model.Command
+	reply   chan model.QueryResponse
+}
+
+type memoryInitReceiver struct {
+	transport *MemoryTransport
+	done      chan struct{}
+	once      sync.Once
+}
+
+type memoryCommandReceiver struct {
+	transport *MemoryTransport
+	sim_id    uuid.UUID
+	commands  chan memoryCommand
+	done      chan struct{}
+	once      sync.Once
+}
+
+type memoryPublisher struct {
+	transport *MemoryTransport
+	api_id    uuid.UUID
+	sim_id    uuid.UUID
+}
+
+// manager commands are addressed to the nil simulation id
+var manager_id = uuid.Nil
+
+// ErrUnknownSimulation is returned for commands to simulations without a command receiver
+var ErrUnknownSimulation = errors.New("unknown simulation")
+
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{
+		inits:     make(chan memoryInit),
+		commands:  make(map[uuid.UUID]chan memoryCommand),
+		listeners: make([]chan AddressedNotification, 0),
+		closed:    make(chan struct{}),
+	}
+}
+
+// SendInit blocks until an init receiver picks up the message
+func (transport *MemoryTransport) SendInit(api_id uuid.UUID, config model.Config) {
+	transport.SendInitContext(context.Background(), api_id, config)
+}
+
+// SendCommand blocks until the simulation's command receiver picks up the
+// command. Commands to simulations without a receiver are dropped.
+func (transport *MemoryTransport) SendCommand(sim_id uuid.UUID, command model.Command) {
+	transport.SendCommandContext(context.Background(), sim_id, command)
+}
+
+// SendQuery blocks until the simulation's command receiver has answered the query
+func (transport *MemoryTransport) SendQuery(sim_id uuid.UUID, command model.Command) model.QueryResponse {
+	response, _ := transport.SendQueryContext(context.Background(), sim_id, command)
+
+	return response
+}
+
+// SendManagerCommand blocks until the manager command receiver has answered the command
+func (transport *MemoryTransport) SendManagerCommand(command model.Command) model.QueryResponse {
+	return transport.SendQuery(manager_id, command)
+}
+
+func (transport *MemoryTransport) SendInitContext(ctx context.Context, api_id uuid.UUID, config model.Config) error {
+	select {
+	case transport.inits <- memoryInit{api_id, config}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (transport *MemoryTransport) SendCommandContext(ctx context.Context, sim_id uuid.UUID, command model.Command) error {
+	commands, ok := transport.receiverChannel(sim_id)
+	if !ok {
+		return ErrUnknownSimulation
+	}
+
+	select {
+	case commands <- memoryCommand{command, nil}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (transport *MemoryTransport) SendQueryContext(ctx context.Context, sim_id uuid.UUID, command model.Command) (model.QueryResponse, error) {
+	commands, ok := transport.receiverChannel(sim_id)
+	if !ok {
+		return model.QueryResponse{}, ErrUnknownSimulation
+	}
+
+	reply := make(chan model.QueryResponse, 1)
+
+	select {
+	case commands <- memoryCommand{command, reply}:
+	case <-ctx.Done():
+		return model.QueryResponse{}, ctx.Err()
+	}
+
+	select {
+	case response := <-reply:
+		return response, nil
+	case <-ctx.Done():
+		return model.QueryResponse{}, ctx.Err()
+	}
+}
+
+func (transport *MemoryTransport) SendManagerCommandContext(ctx context.Context, command model.Command) (model.QueryResponse, error) {
+	return transport.SendQueryContext(ctx, manager_id, command)
+}
+
+// Listen returns a channel that receives every notification published
+// after the call. Publishing blocks until listeners have received it.
+func (transport *MemoryTransport) Listen() <-chan AddressedNotification {
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+
+	listener := make(chan AddressedNotification, 64)
+	transport.listeners = append(transport.listeners, listener)
+
+	return listener
+}
+
+func (transport *MemoryTransport) NewInitReceiver() InitReceiver {
+	return &memoryInitReceiver{
+		transport: transport,
+		done:      make(chan struct{}),
+	}
+}
+
+func (transport *MemoryTransport) NewCommandReceiver(sim_id uuid.UUID) CommandReceiver {
+	return &memoryCommandReceiver{
+		transport: transport,
+		sim_id:    sim_id,
+		commands:  transport.commandChannel(sim_id),
+		done:      make(chan struct{}),
+	}
+}
+
+func (transport *MemoryTransport) NewManagerCommandReceiver() CommandReceiver {
+	return transport.NewCommandReceiver(manager_id)
+}
+
+// NewPublisher ignores the encoding since notifications never leave the process
+func (transport *MemoryTransport) NewPublisher(api_id, sim_id uuid.UUID, encoding Encoding) Publisher {
+	return &memoryPublisher{transport, api_id, sim_id}
+}
+
+func (transport *MemoryTransport) Close() error {
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+
+	select {
+	case <-transport.closed:
+	default:
+		close(transport.closed)
+	}
+
+	return nil
+}
+
+func (transport *MemoryTransport) commandChannel(sim_id uuid.UUID) chan memoryCommand {
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+
+	if _, ok := transport.commands[sim_id]; !ok {
+		transport.commands[sim_id] = make(chan memoryCommand)
+	}
+
+	return transport.commands[sim_id]
+}
+
+// receiverChannel is the channel of a simulation's command receiver, if it has one
+func (transport *MemoryTransport) receiverChannel(sim_id uuid.UUID) (chan memoryCommand, bool) {
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+
+	commands, ok := transport.commands[sim_id]
+
+	return commands, ok
+}
+
+// removeCommandChannel forgets the channel of a simulation, unless a newer
+// receiver has replaced it already
+func (transport *MemoryTransport) removeCommandChannel(sim_id uuid.UUID, commands chan memoryCommand) {
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+
+	if transport.commands[sim_id] == commands {
+		delete(transport.commands, sim_id)
+	}
+}
+
+func (rx *memoryInitReceiver) OnReceive(handler func(api_id uuid.UUID, config model.Config)) {
+	for {
+		select {
+		case init := <-rx.transport.inits:
+			go handler(init.api_id, init.config)
+		case <-rx.done:
+			return
+		case <-rx.transport.closed:
+			return
+		}
+	}
+}
+
+func (rx *memoryInitReceiver) Close() {
+	rx.once.Do(func() { close(rx.done) })
+}
+
+func (rx *memoryCommandReceiver) OnReceive(handler func(command model.Command), query_handler func(command model.Command) model.QueryResponse) {
+	rx.OnRequest(func(command model.Command) model.QueryResponse {
+		if command.IsQuery() {
+			return query_handler(command)
+		}
+
+		handler(command)
+
+		return model.QueryResponse{Type: command.Type}
+	})
+}
+
+func (rx *memoryCommandReceiver) OnRequest(handler func(command model.Command) model.QueryResponse) {
+	for {
+		select {
+		case msg := <-rx.commands:
+			response := handler(msg.command)
+			if msg.reply != nil {
+				msg.reply <- response
+			}
+		case <-rx.done:
+			return
+		}
+	}
+}
+
+func (rx *memoryCommandReceiver) Close() {
+	rx.once.Do(func() {
+		close(rx.done)
+		rx.transport.removeCommandChannel(rx.sim_id, rx.commands)
+	})
+}
+
+func (publisher *memoryPublisher) Publish(notification Notification) error {
+	publisher.transport.mu.Lock()
+	listeners := publisher.transport.listeners
+	publisher.transport.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener <- AddressedNotification{publisher.api_id, publisher.sim_id, notification}
+	}
+
+	return nil
+}
+
+func (publisher *memoryPublisher) Close() {}
@@ -0,0 +1,189 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventTxPublishesSimulationEvents(t *testing.T) {
+	transport := NewMemoryTransport()
+	notifications := transport.Listen()
+
+	api_id, sim_id := uuid.New(), uuid.New()
+
+	tx := NewEventTx(transport, api_id, &model.Config{Id: sim_id})
+	defer tx.Close()
+
+	subscriber := tx.NewEventSubscriber()
+
+	// agent level events are not forwarded
+	subscriber(&logger.Event{Type: model.AgentStateUpdate, Payload: model.AgentStateUpdatePayload{}})
+	subscriber(&logger.Event{Type: model.SimulationEnded, Payload: model.SimulationEndedPayload{State: model.Finished}})
+
+	notification := <-notifications
+
+	assert.Equal(t, api_id, notification.ApiId, "Expected the notification to be addressed to the api")
+	assert.Equal(t, sim_id, notification.SimId, "Expected the notification to be addressed to the simulation")
+	assert.Equal(t, EventNotification, notification.Notification.Type, "Expected an event notification")
+	assert.Equal(t, model.SimulationEnded, notification.Notification.Payload.(*logger.Event).Type, "Expected the simulation ended event to be forwarded")
+	assert.Empty(t, notifications, "Expected the agent state update not to be forwarded")
+}
+
+func TestEventTxBatchesEventsPerEpoch(t *testing.T) {
+	transport := NewMemoryTransport()
+	notifications := transport.Listen()
+
+	tx := NewEventTx(transport, uuid.New(), &model.Config{Id: uuid.New(), BatchEvents: true})
+	defer tx.Close()
+
+	subscriber := tx.NewEventSubscriber()
+
+	subscriber(&logger.Event{Type: model.PolicyUpdate, Payload: model.PolicyUpdatePayload{}})
+	subscriber(&logger.Event{Type: model.BudgetUpdate, Payload: model.BudgetUpdatePayload{}})
+
+	assert.Empty(t, notifications, "Expected events to be held until the epoch ends")
+
+	subscriber(&logger.Event{Type: model.EpochEnd, Payload: model.EpochEndPayload{}})
+
+	notification := <-notifications
+
+	assert.Equal(t, EventBatchNotification, notification.Notification.Type, "Expected an event batch notification")
+	assert.Len(t, notification.Notification.Payload, 2, "Expected both events in one batch")
+
+	// empty epochs don't produce notifications
+	subscriber(&logger.Event{Type: model.EpochEnd, Payload: model.EpochEndPayload{}})
+	assert.Empty(t, notifications, "Expected no notification for an epoch without events")
+}
+
+func TestEventTxFlushesBatchesWhilePaused(t *testing.T) {
+	transport := NewMemoryTransport()
+	notifications := transport.Listen()
+
+	tx := NewEventTx(transport, uuid.New(), &model.Config{Id: uuid.New(), BatchEvents: true})
+	defer tx.Close()
+
+	subscriber := tx.NewEventSubscriber()
+
+	subscriber(&logger.Event{Type: model.SimulationStateUpdate, Payload: model.SimulationStateUpdatePayload{State: model.Paused}})
+	assert.Len(t, (<-notifications).Notification.Payload, 1, "Expected the state update to be sent without waiting for an epoch")
+
+	subscriber(&logger.Event{Type: model.PolicyUpdate, Payload: model.PolicyUpdatePayload{}})
+	subscriber(&logger.Event{Type: model.CommandProcessed, Payload: model.CommandProcessedPayload{Command: model.Command{Type: model.ApplyPolicyUpdate}}})
+	assert.Len(t, (<-notifications).Notification.Payload, 2, "Expected a processed command to flush the events it caused")
+}
+
+func TestEventTxStreamsSampledAgentEventsPerHour(t *testing.T) {
+	transport := NewMemoryTransport()
+	notifications := transport.Listen()
+
+	tx := NewEventTx(transport, uuid.New(), &model.Config{Id: uuid.New()})
+	defer tx.Close()
+
+	subscriber := tx.NewEventSubscriber()
+	agent_event := func() *logger.Event {
+		return &logger.Event{Type: model.AgentLocationUpdate, Payload: model.AgentLocationUpdatePayload{Id: uuid.New()}}
+	}
+	epoch_end := func(epoch int64) *logger.Event {
+		return &logger.Event{Type: model.EpochEnd, Payload: model.EpochEndPayload{Epoch: epoch, TimeStep: 15 * 60 * 1000}}
+	}
+
+	subscriber(agent_event())
+	subscriber(epoch_end(4))
+	assert.Empty(t, notifications, "Expected agent events not to be streamed unless asked for")
+
+	subscriber(&logger.Event{
+		Type: model.CommandProcessed,
+		Payload: model.CommandProcessedPayload{
+			Command: model.Command{Type: model.SetAgentStream, Payload: &model.AgentStreamConfig{Enabled: true, MaxEventsPerHour: 2}},
+		},
+	})
+	<-notifications
+
+	for epoch := int64(5); epoch < 8; epoch++ {
+		subscriber(agent_event())
+		subscriber(epoch_end(epoch))
+	}
+	assert.Empty(t, notifications, "Expected agent events to be held until the hour ends")
+
+	subscriber(epoch_end(8))
+
+	notification := <-notifications
+	batch := notification.Notification.Payload.(*AgentEventBatch)
+
+	assert.Equal(t, AgentEventsNotification, notification.Notification.Type, "Expected an agent events notification")
+	assert.Len(t, batch.Events, 2, "Expected the batch to be limited to the events allowed per hour")
+	assert.Equal(t, int64(1), batch.Dropped, "Expected the events over the limit to be counted")
+}
+
+func TestAgentStreamSamplesAgentsConsistently(t *testing.T) {
+	stream := newAgentStream(model.AgentStreamConfig{Enabled: true, SampleRate: 0.25})
+
+	num_sampled := 0
+	for i := 0; i < 10000; i++ {
+		id := uuid.New()
+		if stream.wants(id, nil) {
+			num_sampled += 1
+		}
+
+		assert.Equal(t, stream.wants(id, nil), stream.wants(id, nil), "Expected an agent to be sampled the same way every time")
+	}
+
+	assert.InDelta(t, 2500, num_sampled, 250, "Expected roughly the sample rate of agents to be streamed")
+
+	stream.configure(model.AgentStreamConfig{Enabled: true, JurisdictionId: "E02000001"})
+	assert.False(t, stream.wants(uuid.New(), nil), "Expected agents outside the jurisdiction not to be streamed")
+}
+
+func TestCommandReceiverSeparatesQueriesFromCommands(t *testing.T) {
+	transport := NewMemoryTransport()
+	sim_id := uuid.New()
+
+	rx := transport.NewCommandReceiver(sim_id)
+	defer rx.Close()
+
+	commands := make(chan model.Command, 1)
+	go rx.OnReceive(
+		func(command model.Command) {
+			commands <- command
+		},
+		func(command model.Command) model.QueryResponse {
+			return model.QueryResponse{Type: command.Type, Payload: "answer"}
+		},
+	)
+
+	transport.SendCommand(sim_id, model.Command{Type: model.Pause})
+	assert.Equal(t, model.Pause, (<-commands).Type, "Expected the command to reach the command handler")
+
+	response := transport.SendQuery(sim_id, model.Command{Type: model.QueryTime})
+	assert.Equal(t, "answer", response.Payload, "Expected the query to be answered by the query handler")
+	assert.Empty(t, commands, "Expected the query not to reach the command handler")
+}
+
+func TestClosingCommandReceiverForgetsItsChannel(t *testing.T) {
+	transport := NewMemoryTransport()
+
+	rx := transport.NewCommandReceiver(uuid.New())
+	assert.Len(t, transport.commands, 1)
+
+	rx.Close()
+	assert.Empty(t, transport.commands, "Expected the command channel of a closed receiver to be removed")
+}
+
+func TestMetricsTxPublishesACopyOfTheMetrics(t *testing.T) {
+	transport := NewMemoryTransport()
+	notifications := transport.Listen()
+
+	tx := NewMetricsTx(transport, uuid.New(), &model.Config{Id: uuid.New()})
+	defer tx.Close()
+
+	jurisdiction_metrics := JuristictionMetrics{"GLOBAL": &Metrics{Day: 1, NewInfections: 3}}
+	tx.send(jurisdiction_metrics)
+	jurisdiction_metrics.reset()
+
+	published := (<-notifications).Notification.Payload.(JuristictionMetrics)
+	assert.Equal(t, 3, published["GLOBAL"].NewInfections, "Expected the published metrics not to be reset with the aggregated ones")
+}
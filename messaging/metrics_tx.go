@@ -1,20 +1,16 @@
 package messaging
 
 import (
-	"context"
-	"encoding/json"
-	"fmt"
+	"log/slog"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
 	"github.com/CoralCoralCoralCoral/simulation-engine/model"
 	"github.com/google/uuid"
-	"github.com/rabbitmq/amqp091-go"
 )
 
 type MetricsTx struct {
-	api_id uuid.UUID
-	sim_id uuid.UUID
-	ch     *amqp091.Channel
+	publisher Publisher
+	log       *slog.Logger
 }
 
 type JuristictionMetrics map[string]*Metrics
@@ -26,6 +22,7 @@ type Metrics struct {
 	Day int `json:"day"`
 
 	NewInfections          int `json:"new_infections"`
+	NewReinfections        int `json:"new_reinfections"` // the new infections of agents that had been infected before
 	NewHospitalizations    int `json:"new_hospitalizations"`
 	NewRecoveries          int `json:"new_recoveries"`
 	NewDeaths              int `json:"new_deaths"`
@@ -49,23 +46,29 @@ type Metrics struct {
 	// jurisdictions other than the patient's home jurisdiction
 	NewCases   int `json:"new_cases"`
 	TotalCases int `json:"total_cases"`
-}
 
-func NewMetricsTx(conn *amqp091.Connection, api_id, sim_id uuid.UUID) *MetricsTx {
-	ch, err := conn.Channel()
-	failOnError(err, "failed to create channel")
+	// agents turned away by the closed or full spaces of the jurisdiction
+	TurnedAway int `json:"turned_away"`
 
-	err = ch.ExchangeDeclare(NOTIFICATION_EXCHANGE, "topic", false, true, false, false, nil)
-	failOnError(err, "failed to create exchange")
+	// fraction of agents that comply with the policy, as of the end of the day
+	ComplianceRate float64 `json:"compliance_rate"`
+}
 
+func NewMetricsTx(transport Transport, api_id uuid.UUID, config *model.Config) *MetricsTx {
 	return &MetricsTx{
-		api_id,
-		sim_id,
-		ch,
+		publisher: transport.NewPublisher(api_id, config.Id, configEncoding(config)),
+		log:       slog.With("api_id", api_id, "sim_id", config.Id),
 	}
 }
 
 func (tx *MetricsTx) NewEventSubscriber() func(event *logger.Event) {
+	return NewMetricsAggregator(tx.send)
+}
+
+// NewMetricsAggregator returns an event subscriber that aggregates metrics
+// per jurisdiction and hands them to on_day at the end of every simulated
+// day. The daily counts are reset once on_day returns.
+func NewMetricsAggregator(on_day func(jurisdiction_metrics JuristictionMetrics)) func(event *logger.Event) {
 	jurisdiction_metrics := make(JuristictionMetrics)
 
 	day := 0
@@ -83,7 +86,7 @@ func (tx *MetricsTx) NewEventSubscriber() func(event *logger.Event) {
 					metrics.Day = day
 				}
 
-				tx.send(jurisdiction_metrics)
+				on_day(jurisdiction_metrics)
 				jurisdiction_metrics.reset()
 			}
 		case model.AgentStateUpdate:
@@ -98,14 +101,26 @@ func (tx *MetricsTx) NewEventSubscriber() func(event *logger.Event) {
 			if payload, ok := event.Payload.(model.SpaceTestingUpdatePayload); ok {
 				jurisdiction_metrics.applySpaceTestingUpdate(payload.Jurisdiction(), &payload)
 			}
+		case model.AgentTurnedAway:
+			if payload, ok := event.Payload.(model.AgentTurnedAwayPayload); ok {
+				jurisdiction_metrics.applyAgentTurnedAway(payload.Jurisdiction())
+			}
+		case model.ComplianceUpdate:
+			if payload, ok := event.Payload.(model.ComplianceUpdatePayload); ok {
+				jurisdiction_metrics.applyComplianceUpdate(payload.Jurisdiction(), &payload)
+			}
 		default:
 			// ignore other types of events
 		}
 	}
 }
 
+func (metrics *Metrics) Jurisdiction() *model.Jurisdiction {
+	return metrics.jurisdiction
+}
+
 func (tx *MetricsTx) Close() {
-	tx.ch.Close()
+	tx.publisher.Close()
 }
 
 func (jurisdiction_metrics JuristictionMetrics) applySpaceTestingUpdate(jur *model.Jurisdiction, payload *model.SpaceTestingUpdatePayload) {
@@ -146,6 +161,31 @@ func (jurisdiction_metrics JuristictionMetrics) applyCaseDetected(jur *model.Jur
 	}
 }
 
+func (jurisdiction_metrics JuristictionMetrics) applyAgentTurnedAway(jur *model.Jurisdiction) {
+	jur_id := jur.Id
+
+	if _, ok := jurisdiction_metrics[jur_id]; !ok {
+		jurisdiction_metrics[jur_id] = &Metrics{jurisdiction: jur}
+	}
+
+	jurisdiction_metrics[jur_id].TurnedAway += 1
+
+	if parent := jur.Parent(); parent != nil {
+		jurisdiction_metrics.applyAgentTurnedAway(parent)
+	}
+}
+
+// every jurisdiction reports its own compliance rate, so it isn't propagated to parents
+func (jurisdiction_metrics JuristictionMetrics) applyComplianceUpdate(jur *model.Jurisdiction, payload *model.ComplianceUpdatePayload) {
+	jur_id := jur.Id
+
+	if _, ok := jurisdiction_metrics[jur_id]; !ok {
+		jurisdiction_metrics[jur_id] = &Metrics{jurisdiction: jur}
+	}
+
+	jurisdiction_metrics[jur_id].ComplianceRate = payload.ComplianceRate
+}
+
 func (jurisdiction_metrics JuristictionMetrics) applyAgentStateUpdate(jur *model.Jurisdiction, payload *model.AgentStateUpdatePayload) {
 	jur_id := jur.Id
 
@@ -159,6 +199,12 @@ func (jurisdiction_metrics JuristictionMetrics) applyAgentStateUpdate(jur *model
 	case model.Infected:
 		metrics.NewInfections += 1
 		metrics.InfectedPopulation += 1
+		if payload.InfectionCount > 1 {
+			metrics.NewReinfections += 1
+		}
+		if payload.PreviousState == model.Immune {
+			metrics.ImmunePopulation -= 1
+		}
 	case model.Infectious:
 		metrics.InfectiousPopulation += 1
 	case model.Immune:
@@ -198,6 +244,16 @@ func (jurisdiction_metrics JuristictionMetrics) applyAgentStateUpdate(jur *model
 	}
 }
 
+func (jurisdiction_metrics JuristictionMetrics) copy() JuristictionMetrics {
+	copied := make(JuristictionMetrics, len(jurisdiction_metrics))
+	for jur_id, metrics := range jurisdiction_metrics {
+		metrics_copy := *metrics
+		copied[jur_id] = &metrics_copy
+	}
+
+	return copied
+}
+
 func (jurisdiction_metrics JuristictionMetrics) reset() {
 	for _, metrics := range jurisdiction_metrics {
 		metrics.reset()
@@ -206,6 +262,7 @@ func (jurisdiction_metrics JuristictionMetrics) reset() {
 
 func (metrics *Metrics) reset() {
 	metrics.NewInfections = 0
+	metrics.NewReinfections = 0
 	metrics.NewHospitalizations = 0
 	metrics.NewRecoveries = 0
 	metrics.NewDeaths = 0
@@ -216,26 +273,16 @@ func (metrics *Metrics) reset() {
 	metrics.TestCapacity = 0 // since the capacity is reported faily, reset it
 
 	metrics.NewCases = 0
+
+	metrics.TurnedAway = 0
 }
 
 func (tx *MetricsTx) send(jurisdiction_metrics JuristictionMetrics) {
-	routing_key := fmt.Sprintf("%s.%s", tx.api_id, tx.sim_id)
-
-	body, err := json.Marshal(Notification{
-		Type:    MetricsNotification,
-		Payload: jurisdiction_metrics,
-	})
-	failOnError(err, "failed to json serialize event")
-
-	err = tx.ch.PublishWithContext(context.Background(),
-		NOTIFICATION_EXCHANGE, // exchange
-		routing_key,           // routing key
-		false,                 // mandatory
-		false,                 // immediate
-		amqp091.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		})
-
-	failOnError(err, "Failed to publish message")
+	// publishers hand the notification to other goroutines, while the
+	// aggregator resets and updates the metrics as soon as this returns
+	err := tx.publisher.Publish(NewNotification(MetricsNotification, jurisdiction_metrics.copy()))
+
+	if err != nil {
+		tx.log.Error("failed to publish metrics", "error", err)
+	}
 }
@@ -0,0 +1,141 @@
+package messaging
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+)
+
+// MultiTransport serves the engine over several transports at once. Init
+// messages and commands are accepted from any of them and notifications
+// are published to all of them, so e.g. a simulation started over AMQP can
+// be watched over gRPC.
+type MultiTransport struct {
+	transports []Transport
+}
+
+type multiInitReceiver []InitReceiver
+
+type multiCommandReceiver []CommandReceiver
+
+type multiPublisher []Publisher
+
+func NewMultiTransport(transports ...Transport) *MultiTransport {
+	return &MultiTransport{transports}
+}
+
+func (transport *MultiTransport) NewInitReceiver() InitReceiver {
+	receivers := make(multiInitReceiver, 0, len(transport.transports))
+	for _, t := range transport.transports {
+		receivers = append(receivers, t.NewInitReceiver())
+	}
+
+	return receivers
+}
+
+func (transport *MultiTransport) NewCommandReceiver(sim_id uuid.UUID) CommandReceiver {
+	receivers := make(multiCommandReceiver, 0, len(transport.transports))
+	for _, t := range transport.transports {
+		receivers = append(receivers, t.NewCommandReceiver(sim_id))
+	}
+
+	return receivers
+}
+
+func (transport *MultiTransport) NewManagerCommandReceiver() CommandReceiver {
+	receivers := make(multiCommandReceiver, 0, len(transport.transports))
+	for _, t := range transport.transports {
+		receivers = append(receivers, t.NewManagerCommandReceiver())
+	}
+
+	return receivers
+}
+
+func (transport *MultiTransport) NewPublisher(api_id, sim_id uuid.UUID, encoding Encoding) Publisher {
+	publishers := make(multiPublisher, 0, len(transport.transports))
+	for _, t := range transport.transports {
+		publishers = append(publishers, t.NewPublisher(api_id, sim_id, encoding))
+	}
+
+	return publishers
+}
+
+func (transport *MultiTransport) Close() error {
+	errs := make([]error, 0)
+	for _, t := range transport.transports {
+		errs = append(errs, t.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (receivers multiInitReceiver) OnReceive(handler func(api_id uuid.UUID, config model.Config)) {
+	var wg sync.WaitGroup
+
+	for _, rx := range receivers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rx.OnReceive(handler)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (receivers multiInitReceiver) Close() {
+	for _, rx := range receivers {
+		rx.Close()
+	}
+}
+
+func (receivers multiCommandReceiver) OnReceive(handler func(command model.Command), query_handler func(command model.Command) model.QueryResponse) {
+	var wg sync.WaitGroup
+
+	for _, rx := range receivers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rx.OnReceive(handler, query_handler)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (receivers multiCommandReceiver) OnRequest(handler func(command model.Command) model.QueryResponse) {
+	var wg sync.WaitGroup
+
+	for _, rx := range receivers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rx.OnRequest(handler)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (receivers multiCommandReceiver) Close() {
+	for _, rx := range receivers {
+		rx.Close()
+	}
+}
+
+func (publishers multiPublisher) Publish(notification Notification) error {
+	errs := make([]error, 0)
+	for _, publisher := range publishers {
+		errs = append(errs, publisher.Publish(notification))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (publishers multiPublisher) Close() {
+	for _, publisher := range publishers {
+		publisher.Close()
+	}
+}
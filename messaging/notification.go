@@ -1,11 +1,31 @@
 package messaging
 
+import "github.com/CoralCoralCoralCoral/simulation-engine/model"
+
 const EventNotification NotificationType = "event"
 const MetricsNotification NotificationType = "metrics"
+const QueryResponseNotification NotificationType = "query_response"
+
+// the events of one epoch, sent instead of EventNotification when the
+// config asks for batched events
+const EventBatchNotification NotificationType = "event_batch"
+
+// the sampled agent events of one simulated hour, see model.AgentStreamConfig
+const AgentEventsNotification NotificationType = "agent_events"
 
 type Notification struct {
+	Version int              `json:"version"`
 	Type    NotificationType `json:"type"`
 	Payload interface{}      `json:"payload"`
 }
 
 type NotificationType string
+
+// NewNotification wraps payload in an envelope of the current protocol version
+func NewNotification(notification_type NotificationType, payload interface{}) Notification {
+	return Notification{
+		Version: model.ProtocolVersion,
+		Type:    notification_type,
+		Payload: payload,
+	}
+}
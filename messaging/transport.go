@@ -0,0 +1,42 @@
+package messaging
+
+import (
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+)
+
+// Transport carries init messages and commands into the engine and
+// notifications out of it. Everything above it (EventTx, MetricsTx, the
+// simulation manager) is independent of how messages actually travel.
+type Transport interface {
+	NewInitReceiver() InitReceiver
+	NewCommandReceiver(sim_id uuid.UUID) CommandReceiver
+	NewManagerCommandReceiver() CommandReceiver
+	NewPublisher(api_id, sim_id uuid.UUID, encoding Encoding) Publisher
+	Close() error
+}
+
+type InitReceiver interface {
+	// OnReceive blocks, calling handler for every init message until the receiver is closed
+	OnReceive(handler func(api_id uuid.UUID, config model.Config))
+	Close()
+}
+
+type CommandReceiver interface {
+	// OnReceive blocks, passing commands to handler and queries to
+	// query_handler until the receiver is closed
+	OnReceive(handler func(command model.Command), query_handler func(command model.Command) model.QueryResponse)
+
+	// OnRequest blocks, passing every command to handler and answering
+	// with its response until the receiver is closed
+	OnRequest(handler func(command model.Command) model.QueryResponse)
+
+	Close()
+}
+
+// Publisher sends notifications to the client that started a simulation.
+// Transports that carry bytes encode them with the publisher's encoding.
+type Publisher interface {
+	Publish(notification Notification) error
+	Close()
+}
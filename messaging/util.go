@@ -2,17 +2,14 @@ package messaging
 
 import (
 	"errors"
-	"log"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/rabbitmq/amqp091-go"
 )
 
-func failOnError(err error, msg string) {
-	if err != nil {
-		log.Panicf("%s: %s", msg, err)
-	}
+func declareNotificationExchange(ch *amqp091.Channel) error {
+	return ch.ExchangeDeclare(NOTIFICATION_EXCHANGE, "topic", false, true, false, false, nil)
 }
 
 func extractApiId(msg *amqp091.Delivery) (uuid.UUID, error) {
@@ -2,6 +2,7 @@ package model
 
 import (
 	"math"
+	"math/rand"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
 	"github.com/google/uuid"
@@ -14,12 +15,17 @@ const Hospitalized AgentState = "hospitalized"
 const Dead AgentState = "dead"
 const Immune AgentState = "immune"
 
+const KeyWorker Occupation = "key_worker"        // keeps going to work during lockdowns
+const RemoteWorker Occupation = "remote_worker"  // can work from home
+const OnSiteWorker Occupation = "on_site_worker" // can't work from home
+
 type Agent struct {
 	id                         uuid.UUID
 	household                  *Space
 	office                     *Space
 	social_spaces              []*Space
 	healthcare_spaces          []*Space
+	outdoor_spaces             []*Space
 	location                   *Space
 	location_change_epoch      int64
 	next_move_epoch            int64
@@ -29,12 +35,27 @@ type Agent struct {
 	pulmonary_ventilation_rate float64
 	seeks_treatment            bool
 	mask_filtration_efficiency float64
-	compliance                 map[float64]bool
+	compliance_threshold       float64 // complies while the compliance rate is above it
 	has_self_reported          bool
+	occupation                 Occupation
+
+	// the immunity left by past infections wanes from the last recovery
+	infection_count int64
+	recovery_epoch  int64
+	immunity_period float64
+
+	// the commute between household and office passes through transit, after
+	// which the agent stays at the destination for the duration
+	transit             *Space
+	commute_time        float64
+	transit_destination *Space
+	transit_duration    float64
 }
 
 type AgentState string
 
+type Occupation string
+
 func newAgent(config *Config) Agent {
 	seeks_treatment := false
 	if sampleBernoulli(config.SeeksTreatmentProbability) == 1 {
@@ -47,16 +68,29 @@ func newAgent(config *Config) Agent {
 		office:                     nil,
 		social_spaces:              make([]*Space, 0),
 		healthcare_spaces:          make([]*Space, 0),
+		outdoor_spaces:             make([]*Space, 0),
 		location:                   nil,
 		location_change_epoch:      0,
 		next_move_epoch:            0,
 		state:                      Susceptible,
 		state_change_epoch:         0,
 		infection_profile:          nil,
-		pulmonary_ventilation_rate: sampleNormal(config.PulmonaryVentilationRateMean, config.PulmonaryVentilationRateSd),
-		mask_filtration_efficiency: math.Max(sampleNormal(config.MaskFiltrationEfficiencyMean, config.MaskFiltrationEfficiencySd), 0.95),
+		pulmonary_ventilation_rate: config.sample(PulmonaryVentilationRate),
+		mask_filtration_efficiency: math.Max(config.sample(MaskFiltrationEfficiency), 0.95),
 		seeks_treatment:            seeks_treatment,
-		compliance:                 make(map[float64]bool),
+		compliance_threshold:       rand.Float64(),
+		occupation:                 sampleOccupation(config),
+	}
+}
+
+func sampleOccupation(config *Config) Occupation {
+	switch u := rand.Float64(); {
+	case u < config.KeyWorkerProbability:
+		return KeyWorker
+	case u < config.KeyWorkerProbability+config.RemoteWorkerProbability:
+		return RemoteWorker
+	default:
+		return OnSiteWorker
 	}
 }
 
@@ -103,9 +137,10 @@ func (agent *Agent) updateState(sim *Simulation) {
 			}
 		}
 	case Immune:
-		if state_duration >= agent.infection_profile.immunity_period {
+		if sampleBernoulli(agent.pInfected(sim)) == 1 {
+			agent.infect(sim)
+		} else if state_duration >= agent.infection_profile.immunity_period {
 			agent.infection_profile = nil
-			agent.has_self_reported = false
 			agent.setState(sim, Susceptible)
 		}
 	case Dead:
@@ -118,6 +153,24 @@ func (agent *Agent) updateState(sim *Simulation) {
 func (agent *Agent) setState(sim *Simulation, state AgentState) {
 	previous_state := agent.state
 
+	switch state {
+	case Infected:
+		sim.active_infections += 1
+	case Immune, Dead:
+		if previous_state == Infectious || previous_state == Hospitalized {
+			sim.active_infections -= 1
+		}
+	}
+
+	if state == Dead {
+		agent.household.jurisdiction.perceiveRisk(sim)
+	}
+
+	if state == Immune {
+		agent.recovery_epoch = sim.epoch
+		agent.immunity_period = agent.infection_profile.immunity_period
+	}
+
 	agent.state = state
 	agent.state_change_epoch = sim.epoch
 	agent.dispatchStateUpdateEvent(sim, previous_state)
@@ -162,7 +215,7 @@ func (agent *Agent) updateLocation(sim *Simulation) {
 	// in the special case that the agent is infectious and symptomatic and
 	// there is a self reporting mandate and the agent is compliant and
 	// hasn't yet self reported, the agent moves to a healthcare space for a short duration
-	if policy.IsSelfReportingMandate && agent.isCompliant() && agent.state == Infectious && !agent.infection_profile.is_asymptomatic && !agent.has_self_reported {
+	if policy.IsSelfReportingMandate && agent.isCompliant(sim) && agent.state == Infectious && !agent.infection_profile.is_asymptomatic && !agent.has_self_reported {
 		agent.setLocation(
 			sim,
 			agent.healthcare_spaces[sampleUniform(0, int64(len(agent.healthcare_spaces)-1))],
@@ -180,20 +233,43 @@ func (agent *Agent) updateLocation(sim *Simulation) {
 
 	switch agent.location.type_ {
 	case Household:
-		if policy.IsLockdown && agent.isCompliant() {
+		is_going_to_work := sampleBernoulli(0.55) == 1
+
+		// key workers keep going to work during lockdowns, remote workers
+		// work from home
+		if policy.IsLockdown && agent.isCompliant(sim) && !(is_going_to_work && agent.occupation == KeyWorker) {
+			if is_going_to_work && agent.occupation == RemoteWorker {
+				agent.workFromHome(sim, sampleNormal(8*60*60*1000, 2*60*60*1000))
+			}
+
 			break
 		}
 
-		if policy.IsSelfIsolationMandate && agent.isCompliant() && agent.state == Infectious && !agent.infection_profile.is_asymptomatic {
+		if policy.IsSelfIsolationMandate && agent.isCompliant(sim) && agent.state == Infectious && !agent.infection_profile.is_asymptomatic {
 			break
 		}
 
-		if sampleBernoulli(0.55) == 1 {
-			agent.setLocation(
-				sim,
-				agent.office,
-				sampleNormal(8*60*60*1000, 2*60*60*1000),
-			)
+		if is_going_to_work {
+			duration := sampleNormal(8*60*60*1000, 2*60*60*1000)
+
+			if policy.IsWorkFromHomeMandate && agent.occupation == RemoteWorker && agent.isCompliant(sim) {
+				agent.workFromHome(sim, duration)
+				break
+			}
+
+			// agents turned away from their office work from home if they
+			// can, the others lose the day of work
+			if !agent.office.admit(sim, agent) {
+				if agent.occupation == RemoteWorker {
+					agent.workFromHome(sim, duration)
+				} else {
+					agent.stay(sim, duration)
+				}
+
+				break
+			}
+
+			agent.commute(sim, agent.office, duration)
 		} else if sampleBernoulli(0.001) == 1 {
 			// simulate randomly going to a healthcare space
 			agent.setLocation(
@@ -202,13 +278,26 @@ func (agent *Agent) updateLocation(sim *Simulation) {
 				sampleNormal(45*60*1000, 15*60*1000),
 			)
 		} else {
-			agent.setLocation(
-				sim,
-				agent.social_spaces[sampleUniform(0, int64(len(agent.social_spaces)-1))],
-				sampleNormal(45*60*1000, 15*60*1000),
-			)
+			duration := sampleNormal(45*60*1000, 15*60*1000)
+
+			social_space := agent.socialSpace(sim)
+			if social_space == nil {
+				agent.stay(sim, duration)
+				break
+			}
+
+			agent.setLocation(sim, social_space, duration)
 		}
-	case Office, SocialSpace, HealthCareSpace:
+	case Office:
+		agent.commute(
+			sim,
+			agent.household,
+			sampleNormal(12*60*60*1000, 4*60*60*1000),
+		)
+	case Transit:
+		agent.setLocation(sim, agent.transit_destination, agent.transit_duration)
+		agent.transit_destination = nil
+	case SocialSpace, Outdoor, HealthCareSpace:
 		agent.setLocation(
 			sim,
 			agent.household,
@@ -219,6 +308,63 @@ func (agent *Agent) updateLocation(sim *Simulation) {
 	}
 }
 
+// commute moves the agent to destination through its transit space, unless
+// the transit turns it away, in which case it travels there by other means
+func (agent *Agent) commute(sim *Simulation, destination *Space, duration float64) {
+	if agent.transit == nil || agent.commute_time <= 0 || !agent.transit.admit(sim, agent) {
+		agent.setLocation(sim, destination, duration)
+		return
+	}
+
+	agent.transit_destination = destination
+	agent.transit_duration = duration
+	agent.setLocation(sim, agent.transit, agent.commute_time)
+}
+
+// socialSpace picks where a social outing goes, outdoors with the outdoor
+// visit probability. Agents turned away go to another of their social or
+// outdoor spaces, and nil means that none of them will admit the agent.
+func (agent *Agent) socialSpace(sim *Simulation) *Space {
+	choice := agent.social_spaces[sampleUniform(0, int64(len(agent.social_spaces)-1))]
+	if len(agent.outdoor_spaces) > 0 && sampleBernoulli(sim.config.OutdoorVisitProbability) == 1 {
+		choice = agent.outdoor_spaces[sampleUniform(0, int64(len(agent.outdoor_spaces)-1))]
+	}
+
+	if choice.admit(sim, agent) {
+		return choice
+	}
+
+	for _, spaces := range [][]*Space{agent.social_spaces, agent.outdoor_spaces} {
+		for _, alternative := range spaces {
+			if alternative != choice && alternative.admits() {
+				return alternative
+			}
+		}
+	}
+
+	return nil
+}
+
+// stay keeps the agent where it is for duration
+func (agent *Agent) stay(sim *Simulation, duration float64) {
+	agent.next_move_epoch = sim.epoch + int64(math.Ceil(duration/float64(sim.time_step)))
+}
+
+// workFromHome keeps the agent at home for a day of work of duration
+func (agent *Agent) workFromHome(sim *Simulation, duration float64) {
+	agent.stay(sim, duration)
+
+	sim.logger.Log(logger.Event{
+		Type: AgentWorkedFromHome,
+		Payload: AgentWorkedFromHomePayload{
+			Epoch:         sim.epoch,
+			Id:            agent.id,
+			Occupation:    agent.occupation,
+			NextMoveEpoch: agent.next_move_epoch,
+		},
+	})
+}
+
 func (agent *Agent) setLocation(sim *Simulation, location *Space, duration float64) {
 	previous_location := agent.location
 
@@ -236,7 +382,11 @@ func (agent *Agent) setLocation(sim *Simulation, location *Space, duration float
 }
 
 func (agent *Agent) infect(sim *Simulation) {
-	agent.infection_profile = sim.pathogen.generateInfectionProfile()
+	severity_protection := sim.config.SeverityProtection * agent.waningImmunity(sim)
+
+	agent.infection_profile = sim.pathogen.generateInfectionProfile(sim.epoch, severity_protection)
+	agent.infection_count += 1
+	agent.has_self_reported = false
 	agent.setState(sim, Infected)
 }
 
@@ -249,6 +399,7 @@ func (agent *Agent) dispatchStateUpdateEvent(sim *Simulation, previous_state Age
 			State:               agent.state,
 			PreviousState:       previous_state,
 			HasInfectionProfile: agent.infection_profile != nil,
+			InfectionCount:      agent.infection_count,
 
 			jurisdiction: agent.household.jurisdiction,
 		},
@@ -265,18 +416,33 @@ func (agent *Agent) dispatchLocationUpdateEvent(sim *Simulation, previous_locati
 			Id:                 agent.id,
 			LocationId:         agent.location.id,
 			PreviousLocationId: previous_location.id,
-			agent:              agent,
+
+			jurisdiction:    agent.household.jurisdiction,
+			location_type:   agent.location.type_,
+			next_move_epoch: agent.next_move_epoch,
 		},
 	}
 
 	sim.logger.Log(event)
 }
 
+// quantaEmissionRate of the agent at the current epoch, which is 0 unless it
+// is infected or infectious
+func (agent *Agent) quantaEmissionRate(sim *Simulation) float64 {
+	if agent.state != Infected && agent.state != Infectious {
+		return 0
+	}
+
+	time := float64((sim.epoch - agent.infection_profile.infection_epoch) * sim.time_step)
+
+	return sim.pathogen.quantaEmissionRate(agent.infection_profile, time)
+}
+
 func (agent *Agent) pInfected(sim *Simulation) float64 {
-	_, volume, _, total_infectious_doses, policy := agent.location.state()
+	space_type, volume, _, total_infectious_doses, policy := agent.location.state()
 
 	filtration_efficiency := 0.0
-	if policy.IsMaskMandate && agent.isCompliant() {
+	if policy.requiresMask(space_type) && agent.isCompliant(sim) {
 		filtration_efficiency = agent.mask_filtration_efficiency
 	}
 
@@ -284,22 +450,31 @@ func (agent *Agent) pInfected(sim *Simulation) float64 {
 
 	p := 1 - math.Exp(-1*(1-filtration_efficiency)*dose_concentration*(agent.pulmonary_ventilation_rate/3600)*(float64(sim.time_step)/1000))
 
-	return p
+	// past infections protect against reinfection
+	return p * (1 - sim.config.ImmunityProtection*agent.waningImmunity(sim))
 }
 
-func (agent *Agent) isCompliant() bool {
-	compliance_probability := agent.location.resolvePolicy().ComplianceProbability
-
-	if is_compliant, ok := agent.compliance[compliance_probability]; ok {
-		return is_compliant
+// waningImmunity is the fraction of the protection of the last infection
+// that is left. It decays exponentially from recovery, by a factor of e
+// every immunity period.
+func (agent *Agent) waningImmunity(sim *Simulation) float64 {
+	if agent.infection_count == 0 || agent.immunity_period <= 0 {
+		return 0
 	}
 
-	is_compliant := false
-	if sampleBernoulli(compliance_probability) == 1 {
-		is_compliant = true
+	// only recovered agents are protected by a past infection
+	if agent.state != Immune && agent.state != Susceptible {
+		return 0
 	}
 
-	agent.compliance[compliance_probability] = is_compliant
+	time_since_recovery := float64((sim.epoch - agent.recovery_epoch) * sim.time_step)
+
+	return math.Exp(-time_since_recovery / agent.immunity_period)
+}
 
-	return is_compliant
+// isCompliant compares the agent's fixed threshold with the compliance rate
+// of its location, so the most compliant agents are the last to give up as
+// the rate falls and the first to comply again as it rises
+func (agent *Agent) isCompliant(sim *Simulation) bool {
+	return agent.compliance_threshold < agent.location.jurisdiction.complianceRate(sim)
 }
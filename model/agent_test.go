@@ -0,0 +1,171 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmunityWanesAfterRecovery(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	agent := newAgent(&config)
+	assert.Equal(t, 0.0, agent.waningImmunity(&sim), "Expected agents that were never infected to have no immunity")
+
+	agent.state = Immune
+	agent.infection_count = 1
+	agent.recovery_epoch = 0
+	agent.immunity_period = float64(10 * sim.time_step)
+
+	assert.Equal(t, 1.0, agent.waningImmunity(&sim), "Expected full immunity right after recovery")
+
+	sim.epoch = 10
+	agent.state = Susceptible
+
+	assert.InDelta(t, math.Exp(-1), agent.waningImmunity(&sim), 1e-9, "Expected immunity to wane by a factor of e every immunity period")
+
+	agent.state = Infected
+
+	assert.Equal(t, 0.0, agent.waningImmunity(&sim), "Expected no protection while infected")
+}
+
+func TestSeverityProtectionPreventsHospitalization(t *testing.T) {
+	config := DefaultConfig()
+	config.HospitalizationProbability = 1
+
+	pathogen := newPathogen(&config)
+
+	assert.True(t, pathogen.generateInfectionProfile(0, 0).is_hospitalized, "Expected first infections to follow the hospitalization probability")
+	assert.False(t, pathogen.generateInfectionProfile(0, 1).is_hospitalized, "Expected full severity protection to prevent hospitalization")
+}
+
+func TestCommuteGoesThroughTransitUnlessItIsFull(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+	transit_cap := 1.0
+	jur.Policy.TransitCapacityCap = &transit_cap
+
+	household := newHousehold(&config, 2)
+	office := newOffice(&config, 10)
+	transit := newTransit(&config, 1)
+	for _, space := range []*Space{&household, &office, &transit} {
+		space.jurisdiction = jur
+	}
+
+	agent := newAgent(&config)
+	agent.household = &household
+	agent.office = &office
+	agent.location = &household
+	agent.transit = &transit
+	agent.commute_time = float64(2 * sim.time_step)
+
+	agent.commute(&sim, &office, float64(8*sim.time_step))
+
+	assert.Equal(t, &transit, agent.location, "Expected the commute to start in transit")
+	assert.Equal(t, int64(2), agent.next_move_epoch, "Expected the agent to stay in transit for the commute time")
+
+	sim.epoch = agent.next_move_epoch
+	agent.updateLocation(&sim)
+
+	assert.Equal(t, &office, agent.location, "Expected the agent to arrive at the destination after transit")
+	assert.Equal(t, int64(10), agent.next_move_epoch, "Expected the agent to stay at the destination for the duration")
+
+	other := newAgent(&config)
+	transit.occupants = append(transit.occupants, &other)
+
+	agent.commute(&sim, &household, float64(sim.time_step))
+
+	assert.Equal(t, &household, agent.location, "Expected the agent to skip transit at its capacity cap")
+
+	jur.Policy.TransitCapacityCap = nil
+
+	assert.True(t, transit.hasRoom(), "Expected transit without a cap to have room")
+}
+
+// officeVisits counts how often an agent at home goes to its office in the
+// given number of decisions
+func officeVisits(sim *Simulation, agent *Agent, decisions int) int {
+	visits := 0
+	for i := 0; i < decisions; i++ {
+		sim.epoch += 1
+		agent.next_move_epoch = sim.epoch
+
+		if agent.location != agent.household {
+			agent.setLocation(sim, agent.household, 0)
+		}
+
+		agent.updateLocation(sim)
+		if agent.location == agent.office {
+			visits += 1
+		}
+	}
+
+	return visits
+}
+
+func TestWorkFromHomeMandateOnlyKeepsRemoteWorkersHome(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	worked_from_home := make([]AgentWorkedFromHomePayload, 0)
+	sim.Subscribe(func(event *logger.Event) {
+		if payload, ok := event.Payload.(AgentWorkedFromHomePayload); ok {
+			worked_from_home = append(worked_from_home, payload)
+		}
+	})
+
+	go sim.logger.Broadcast()
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+	jur.Policy.ComplianceProbability = 1
+	jur.Policy.IsWorkFromHomeMandate = true
+
+	household := newHousehold(&config, 1)
+	office := newOffice(&config, 10)
+	social_space := newSocialSpace(&config, 10)
+	for _, space := range []*Space{&household, &office, &social_space} {
+		space.jurisdiction = jur
+	}
+
+	agent := newAgent(&config)
+	agent.household = &household
+	agent.office = &office
+	agent.social_spaces = []*Space{&social_space}
+	agent.location = &household
+
+	agent.occupation = RemoteWorker
+	assert.Equal(t, 0, officeVisits(&sim, &agent, 50), "Expected compliant remote workers to work from home")
+
+	agent.occupation = OnSiteWorker
+	assert.Greater(t, officeVisits(&sim, &agent, 50), 0, "Expected workers that can't work from home to keep going to work")
+
+	jur.Policy.IsWorkFromHomeMandate = false
+	jur.Policy.IsLockdown = true
+
+	assert.Equal(t, 0, officeVisits(&sim, &agent, 50), "Expected compliant workers to stay home during lockdowns")
+
+	agent.occupation = KeyWorker
+	assert.Greater(t, officeVisits(&sim, &agent, 50), 0, "Expected key workers to keep going to work during lockdowns")
+
+	lockdown_epoch := sim.epoch
+	agent.occupation = RemoteWorker
+	assert.Equal(t, 0, officeVisits(&sim, &agent, 50), "Expected compliant remote workers to stay home during lockdowns")
+
+	sim.logger.Close()
+
+	worked_during_lockdown := 0
+	for _, payload := range worked_from_home {
+		if payload.Epoch > lockdown_epoch {
+			worked_during_lockdown += 1
+		}
+	}
+	assert.Greater(t, worked_during_lockdown, 0, "Expected remote workers to work from home during lockdowns")
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"slices"
+	"sync"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
 )
@@ -20,31 +21,36 @@ type BudgetConfig struct {
 	CostMultiplier   float64
 	IncomeMultiplier float64
 
-	sim    *Simulation
 	logger *logger.Logger
+	mu     sync.Mutex
 }
 
-func InitialiseBudget(sim *Simulation) BudgetConfig {
+func InitialiseBudget(sim *Simulation) *BudgetConfig {
+	return NewBudgetConfig(&sim.config, sim.logger)
+}
+
+// NewBudgetConfig creates a budget that logs its updates to logger_. It
+// isn't tied to a simulation, so it can also follow a replayed recording.
+func NewBudgetConfig(config *Config, logger_ *logger.Logger) *BudgetConfig {
 	// https://www.ons.gov.uk/employmentandlabourmarket/peopleinwork/earningsandworkinghours/timeseries/ybuy/lms
-	config := BudgetConfig{
+	budget := &BudgetConfig{
 		StartingBudget:        1000000,
 		TestCost:              59.99,
 		MaskCost:              19.99,
 		LockdownCostPerCapita: 2500.0,
-		GDPPerCapitaPerEpoch:  (50000.0 / (48 * 38.5)) / (1000 * 60 * 60 / float64(sim.config.TimeStep)),
+		GDPPerCapitaPerEpoch:  (50000.0 / (48 * 38.5)) / (1000 * 60 * 60 / float64(config.TimeStep)),
 		TaxRate:               0.2,
 		DepartmentBudgetRate:  0.025,
 		CostMultiplier:        1.0,
 		IncomeMultiplier:      1.0,
-		sim:                   sim,
-		logger:                &sim.logger,
+		logger:                logger_,
 	}
 
-	config.BudgetUpdatePayload = &BudgetUpdatePayload{
-		CurrentBudget: config.StartingBudget,
+	budget.BudgetUpdatePayload = &BudgetUpdatePayload{
+		CurrentBudget: budget.StartingBudget,
 	}
 
-	return config
+	return budget
 }
 
 func (conf *BudgetConfig) NewEventSubscriber() func(event *logger.Event) {
@@ -62,9 +68,9 @@ func (conf *BudgetConfig) NewEventSubscriber() func(event *logger.Event) {
 				if (payload.Epoch*payload.TimeStep)%(24*60*60*1000) != 0 {
 					return
 				}
-				go conf.logger.Log(logger.Event{
+				conf.logger.LogFollowUp(logger.Event{
 					Type:    BudgetUpdate,
-					Payload: conf.BudgetUpdatePayload,
+					Payload: conf.Update(),
 				})
 			}
 		case AgentStateUpdate:
@@ -79,15 +85,20 @@ func (conf *BudgetConfig) NewEventSubscriber() func(event *logger.Event) {
 			}
 		case AgentLocationUpdate:
 			if payload, ok := event.Payload.(AgentLocationUpdatePayload); ok {
-				if payload.agent.location.type_ == Office {
-					conf.addBudget(float64(payload.agent.next_move_epoch-payload.Epoch) * conf.GDPPerCapitaPerEpoch * conf.TaxRate * conf.DepartmentBudgetRate)
+				if payload.location_type == Office {
+					conf.addBudget(float64(payload.next_move_epoch-payload.Epoch) * conf.GDPPerCapitaPerEpoch * conf.TaxRate * conf.DepartmentBudgetRate)
 				}
 			}
+		case AgentWorkedFromHome:
+			// remote work is taxed like work at the office
+			if payload, ok := event.Payload.(AgentWorkedFromHomePayload); ok {
+				conf.addBudget(float64(payload.NextMoveEpoch-payload.Epoch) * conf.GDPPerCapitaPerEpoch * conf.TaxRate * conf.DepartmentBudgetRate)
+			}
 		case CommandProcessed:
 			if payload, ok := event.Payload.(CommandProcessedPayload); ok {
 				if payload.Command.Type == ApplyPolicyUpdate {
 					if command, ok := payload.Command.Payload.(*ApplyPolicyUpdatePayload); ok {
-						conf.handleCommandProcessedPayload(command)
+						conf.handleCommandProcessedPayload(command, payload.affected_population)
 					}
 				}
 			}
@@ -95,47 +106,90 @@ func (conf *BudgetConfig) NewEventSubscriber() func(event *logger.Event) {
 	}
 }
 
-func (conf *BudgetConfig) handleCommandProcessedPayload(payload *ApplyPolicyUpdatePayload) {
-	var affectedPeople int
+func (conf *BudgetConfig) handleCommandProcessedPayload(payload *ApplyPolicyUpdatePayload, affectedPeople int64) {
+	if payload.IsLockdown != nil && *payload.IsLockdown {
+		conf.spendBudget(float64(affectedPeople) * conf.LockdownCostPerCapita)
+	}
+	if payload.IsMaskMandate != nil && *payload.IsMaskMandate {
+		conf.spendBudget(float64(affectedPeople) * conf.MaskCost)
+	}
+}
+
+func (conf *BudgetConfig) spendBudget(amount float64) {
+	conf.mu.Lock()
+	defer conf.mu.Unlock()
+
+	conf.BudgetUpdatePayload.CurrentBudget -= amount * conf.CostMultiplier
+}
+
+func (conf *BudgetConfig) addBudget(amount float64) {
+	conf.mu.Lock()
+	defer conf.mu.Unlock()
+
+	conf.BudgetUpdatePayload.CurrentBudget += amount * conf.IncomeMultiplier
+}
+
+// currentBudget is safe to call from outside the budget's subscriber goroutine
+func (conf *BudgetConfig) currentBudget() float64 {
+	conf.mu.Lock()
+	defer conf.mu.Unlock()
+
+	return conf.BudgetUpdatePayload.CurrentBudget
+}
+
+// Update returns a copy of the budget update. Subscribers encode logged
+// payloads while the budget keeps changing, so the shared one is never logged.
+func (conf *BudgetConfig) Update() BudgetUpdatePayload {
+	conf.mu.Lock()
+	defer conf.mu.Unlock()
+
+	return *conf.BudgetUpdatePayload
+}
+
+// affectedPopulation counts the agents in the spaces of a jurisdiction,
+// which is what policy updates applied to it are charged for
+func (sim *Simulation) affectedPopulation(jurisdiction_id string) int64 {
+	var affectedPeople int64
 	var jur *Jurisdiction
-	for _, _jur := range conf.sim.jurisdictions {
-		if _jur.Id == payload.JurisdictionId {
+	for _, _jur := range sim.jurisdictions {
+		if _jur.Id == jurisdiction_id {
 			jur = _jur
 		}
 	}
 
+	if jur == nil {
+		return 0
+	}
+
 	leafJurs := getLeafJuristictionIDs(jur)
 
-	for _, office := range conf.sim.offices {
+	for _, office := range sim.offices {
 		if slices.Contains(leafJurs, &office.jurisdiction.Id) {
-			affectedPeople += len(office.occupants)
+			affectedPeople += int64(len(office.occupants))
 		}
 	}
-	for _, houses := range conf.sim.households {
+	for _, houses := range sim.households {
 		if slices.Contains(leafJurs, &houses.jurisdiction.Id) {
-			affectedPeople += len(houses.occupants)
+			affectedPeople += int64(len(houses.occupants))
 		}
 	}
-	for _, social_space := range conf.sim.social_spaces {
+	for _, social_space := range sim.social_spaces {
 		if slices.Contains(leafJurs, &social_space.jurisdiction.Id) {
-			affectedPeople += len(social_space.occupants)
+			affectedPeople += int64(len(social_space.occupants))
 		}
 	}
-
-	if payload.IsLockdown != nil && *payload.IsLockdown {
-		conf.spendBudget(float64(affectedPeople) * conf.LockdownCostPerCapita)
+	for _, transit := range sim.transit_spaces {
+		if slices.Contains(leafJurs, &transit.jurisdiction.Id) {
+			affectedPeople += int64(len(transit.occupants))
+		}
 	}
-	if payload.IsMaskMandate != nil && *payload.IsMaskMandate {
-		conf.spendBudget(float64(affectedPeople) * conf.MaskCost)
+	for _, outdoor_space := range sim.outdoor_spaces {
+		if slices.Contains(leafJurs, &outdoor_space.jurisdiction.Id) {
+			affectedPeople += int64(len(outdoor_space.occupants))
+		}
 	}
-}
-
-func (conf *BudgetConfig) spendBudget(amount float64) {
-	conf.BudgetUpdatePayload.CurrentBudget -= amount * conf.CostMultiplier
-}
 
-func (conf *BudgetConfig) addBudget(amount float64) {
-	conf.BudgetUpdatePayload.CurrentBudget += amount * conf.IncomeMultiplier
+	return affectedPeople
 }
 
 func getLeafJuristictionIDs(jur *Jurisdiction) []*string {
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkFromHomeEarnsIncome(t *testing.T) {
+	config := DefaultConfig()
+	budget := NewBudgetConfig(&config, logger.NewLogger())
+	subscriber := budget.NewEventSubscriber()
+
+	subscriber(&logger.Event{
+		Type: AgentWorkedFromHome,
+		Payload: AgentWorkedFromHomePayload{
+			Epoch:         10,
+			Id:            uuid.New(),
+			Occupation:    RemoteWorker,
+			NextMoveEpoch: 42,
+		},
+	})
+
+	income := 32 * budget.GDPPerCapitaPerEpoch * budget.TaxRate * budget.DepartmentBudgetRate
+	assert.InDelta(t, budget.StartingBudget+income, budget.currentBudget(), 1e-9, "Expected a day of remote work to be taxed like a day at the office")
+}
+
+func TestBudgetUpdatesAreLoggedAsCopies(t *testing.T) {
+	config := DefaultConfig()
+	budget := NewBudgetConfig(&config, logger.NewLogger())
+
+	update := budget.Update()
+	budget.spendBudget(100)
+
+	assert.Equal(t, budget.StartingBudget, update.CurrentBudget, "Expected a logged update not to change with the budget")
+	assert.Equal(t, budget.StartingBudget-100, budget.Update().CurrentBudget)
+}
+
+func TestFinalBudgetUpdateIsLoggedBeforeClose(t *testing.T) {
+	config := DefaultConfig()
+	logger_ := logger.NewLogger()
+	budget := NewBudgetConfig(&config, logger_)
+	logger_.Subscribe(budget.NewEventSubscriber())
+
+	updates := 0
+	logger_.Subscribe(func(event *logger.Event) {
+		if event.Type == BudgetUpdate {
+			updates += 1
+		}
+	})
+
+	go logger_.Broadcast()
+
+	logger_.Log(logger.Event{
+		Type:    EpochEnd,
+		Payload: EpochEndPayload{Epoch: 24 * 60 * 60 * 1000 / config.TimeStep, TimeStep: config.TimeStep},
+	})
+	logger_.Close()
+
+	assert.Equal(t, 1, updates, "Expected the budget update of the last day to reach subscribers before Close returns")
+}
@@ -2,16 +2,37 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
 )
 
 const Quit CommandType = "quit"
 const Pause CommandType = "pause"
 const Resume CommandType = "resume"
 const ApplyPolicyUpdate CommandType = "apply_policy_update"
+const SetSpeed CommandType = "set_speed"
+const Step CommandType = "step"
+const RunUntil CommandType = "run_until"
+const QueryPolicy CommandType = "query_policy"
+const QueryBudget CommandType = "query_budget"
+const QueryTime CommandType = "query_time"
+const QueryPopulation CommandType = "query_population"
+const QuerySpace CommandType = "query_space"
+const QueryAgent CommandType = "query_agent"
+const ListSimulations CommandType = "list_simulations"
+const KillSimulation CommandType = "kill_simulation"
+const SetAgentStream CommandType = "set_agent_stream"
 
 type Command struct {
+	// the protocol version the command was written for. commands that
+	// predate versioning are treated as version 1
+	Version int         `json:"version"`
 	Type    CommandType `json:"type"`
 	Payload interface{} `json:"payload"`
+
+	// set for queries sent through SendQuery. not serialized
+	reply chan QueryResponse
 }
 
 type CommandType string
@@ -22,15 +43,67 @@ type ApplyPolicyUpdatePayload struct {
 	IsSelfIsolationMandate *bool         `json:"is_self_isolation_mandate"`
 	IsSelfReportingMandate *bool         `json:"is_self_reporting_mandate"`
 	IsLockdown             *bool         `json:"is_lockdown"`
+	IsWorkFromHomeMandate  *bool         `json:"is_work_from_home_mandate"`
 	TestStrategy           *TestStrategy `json:"test_strategy"`
 	TestCapacityMultiplier *float64      `json:"test_capacity_multiplier"`
 	ComplianceProbability  *float64      `json:"compliance_probability"`
+	ComplianceFatigue      *float64      `json:"compliance_fatigue"`
+	RiskPerception         *float64      `json:"risk_perception"`
+
+	// sets the improvement of the listed space types, leaving the others as they are
+	VentilationImprovement map[SpaceType]float64 `json:"ventilation_improvement,omitempty"`
+
+	// a negative capacity cap lifts the cap, 0 turns everyone away
+	IsTransitMaskMandate *bool    `json:"is_transit_mask_mandate"`
+	TransitCapacityCap   *float64 `json:"transit_capacity_cap"`
+	OutdoorCapacityCap   *float64 `json:"outdoor_capacity_cap"`
+	VenueCapacityCap     *float64 `json:"venue_capacity_cap"`
+
+	// opens or closes the listed space types, leaving the others as they are
+	ClosedSpaces map[SpaceType]bool `json:"closed_spaces,omitempty"`
+}
+
+// Speed is expressed in simulated seconds per wall clock second. A speed
+// of 0 removes the throttle and runs epochs as fast as possible.
+type SetSpeedPayload struct {
+	Speed float64 `json:"speed"`
+}
+
+// Step runs the given number of epochs while the simulation is paused.
+type StepPayload struct {
+	Epochs int64 `json:"epochs"`
+}
+
+// RunUntil resumes the simulation and pauses it again once the given
+// (1-indexed) day has been fully simulated.
+type RunUntilPayload struct {
+	Day int64 `json:"day"`
+}
+
+type QueryJurisdictionPayload struct {
+	JurisdictionId string `json:"jurisdiction_id"`
+}
+
+type QueryEntityPayload struct {
+	Id uuid.UUID `json:"id"`
+}
+
+// IsQuery reports whether the command asks the simulation for information
+// rather than changing it. Queries are answered with a QueryResponse.
+func (c *Command) IsQuery() bool {
+	switch c.Type {
+	case QueryPolicy, QueryBudget, QueryTime, QueryPopulation, QuerySpace, QueryAgent:
+		return true
+	default:
+		return false
+	}
 }
 
 // UnmarshalJSON implements the custom unmarshalling logic for Command.
 func (c *Command) UnmarshalJSON(data []byte) error {
 	// Define an intermediate structure to capture the "type" and raw "payload".
 	var intermediate struct {
+		Version int              `json:"version"`
 		Type    CommandType      `json:"type"`
 		Payload *json.RawMessage `json:"payload"`
 	}
@@ -40,6 +113,15 @@ func (c *Command) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	switch {
+	case intermediate.Version == 0:
+		c.Version = 1
+	case intermediate.Version > ProtocolVersion:
+		return fmt.Errorf("unsupported protocol version %d, expected at most %d", intermediate.Version, ProtocolVersion)
+	default:
+		c.Version = intermediate.Version
+	}
+
 	c.Type = intermediate.Type
 
 	// Determine the actual type of the payload based on the "type" field.
@@ -51,6 +133,18 @@ func (c *Command) UnmarshalJSON(data []byte) error {
 	switch intermediate.Type {
 	case ApplyPolicyUpdate:
 		payload = &ApplyPolicyUpdatePayload{}
+	case SetSpeed:
+		payload = &SetSpeedPayload{}
+	case Step:
+		payload = &StepPayload{}
+	case RunUntil:
+		payload = &RunUntilPayload{}
+	case QueryPolicy, QueryPopulation:
+		payload = &QueryJurisdictionPayload{}
+	case QuerySpace, QueryAgent, KillSimulation:
+		payload = &QueryEntityPayload{}
+	case SetAgentStream:
+		payload = &AgentStreamConfig{}
 	default:
 		payload = &map[string]interface{}{}
 	}
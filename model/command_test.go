@@ -74,3 +74,109 @@ func TestDeserializeCommandWithNoPayloadFromJsonString(t *testing.T) {
 
 	assert.Equal(t, Pause, command.Type, "Expected the unmarshalled command to have the same type as the original")
 }
+
+func TestDeserializeTimeControlCommandsFromJsonString(t *testing.T) {
+	commandBytes := []byte(`
+		{
+			"type": "step",
+			"payload": {
+				"epochs": 4
+			}
+		}
+	`)
+
+	var command Command
+	err := json.Unmarshal(commandBytes, &command)
+	if err != nil {
+		t.Fatalf("Test failed due to the following Unmarshalling error: %s", err)
+	}
+
+	payload, ok := command.Payload.(*StepPayload)
+	if !ok {
+		t.Fatalf("Test failed because the payload is not of the expected type. Got %T", command.Payload)
+	}
+
+	assert.Equal(t, int64(4), payload.Epochs, "Expected the step payload to contain the number of epochs")
+
+	commandBytes = []byte(`
+		{
+			"type": "run_until",
+			"payload": {
+				"day": 30
+			}
+		}
+	`)
+
+	err = json.Unmarshal(commandBytes, &command)
+	if err != nil {
+		t.Fatalf("Test failed due to the following Unmarshalling error: %s", err)
+	}
+
+	run_until, ok := command.Payload.(*RunUntilPayload)
+	if !ok {
+		t.Fatalf("Test failed because the payload is not of the expected type. Got %T", command.Payload)
+	}
+
+	assert.Equal(t, int64(30), run_until.Day, "Expected the run_until payload to contain the day")
+}
+
+func TestDeserializeSetSpeedCommandFromJsonString(t *testing.T) {
+	commandBytes := []byte(`
+		{
+			"type": "set_speed",
+			"payload": {
+				"speed": 3600
+			}
+		}
+	`)
+
+	var command Command
+	err := json.Unmarshal(commandBytes, &command)
+	if err != nil {
+		t.Fatalf("Test failed due to the following Unmarshalling error: %s", err)
+	}
+
+	payload, ok := command.Payload.(*SetSpeedPayload)
+	if !ok {
+		t.Fatalf("Test failed because the payload is not of the expected type. Got %T", command.Payload)
+	}
+
+	assert.Equal(t, 3600.0, payload.Speed, "Expected the set_speed payload to contain the speed")
+}
+
+func TestDeserializeQueryCommandFromJsonString(t *testing.T) {
+	commandBytes := []byte(`
+		{
+			"type": "query_population",
+			"payload": {
+				"jurisdiction_id": "GLOBAL"
+			}
+		}
+	`)
+
+	var command Command
+	err := json.Unmarshal(commandBytes, &command)
+	if err != nil {
+		t.Fatalf("Test failed due to the following Unmarshalling error: %s", err)
+	}
+
+	payload, ok := command.Payload.(*QueryJurisdictionPayload)
+	if !ok {
+		t.Fatalf("Test failed because the payload is not of the expected type. Got %T", command.Payload)
+	}
+
+	assert.True(t, command.IsQuery(), "Expected query_population to be a query")
+	assert.Equal(t, "GLOBAL", payload.JurisdictionId, "Expected the query payload to contain the jurisdiction id")
+}
+
+func TestDeserializeCommandVersion(t *testing.T) {
+	var command Command
+	if err := json.Unmarshal([]byte(`{"type":"pause"}`), &command); err != nil {
+		t.Fatalf("Test failed due to the following Unmarshalling error: %s", err)
+	}
+
+	assert.Equal(t, 1, command.Version, "Expected commands without a version to be treated as version 1")
+
+	err := json.Unmarshal([]byte(`{"version":99,"type":"pause"}`), &command)
+	assert.Error(t, err, "Expected commands of a newer protocol version to be rejected")
+}
@@ -1,14 +1,31 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
 
 type Config struct {
 	Id uuid.UUID `json:"id"`
 
+	// the preset the other fields default to, see Presets
+	Preset string `json:"preset"`
+
 	// Global Params
 	TimeStep  int64 `json:"time_step"`
 	NumAgents int64 `json:"num_agents"`
 
+	// Stop Conditions (zero values never stop the simulation)
+	MaxDays              int64 `json:"max_days"`
+	StopWhenNoInfections bool  `json:"stop_when_no_infections"`
+
+	// Notification Params
+	NotificationEncoding string            `json:"notification_encoding"` // json (default) or msgpack
+	BatchEvents          bool              `json:"batch_events"`          // send one notification per epoch instead of one per event
+	AgentStream          AgentStreamConfig `json:"agent_stream"`
+
 	// Agent Params
 	ComplianceProbability        float64 `json:"compliance_probability"`
 	SeeksTreatmentProbability    float64 `json:"seeks_treatment_probability"`
@@ -17,6 +34,15 @@ type Config struct {
 	PulmonaryVentilationRateMean float64 `json:"pulmonary_ventilation_rate_mean"`
 	PulmonaryVentilationRateSd   float64 `json:"pulmonary_ventilation_rate_sd"`
 
+	// Occupation Params, the other agents can't work from home
+	KeyWorkerProbability    float64 `json:"key_worker_probability"`
+	RemoteWorkerProbability float64 `json:"remote_worker_probability"`
+
+	// Compliance Params, the defaults of the policies of every jurisdiction
+	ComplianceFatigue float64 `json:"compliance_fatigue"` // fraction of compliance lost per day of restrictions
+	RiskPerception    float64 `json:"risk_perception"`    // compliance gained per recent local case or death
+	RiskMemory        float64 `json:"risk_memory"`        // milliseconds in which a case or death fades by a factor of e
+
 	// Pathogen Params
 	IncubationPeriodMean         float64 `json:"incubation_period_mean"`
 	IncubationPeriodSd           float64 `json:"incubation_period_sd"`
@@ -34,6 +60,19 @@ type Config struct {
 	DeathProbability             float64 `json:"death_probability"` // conditional on hospitalized
 	AsymptomaticProbability      float64 `json:"asymptomatic_probability"`
 
+	// Infectiousness Params
+	InfectiousnessCurve        InfectiousnessCurve `json:"infectiousness_curve"`
+	AsymptomaticInfectiousness float64             `json:"asymptomatic_infectiousness"` // emission of asymptomatic cases relative to symptomatic ones
+
+	// Immunity Params, protection right after recovery. It wanes by a factor of e every immunity period
+	ImmunityProtection float64 `json:"immunity_protection"` // reduction of the infection probability
+	SeverityProtection float64 `json:"severity_protection"` // reduction of the hospitalization probability on reinfection
+
+	// Air Params, removal rates per hour on top of the air change rate of a space
+	ViralDecayRate float64 `json:"viral_decay_rate"` // inactivation of the pathogen in aerosols
+	DepositionRate float64 `json:"deposition_rate"`  // settling of aerosols onto surfaces
+	FiltrationRate float64 `json:"filtration_rate"`  // HEPA or UV filtration present in every space
+
 	// Household Params
 	HouseholdCapacityMean      float64 `json:"household_capacity_mean"`
 	HouseholdCapacitySd        float64 `json:"household_capacity_sd"`
@@ -69,4 +108,94 @@ type Config struct {
 	TestCapacitySd                   float64 `json:"test_capacity_sd"`
 	TestSensitivity                  float64 `json:"test_sensitivity"`
 	TestSpecificity                  float64 `json:"test_specificity"`
+
+	// Transit Params, ridden on the way between household and office
+	TransitCapacityMean      float64 `json:"transit_capacity_mean"`
+	TransitCapacitySd        float64 `json:"transit_capacity_sd"`
+	TransitAirChangeRateMean float64 `json:"transit_air_change_rate_mean"`
+	TransitAirChangeRateSd   float64 `json:"transit_air_change_rate_sd"`
+	TransitVolumeMean        float64 `json:"transit_volume_mean"`
+	TransitVolumeSd          float64 `json:"transit_volume_sd"`
+	TransitSpeed             float64 `json:"transit_speed"`     // km/h between the centroids of the household and office jurisdictions
+	TransitWaitTime          float64 `json:"transit_wait_time"` // milliseconds spent at stops on top of the ride
+
+	// Outdoor Params, social spaces in the open air
+	OutdoorCapacityMean      float64 `json:"outdoor_capacity_mean"`
+	OutdoorCapacitySd        float64 `json:"outdoor_capacity_sd"`
+	OutdoorAirChangeRateMean float64 `json:"outdoor_air_change_rate_mean"`
+	OutdoorAirChangeRateSd   float64 `json:"outdoor_air_change_rate_sd"`
+	OutdoorVolumeMean        float64 `json:"outdoor_volume_mean"`
+	OutdoorVolumeSd          float64 `json:"outdoor_volume_sd"`
+	OutdoorVisitProbability  float64 `json:"outdoor_visit_probability"` // of a social outing going outdoors
+
+	// distributions of sampled params, replacing the normal distribution of their mean and sd fields
+	Distributions map[Parameter]Distribution `json:"distributions,omitempty"`
+}
+
+// AgentStreamConfig opts the client that started a simulation into the
+// state and location updates of individual agents. Agents are sampled by
+// id, so the same agents are streamed for the whole run. The events are
+// sent in batches, one per simulated hour.
+type AgentStreamConfig struct {
+	Enabled          bool    `json:"enabled"`
+	SampleRate       float64 `json:"sample_rate"`         // fraction of agents to stream, 0 streams every agent
+	JurisdictionId   string  `json:"jurisdiction_id"`     // only stream agents living in this jurisdiction
+	MaxEventsPerHour int64   `json:"max_events_per_hour"` // events beyond this per simulated hour are dropped, 0 means no limit
+}
+
+// DefaultConfig returns the values used for fields an init message leaves
+// out, those of DefaultPreset
+func DefaultConfig() Config {
+	return presets[DefaultPreset]()
+}
+
+// UnmarshalJSON decodes a config on top of the preset it names, or
+// DefaultPreset, so that fields left out of the json take the value of the
+// preset while fields set to zero stay zero. Fields can also be overridden
+// through an "overrides" object, which takes precedence:
+//
+//	{"id": "...", "preset": "measles", "overrides": {"num_agents": 5000}}
+//
+// Unknown presets decode on top of DefaultConfig and are reported by
+// Validate.
+func (config *Config) UnmarshalJSON(data []byte) error {
+	// the alias has no methods, which stops json from recursing into this one
+	type plain Config
+
+	var selection struct {
+		Preset    string          `json:"preset"`
+		Overrides json.RawMessage `json:"overrides"`
+	}
+
+	if err := json.Unmarshal(data, &selection); err != nil {
+		return err
+	}
+
+	decoded := plain(PresetOrDefault(selection.Preset))
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if len(selection.Overrides) > 0 {
+		if err := json.Unmarshal(selection.Overrides, &decoded); err != nil {
+			return fmt.Errorf("invalid overrides: %w", err)
+		}
+	}
+
+	*config = Config(decoded)
+
+	return nil
+}
+
+// PresetOrDefault returns the config of the named preset for a decoded
+// config to start from. Unknown presets give DefaultConfig, keeping the name
+// for Validate to report.
+func PresetOrDefault(name string) Config {
+	config, err := PresetConfig(name)
+	if err != nil {
+		config = DefaultConfig()
+		config.Preset = name
+	}
+
+	return config
 }
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	config := DefaultConfig()
+	config.Id = uuid.New()
+
+	assert.NoError(t, config.Validate(), "Expected the default config to be valid")
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	config := DefaultConfig()
+	config.TimeStep = 0
+	config.NumAgents = 10
+	config.HouseholdVolumeMean = -17
+	config.DeathProbability = math.NaN()
+
+	errors := SplitErrors(config.Validate())
+
+	assert.Equal(t, []string{
+		"id must be set",
+		"time_step must be positive, got 0",
+		"num_agents must be at least 1000, got 10",
+		"death_probability must be between 0 and 1, got NaN",
+		"household_volume_mean must be positive, got -17",
+	}, errors, "Expected every problem to be reported")
+}
+
+func TestTimeStepMustDivideADay(t *testing.T) {
+	config := DefaultConfig()
+	config.Id = uuid.New()
+
+	for _, time_step := range []int64{2 * 24 * 60 * 60 * 1000, 7 * 60 * 1000} {
+		config.TimeStep = time_step
+		assert.Equal(t, []string{fmt.Sprintf("time_step must be a divisor of a day (86400000 ms), got %d", time_step)}, SplitErrors(config.Validate()))
+	}
+
+	config.TimeStep = 24 * 60 * 60 * 1000
+	assert.NoError(t, config.Validate(), "Expected a time step of a day to be valid")
+}
+
+func TestOmittedFieldsTakeTheirDefault(t *testing.T) {
+	var config Config
+	if err := json.Unmarshal([]byte(`{"num_agents": 5000, "asymptomatic_probability": 0}`), &config); err != nil {
+		t.Fatalf("Test failed because the config couldn't be decoded: %s", err)
+	}
+
+	assert.Equal(t, int64(5000), config.NumAgents, "Expected fields that are set to be kept")
+	assert.Equal(t, 0.0, config.AsymptomaticProbability, "Expected fields set to zero to stay zero")
+	assert.Equal(t, DefaultConfig().TimeStep, config.TimeStep, "Expected omitted fields to take their default")
+}
+
+func TestEveryPresetIsValid(t *testing.T) {
+	for _, name := range Presets() {
+		config, err := PresetConfig(name)
+		if err != nil {
+			t.Fatalf("Test failed because preset %s couldn't be found: %s", name, err)
+		}
+
+		config.Id = uuid.New()
+
+		assert.NoError(t, config.Validate(), "Expected preset %s to be valid", name)
+		assert.Equal(t, name, config.Preset, "Expected preset %s to carry its name", name)
+	}
+}
+
+func TestPresetIsMergedWithOverrides(t *testing.T) {
+	var config Config
+	err := json.Unmarshal([]byte(`{"preset": "measles", "num_agents": 2000, "overrides": {"num_agents": 5000, "death_probability": 0.02}}`), &config)
+	if err != nil {
+		t.Fatalf("Test failed because the config couldn't be decoded: %s", err)
+	}
+
+	measles, _ := PresetConfig("measles")
+
+	assert.Equal(t, "measles", config.Preset, "Expected the preset to be kept")
+	assert.Equal(t, int64(5000), config.NumAgents, "Expected overrides to take precedence")
+	assert.Equal(t, 0.02, config.DeathProbability, "Expected overrides to be applied")
+	assert.Equal(t, measles.IncubationPeriodMean, config.IncubationPeriodMean, "Expected other fields to take the value of the preset")
+}
+
+func TestUnknownPresetIsReported(t *testing.T) {
+	var config Config
+	if err := json.Unmarshal([]byte(`{"id": "`+uuid.NewString()+`", "preset": "smallpox"}`), &config); err != nil {
+		t.Fatalf("Test failed because the config couldn't be decoded: %s", err)
+	}
+
+	assert.Equal(t, []string{
+		"preset must be one of covid-like-2020, influenza, measles, got smallpox",
+	}, SplitErrors(config.Validate()), "Expected the unknown preset to be the only problem")
+}
@@ -0,0 +1,184 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// healthcare spaces are created for every 1000 agents, so fewer agents
+// leave nowhere to seek treatment
+const MinAgents = 1000
+
+const day_ms = 24 * 60 * 60 * 1000
+
+// Validate reports every problem with the config at once, joined with
+// errors.Join. Fields are named by their json tags.
+func (config *Config) Validate() error {
+	v := &validator{}
+
+	if config.Id == uuid.Nil {
+		v.fail("id must be set")
+	}
+
+	_, err := PresetConfig(config.Preset)
+	v.check(err == nil, "preset", "one of "+strings.Join(Presets(), ", "), config.Preset)
+
+	v.check(config.TimeStep > 0, "time_step", "positive", config.TimeStep)
+	// daily events, run_until and max_days are checked at the epoch a day ends
+	// on, so every day must end on one
+	v.check(config.TimeStep <= 0 || day_ms%config.TimeStep == 0, "time_step", fmt.Sprintf("a divisor of a day (%d ms)", day_ms), config.TimeStep)
+	v.check(config.NumAgents >= MinAgents, "num_agents", fmt.Sprintf("at least %d", MinAgents), config.NumAgents)
+	v.check(config.MaxDays >= 0, "max_days", "0 or more", config.MaxDays)
+
+	v.probability("agent_stream.sample_rate", config.AgentStream.SampleRate)
+	v.check(config.AgentStream.MaxEventsPerHour >= 0, "agent_stream.max_events_per_hour", "0 or more", config.AgentStream.MaxEventsPerHour)
+
+	// Agent Params
+	v.probability("compliance_probability", config.ComplianceProbability)
+	v.probability("seeks_treatment_probability", config.SeeksTreatmentProbability)
+	v.probability("mask_filtration_efficiency_mean", config.MaskFiltrationEfficiencyMean)
+	v.sd("mask_filtration_efficiency_sd", config.MaskFiltrationEfficiencySd)
+	v.positive("pulmonary_ventilation_rate_mean", config.PulmonaryVentilationRateMean)
+	v.sd("pulmonary_ventilation_rate_sd", config.PulmonaryVentilationRateSd)
+
+	// Occupation Params
+	v.probability("key_worker_probability", config.KeyWorkerProbability)
+	v.probability("remote_worker_probability", config.RemoteWorkerProbability)
+	v.check(config.KeyWorkerProbability+config.RemoteWorkerProbability <= 1, "remote_worker_probability", "at most 1 - key_worker_probability", config.RemoteWorkerProbability)
+
+	// Compliance Params
+	v.nonNegative("compliance_fatigue", config.ComplianceFatigue)
+	v.nonNegative("risk_perception", config.RiskPerception)
+	v.nonNegative("risk_memory", config.RiskMemory)
+
+	// Pathogen Params
+	v.positive("incubation_period_mean", config.IncubationPeriodMean)
+	v.sd("incubation_period_sd", config.IncubationPeriodSd)
+	v.positive("recovery_period_mean", config.RecoveryPeriodMean)
+	v.sd("recovery_period_sd", config.RecoveryPeriodSd)
+	v.positive("immunity_period_mean", config.ImmunityPeriodMean)
+	v.sd("immunity_period_sd", config.ImmunityPeriodSd)
+	v.positive("prehospitalization_period_mean", config.PrehospitalizationPeriodMean)
+	v.sd("prehospitalization_period_sd", config.PrehospitalizationPeriodSd)
+	v.positive("hospitalization_period_mean", config.HospitalizationPeriodMean)
+	v.sd("hospitalization_period_sd", config.HospitalizationPeriodSd)
+	v.nonNegative("quanta_emission_rate_mean", config.QuantaEmissionRateMean)
+	v.sd("quanta_emission_rate_sd", config.QuantaEmissionRateSd)
+	v.probability("hospitalization_probability", config.HospitalizationProbability)
+	v.probability("death_probability", config.DeathProbability)
+	v.probability("asymptomatic_probability", config.AsymptomaticProbability)
+
+	// Infectiousness Params
+	config.InfectiousnessCurve.validate(v)
+	v.nonNegative("asymptomatic_infectiousness", config.AsymptomaticInfectiousness)
+
+	// Immunity Params
+	v.probability("immunity_protection", config.ImmunityProtection)
+	v.probability("severity_protection", config.SeverityProtection)
+
+	// Air Params
+	v.nonNegative("viral_decay_rate", config.ViralDecayRate)
+	v.nonNegative("deposition_rate", config.DepositionRate)
+	v.nonNegative("filtration_rate", config.FiltrationRate)
+
+	v.space("household", config.HouseholdCapacityMean, config.HouseholdCapacitySd, config.HouseholdAirChangeRateMean, config.HouseholdAirChangeRateSd, config.HouseholdVolumeMean, config.HouseholdVolumeSd)
+	v.space("office", config.OfficeCapacityMean, config.OfficeCapacitySd, config.OfficeAirChangeRateMean, config.OfficeAirChangeRateSd, config.OfficeVolumeMean, config.OfficeVolumeSd)
+	v.space("social_space", config.SocialSpaceCapacityMean, config.SocialSpaceCapacitySd, config.SocialSpaceAirChangeRateMean, config.SocialSpaceAirChangeRateSd, config.SocialSpaceVolumeMean, config.SocialSpaceVolumeSd)
+	v.space("healthcare_space", config.HealthcareSpaceCapacityMean, config.HealthcareSpaceCapacitySd, config.HealthcareSpaceAirChangeRateMean, config.HealthcareSpaceAirChangeRateSd, config.HealthcareSpaceVolumeMean, config.HealthcareSpaceVolumeSd)
+
+	v.nonNegative("test_capacity_mean", config.TestCapacityMean)
+	v.sd("test_capacity_sd", config.TestCapacitySd)
+	v.probability("test_sensitivity", config.TestSensitivity)
+	v.probability("test_specificity", config.TestSpecificity)
+
+	v.space("transit", config.TransitCapacityMean, config.TransitCapacitySd, config.TransitAirChangeRateMean, config.TransitAirChangeRateSd, config.TransitVolumeMean, config.TransitVolumeSd)
+	v.positive("transit_speed", config.TransitSpeed)
+	v.nonNegative("transit_wait_time", config.TransitWaitTime)
+
+	v.space("outdoor", config.OutdoorCapacityMean, config.OutdoorCapacitySd, config.OutdoorAirChangeRateMean, config.OutdoorAirChangeRateSd, config.OutdoorVolumeMean, config.OutdoorVolumeSd)
+	v.probability("outdoor_visit_probability", config.OutdoorVisitProbability)
+
+	// sorted so that the problems are reported in the same order every time
+	parameters := make([]Parameter, 0, len(config.Distributions))
+	for parameter := range config.Distributions {
+		parameters = append(parameters, parameter)
+	}
+	slices.Sort(parameters)
+
+	for _, parameter := range parameters {
+		if _, ok := parameter_fields[parameter]; !ok {
+			v.fail(fmt.Sprintf("distributions.%s isn't a sampled parameter", parameter))
+			continue
+		}
+
+		distribution := config.Distributions[parameter]
+		distribution.validate(v, parameter)
+	}
+
+	return errors.Join(v.errs...)
+}
+
+// SplitErrors lists the messages of errors joined with errors.Join, such as
+// those returned by Validate
+func SplitErrors(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, 0)
+	for _, err := range joined.Unwrap() {
+		messages = append(messages, SplitErrors(err)...)
+	}
+
+	return messages
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(message string) {
+	v.errs = append(v.errs, errors.New(message))
+}
+
+func (v *validator) check(ok bool, field string, requirement string, value interface{}) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s must be %s, got %v", field, requirement, value))
+	}
+}
+
+// comparisons with NaN are false, so the checks reject NaN as well
+func (v *validator) positive(field string, value float64) {
+	v.check(value > 0 && !math.IsInf(value, 0), field, "positive", value)
+}
+
+func (v *validator) nonNegative(field string, value float64) {
+	v.check(value >= 0 && !math.IsInf(value, 0), field, "0 or more", value)
+}
+
+func (v *validator) sd(field string, value float64) {
+	v.nonNegative(field, value)
+}
+
+func (v *validator) probability(field string, value float64) {
+	v.check(value >= 0 && value <= 1, field, "between 0 and 1", value)
+}
+
+func (v *validator) space(prefix string, capacity_mean, capacity_sd, air_change_rate_mean, air_change_rate_sd, volume_mean, volume_sd float64) {
+	v.positive(prefix+"_capacity_mean", capacity_mean)
+	v.sd(prefix+"_capacity_sd", capacity_sd)
+	v.nonNegative(prefix+"_air_change_rate_mean", air_change_rate_mean)
+	v.sd(prefix+"_air_change_rate_sd", air_change_rate_sd)
+	v.positive(prefix+"_volume_mean", volume_mean)
+	v.sd(prefix+"_volume_sd", volume_sd)
+}
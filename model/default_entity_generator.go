@@ -1,7 +1,7 @@
 package model
 
 import (
-	"log"
+	"fmt"
 	"math"
 	"math/rand"
 
@@ -17,15 +17,51 @@ func NewDefaultEntityGenerator() *DefaultEntityGenerator {
 	return &DefaultEntityGenerator{}
 }
 
-func (g *DefaultEntityGenerator) Generate(config *Config) Entities {
-	msoa_sampler := geo.NewMSOASampler()
+func (g *DefaultEntityGenerator) Generate(config *Config) (Entities, error) {
+	msoa_sampler, err := geo.NewMSOASampler()
+	if err != nil {
+		return Entities{}, err
+	}
+
+	jurisdictions, err := jurisdictionsFromFeatures(config)
+	if err != nil {
+		return Entities{}, err
+	}
+
+	households, err := createHouseholds(config, jurisdictions, msoa_sampler)
+	if err != nil {
+		return Entities{}, err
+	}
+
+	offices, err := createOffices(config, jurisdictions, msoa_sampler)
+	if err != nil {
+		return Entities{}, err
+	}
+
+	social_spaces, err := createSocialSpaces(config, jurisdictions, msoa_sampler)
+	if err != nil {
+		return Entities{}, err
+	}
+
+	healthcare_spaces, err := createHealthCareSpaces(config, jurisdictions, msoa_sampler)
+	if err != nil {
+		return Entities{}, err
+	}
+
+	transit_spaces, err := createTransitSpaces(config, jurisdictions, msoa_sampler)
+	if err != nil {
+		return Entities{}, err
+	}
 
-	jurisdictions := jurisdictionsFromFeatures(config)
-	households := createHouseholds(config, jurisdictions, msoa_sampler)
-	offices := createOffices(config, jurisdictions, msoa_sampler)
-	social_spaces := createSocialSpaces(config, jurisdictions, msoa_sampler)
-	healthcare_spaces := createHealthCareSpaces(config, jurisdictions, msoa_sampler)
-	agents := createAgents(config, households, offices, social_spaces, healthcare_spaces)
+	outdoor_spaces, err := createOutdoorSpaces(config, jurisdictions, msoa_sampler)
+	if err != nil {
+		return Entities{}, err
+	}
+
+	agents, err := createAgents(config, households, offices, social_spaces, healthcare_spaces, transit_spaces, outdoor_spaces)
+	if err != nil {
+		return Entities{}, err
+	}
 
 	return Entities{
 		agents,
@@ -34,100 +70,181 @@ func (g *DefaultEntityGenerator) Generate(config *Config) Entities {
 		offices,
 		social_spaces,
 		healthcare_spaces,
-	}
+		transit_spaces,
+		outdoor_spaces,
+	}, nil
 }
 
-func createHouseholds(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) []*Space {
+func createHouseholds(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) ([]*Space, error) {
 	households := make([]*Space, 0)
 
 	for remaining_capacity := config.NumAgents; remaining_capacity > 0; {
-		capacity := int64(math.Max(math.Floor(sampleNormal(config.HouseholdCapacityMean, config.HouseholdCapacitySd)), 1))
+		capacity := int64(math.Max(math.Floor(config.sample(HouseholdCapacity)), 1))
 
 		if capacity > remaining_capacity {
 			capacity = remaining_capacity
 		}
 
 		household := newHousehold(config, capacity)
-		household.jurisdiction = sampleJurisdiction(jurisdictions, msoa_sampler)
+		jur, err := sampleJurisdiction(jurisdictions, msoa_sampler)
+		if err != nil {
+			return nil, err
+		}
+
+		household.jurisdiction = jur
 		households = append(households, &household)
 
 		remaining_capacity -= capacity
 	}
 
-	return households
+	return households, nil
 }
 
-func createOffices(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) []*Space {
+func createOffices(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) ([]*Space, error) {
 	offices := make([]*Space, 0)
 
 	for remaining_capacity := config.NumAgents; remaining_capacity > 0; {
-		capacity := int64(math.Max(math.Floor(sampleNormal(config.OfficeCapacityMean, config.OfficeCapacitySd)), 1))
+		capacity := int64(math.Max(math.Floor(config.sample(OfficeCapacity)), 1))
 
 		if capacity > remaining_capacity {
 			capacity = remaining_capacity
 		}
 
-		office := newOffice(config)
-		office.jurisdiction = sampleJurisdiction(jurisdictions, msoa_sampler)
+		office := newOffice(config, capacity)
+		jur, err := sampleJurisdiction(jurisdictions, msoa_sampler)
+		if err != nil {
+			return nil, err
+		}
+
+		office.jurisdiction = jur
 		offices = append(offices, &office)
 
 		remaining_capacity -= capacity
 	}
 
-	return offices
+	return offices, nil
 }
 
-func createSocialSpaces(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) []*Space {
+func createSocialSpaces(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) ([]*Space, error) {
 	social_spaces := make([]*Space, 0)
 
 	for remaining_capacity := config.NumAgents / 100; remaining_capacity > 0; {
-		capacity := int64(math.Max(math.Floor(sampleNormal(config.SocialSpaceCapacityMean, config.SocialSpaceCapacitySd)), 1))
+		capacity := int64(math.Max(math.Floor(config.sample(SocialSpaceCapacity)), 1))
 
 		if capacity > remaining_capacity {
 			capacity = remaining_capacity
 		}
 
-		social_space := newSocialSpace(config)
-		social_space.jurisdiction = sampleJurisdiction(jurisdictions, msoa_sampler)
+		social_space := newSocialSpace(config, capacity)
+		jur, err := sampleJurisdiction(jurisdictions, msoa_sampler)
+		if err != nil {
+			return nil, err
+		}
+
+		social_space.jurisdiction = jur
 		social_spaces = append(social_spaces, &social_space)
 
 		remaining_capacity -= capacity
 	}
 
-	return social_spaces
+	return social_spaces, nil
 }
 
-func createHealthCareSpaces(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) []*Space {
+func createHealthCareSpaces(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) ([]*Space, error) {
 	healthcare_spaces := make([]*Space, 0)
 
 	for remaining_capacity := (config.NumAgents / 1000) * 100; remaining_capacity > 0; {
-		capacity := int64(math.Max(math.Floor(sampleNormal(config.HealthcareSpaceCapacityMean, config.HealthcareSpaceCapacitySd)), 1))
+		capacity := int64(math.Max(math.Floor(config.sample(HealthcareSpaceCapacity)), 1))
 
 		if capacity > remaining_capacity {
 			capacity = remaining_capacity
 		}
 
-		healthcare_space := newHealthcareSpace(config)
-		healthcare_space.jurisdiction = sampleJurisdiction(jurisdictions, msoa_sampler)
+		healthcare_space := newHealthcareSpace(config, capacity)
+		jur, err := sampleJurisdiction(jurisdictions, msoa_sampler)
+		if err != nil {
+			return nil, err
+		}
+
+		healthcare_space.jurisdiction = jur
 		healthcare_spaces = append(healthcare_spaces, &healthcare_space)
 
 		remaining_capacity -= capacity
 	}
 
-	return healthcare_spaces
+	return healthcare_spaces, nil
+}
+
+// transit carries about a tenth of the agents at once
+func createTransitSpaces(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) ([]*Space, error) {
+	transit_spaces := make([]*Space, 0)
+
+	for remaining_capacity := config.NumAgents / 10; remaining_capacity > 0; {
+		capacity := int64(math.Max(math.Floor(config.sample(TransitCapacity)), 1))
+
+		if capacity > remaining_capacity {
+			capacity = remaining_capacity
+		}
+
+		transit := newTransit(config, capacity)
+		jur, err := sampleJurisdiction(jurisdictions, msoa_sampler)
+		if err != nil {
+			return nil, err
+		}
+
+		transit.jurisdiction = jur
+		transit_spaces = append(transit_spaces, &transit)
+
+		remaining_capacity -= capacity
+	}
+
+	return transit_spaces, nil
+}
+
+func createOutdoorSpaces(config *Config, jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) ([]*Space, error) {
+	outdoor_spaces := make([]*Space, 0)
+
+	for remaining_capacity := config.NumAgents / 100; remaining_capacity > 0; {
+		capacity := int64(math.Max(math.Floor(config.sample(OutdoorCapacity)), 1))
+
+		if capacity > remaining_capacity {
+			capacity = remaining_capacity
+		}
+
+		outdoor_space := newOutdoorSpace(config, capacity)
+		jur, err := sampleJurisdiction(jurisdictions, msoa_sampler)
+		if err != nil {
+			return nil, err
+		}
+
+		outdoor_space.jurisdiction = jur
+		outdoor_spaces = append(outdoor_spaces, &outdoor_space)
+
+		remaining_capacity -= capacity
+	}
+
+	return outdoor_spaces, nil
 }
 
-func createAgents(config *Config, households, offices []*Space, social_spaces []*Space, healthcare_spaces []*Space) []*Agent {
+func createAgents(config *Config, households, offices []*Space, social_spaces []*Space, healthcare_spaces []*Space, transit_spaces []*Space, outdoor_spaces []*Space) ([]*Agent, error) {
 	agents := make([]*Agent, config.NumAgents)
 
 	office_sampler := distanceWeighted(offices)
 	social_space_sampler := distanceWeighted(social_spaces)
 	healthcare_space_sampler := distanceWeighted(healthcare_spaces)
+	transit_sampler := distanceWeighted(transit_spaces)
+	outdoor_space_sampler := distanceWeighted(outdoor_spaces)
+	commute_time := commuteTime(config)
 
 	household_idx, household_allocated_capacity := 0, 0
 	for i := 0; i < int(config.NumAgents); i++ {
 		household := households[household_idx]
-		agents[i] = createAgent(config, household, office_sampler, social_space_sampler, healthcare_space_sampler)
+		agent, err := createAgent(config, household, office_sampler, social_space_sampler, healthcare_space_sampler, transit_sampler, outdoor_space_sampler, commute_time)
+		if err != nil {
+			return nil, err
+		}
+
+		agents[i] = agent
 
 		household_allocated_capacity += 1
 		if household_allocated_capacity == cap(household.occupants) {
@@ -136,41 +253,128 @@ func createAgents(config *Config, households, offices []*Space, social_spaces []
 		}
 	}
 
-	return agents
+	return agents, nil
 }
 
-func createAgent(config *Config, household *Space, office_sampler, social_space_sampler, healthcare_space_sampler func(space *Space) *Space) *Agent {
+func createAgent(config *Config, household *Space, office_sampler, social_space_sampler, healthcare_space_sampler, transit_sampler, outdoor_space_sampler func(space *Space) (*Space, error), commute_time func(from, to *Space) (float64, error)) (*Agent, error) {
 	agent := newAgent(config)
 	agent.household = household
 	agent.location = household
 
 	// create distance matrix from agent to offices
-	agent.office = office_sampler(agent.household)
+	office, err := office_sampler(agent.household)
+	if err != nil {
+		return nil, err
+	}
+
+	agent.office = office
 
 	// create distance matrix from agent to social spaces
 	num_social_spaces := int(math.Max(1, math.Floor(sampleNormal(5, 4))))
 	for i := 0; i < num_social_spaces; i++ {
-		agent.social_spaces = append(agent.social_spaces, social_space_sampler(agent.household))
+		social_space, err := social_space_sampler(agent.household)
+		if err != nil {
+			return nil, err
+		}
+
+		agent.social_spaces = append(agent.social_spaces, social_space)
 	}
 
 	// create distance matrix from agent to offices
 	num_healthcare_spaces := int(math.Max(1, math.Floor(sampleNormal(5, 4))))
 	for i := 0; i < num_healthcare_spaces; i++ {
-		agent.healthcare_spaces = append(agent.healthcare_spaces, healthcare_space_sampler(agent.household))
+		healthcare_space, err := healthcare_space_sampler(agent.household)
+		if err != nil {
+			return nil, err
+		}
+
+		agent.healthcare_spaces = append(agent.healthcare_spaces, healthcare_space)
+	}
+
+	// agents ride transit near their household
+	transit, err := transit_sampler(agent.household)
+	if err != nil {
+		return nil, err
+	}
+
+	agent.transit = transit
+
+	agent.commute_time, err = commute_time(agent.household, agent.office)
+	if err != nil {
+		return nil, err
+	}
+
+	num_outdoor_spaces := int(math.Max(1, math.Floor(sampleNormal(2, 1))))
+	for i := 0; i < num_outdoor_spaces; i++ {
+		outdoor_space, err := outdoor_space_sampler(agent.household)
+		if err != nil {
+			return nil, err
+		}
+
+		agent.outdoor_spaces = append(agent.outdoor_spaces, outdoor_space)
+	}
+
+	return &agent, nil
+}
+
+// the length of a degree of latitude. the coordinates of the features are in
+// degrees, so distances between them are converted with this approximation
+const km_per_degree = 111.32
+
+// commuteTime returns how long, in milliseconds, the transit between two
+// spaces takes: the wait at stops plus the ride between the centroids of
+// their jurisdictions at the transit speed
+func commuteTime(config *Config) func(from, to *Space) (float64, error) {
+	centroids := make(map[string]geom.Coord)
+
+	centroid := func(jur *Jurisdiction) (geom.Coord, error) {
+		if point, ok := centroids[jur.Id]; ok {
+			return point, nil
+		}
+
+		point, err := xy.Centroid(jur.Feature.Geometry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate centroid of jurisdiction %s: %w", jur.Id, err)
+		}
+
+		centroids[jur.Id] = point
+
+		return point, nil
 	}
 
-	return &agent
+	return func(from, to *Space) (float64, error) {
+		from_point, err := centroid(from.jurisdiction)
+		if err != nil {
+			return 0, err
+		}
+
+		to_point, err := centroid(to.jurisdiction)
+		if err != nil {
+			return 0, err
+		}
+
+		distance, err := calculateDistance(from_point, to_point)
+		if err != nil {
+			return 0, err
+		}
+
+		return config.TransitWaitTime + distance*km_per_degree/config.TransitSpeed*60*60*1000, nil
+	}
 }
 
-func distanceWeighted(spaces []*Space) func(space *Space) *Space {
+func distanceWeighted(spaces []*Space) func(space *Space) (*Space, error) {
 	weights_map := make(map[string][]float64)
 
-	return func(space *Space) *Space {
+	return func(space *Space) (*Space, error) {
 		if weights, ok := weights_map[space.jurisdiction.Id]; ok {
 			return randomWeightedSample(spaces, weights)
 		}
 
-		weights := calculateWeights(spaces, space)
+		weights, err := calculateWeights(spaces, space)
+		if err != nil {
+			return nil, err
+		}
+
 		weights_map[space.jurisdiction.Id] = weights
 
 		return randomWeightedSample(spaces, weights)
@@ -178,7 +382,7 @@ func distanceWeighted(spaces []*Space) func(space *Space) *Space {
 }
 
 // randomWeightedSample selects an space based on weights
-func randomWeightedSample(spaces []*Space, weights []float64) *Space {
+func randomWeightedSample(spaces []*Space, weights []float64) (*Space, error) {
 	total_weight := 0.0
 	for _, weight := range weights {
 		total_weight += weight
@@ -191,49 +395,55 @@ func randomWeightedSample(spaces []*Space, weights []float64) *Space {
 	for i, weight := range weights {
 		current_weight += weight
 		if random_weight <= current_weight {
-			return spaces[i]
+			return spaces[i], nil
 		}
 	}
 
-	log.Fatalf("this should be unreachable: current weight: %f total_weight %f", current_weight, total_weight)
-	return nil
+	return nil, fmt.Errorf("no space sampled: current weight: %f total_weight %f", current_weight, total_weight)
 }
 
 // calculateWeights calculates weights for areas based on distances to the agent's home area
-func calculateWeights(spaces []*Space, origin *Space) []float64 {
+func calculateWeights(spaces []*Space, origin *Space) ([]float64, error) {
 	weights := make([]float64, len(spaces))
 	origin_point, err := xy.Centroid(origin.jurisdiction.Feature.Geometry)
 
 	if err != nil {
-		log.Fatalf("failed to calculate centroid of jurisdiction feature geometry")
+		return nil, fmt.Errorf("failed to calculate centroid of jurisdiction %s: %w", origin.jurisdiction.Id, err)
 	}
 
 	for i, space := range spaces {
 		space_point, err := xy.Centroid(space.jurisdiction.Feature.Geometry)
 		if err != nil {
-			log.Fatalf("failed to calculate centroid of jurisdiction feature geometry")
+			return nil, fmt.Errorf("failed to calculate centroid of jurisdiction %s: %w", space.jurisdiction.Id, err)
+		}
+
+		distance, err := calculateDistance(origin_point, space_point)
+		if err != nil {
+			return nil, err
 		}
 
-		distance := calculateDistance(origin_point, space_point)
 		weights[i] = 1 / (distance + 1e-6) // Avoid division by zero
 	}
 
-	return weights
+	return weights, nil
 }
 
-func calculateDistance(p1, p2 geom.Coord) float64 {
+func calculateDistance(p1, p2 geom.Coord) (float64, error) {
 	if len(p1) < 2 || len(p2) < 2 {
-		log.Fatalf("Invalid coordinates: %v, %v", p1, p2)
+		return 0, fmt.Errorf("invalid coordinates: %v, %v", p1, p2)
 	}
 
 	dx := p1[0] - p2[0]
 	dy := p1[1] - p2[1]
 
-	return math.Sqrt(dx*dx + dy*dy)
+	return math.Sqrt(dx*dx + dy*dy), nil
 }
 
-func jurisdictionsFromFeatures(config *Config) []*Jurisdiction {
-	features := geo.LoadFeatures()
+func jurisdictionsFromFeatures(config *Config) ([]*Jurisdiction, error) {
+	features, err := geo.LoadFeatures()
+	if err != nil {
+		return nil, err
+	}
 
 	// allocate array of length feature length + 1 (to also contain the GLOBAL jurisdiction)
 	jurisdictions := make([]*Jurisdiction, 0, len(features)+1)
@@ -271,17 +481,24 @@ func jurisdictionsFromFeatures(config *Config) []*Jurisdiction {
 
 	jurisdictions = append(jurisdictions, global_jur)
 
-	return jurisdictions
+	return jurisdictions, nil
 }
 
-func sampleJurisdiction(jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) *Jurisdiction {
-	msoa := msoa_sampler.Sample()
+func sampleJurisdiction(jurisdictions []*Jurisdiction, msoa_sampler *geo.MSOASampler) (*Jurisdiction, error) {
+	msoa, err := msoa_sampler.Sample()
+	if err != nil {
+		return nil, err
+	}
 
 	jur := findJurisdiction(jurisdictions, func(val *Jurisdiction) bool {
 		return val.Id == msoa.GISCode
 	})
 
-	return jur
+	if jur == nil {
+		return nil, fmt.Errorf("no jurisdiction feature for msoa %s", msoa.GISCode)
+	}
+
+	return jur, nil
 }
 
 func findJurisdiction(jurisdictions []*Jurisdiction, predicate func(value *Jurisdiction) bool) *Jurisdiction {
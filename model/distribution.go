@@ -0,0 +1,254 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+const NormalDistribution DistributionType = "normal"
+const LogNormalDistribution DistributionType = "lognormal"
+const GammaDistribution DistributionType = "gamma"
+const WeibullDistribution DistributionType = "weibull"
+const TruncatedNormalDistribution DistributionType = "truncated_normal"
+const FixedDistribution DistributionType = "fixed"
+
+// the parameters of Config that are sampled, named after the json tags of
+// their mean and sd fields
+const MaskFiltrationEfficiency Parameter = "mask_filtration_efficiency"
+const PulmonaryVentilationRate Parameter = "pulmonary_ventilation_rate"
+const IncubationPeriod Parameter = "incubation_period"
+const RecoveryPeriod Parameter = "recovery_period"
+const ImmunityPeriod Parameter = "immunity_period"
+const PrehospitalizationPeriod Parameter = "prehospitalization_period"
+const HospitalizationPeriod Parameter = "hospitalization_period"
+const QuantaEmissionRate Parameter = "quanta_emission_rate"
+const HouseholdCapacity Parameter = "household_capacity"
+const HouseholdAirChangeRate Parameter = "household_air_change_rate"
+const HouseholdVolume Parameter = "household_volume"
+const OfficeCapacity Parameter = "office_capacity"
+const OfficeAirChangeRate Parameter = "office_air_change_rate"
+const OfficeVolume Parameter = "office_volume"
+const SocialSpaceCapacity Parameter = "social_space_capacity"
+const SocialSpaceAirChangeRate Parameter = "social_space_air_change_rate"
+const SocialSpaceVolume Parameter = "social_space_volume"
+const HealthcareSpaceCapacity Parameter = "healthcare_space_capacity"
+const HealthcareSpaceAirChangeRate Parameter = "healthcare_space_air_change_rate"
+const HealthcareSpaceVolume Parameter = "healthcare_space_volume"
+const TestCapacity Parameter = "test_capacity"
+const TransitCapacity Parameter = "transit_capacity"
+const TransitAirChangeRate Parameter = "transit_air_change_rate"
+const TransitVolume Parameter = "transit_volume"
+const OutdoorCapacity Parameter = "outdoor_capacity"
+const OutdoorAirChangeRate Parameter = "outdoor_air_change_rate"
+const OutdoorVolume Parameter = "outdoor_volume"
+
+type DistributionType string
+
+type Parameter string
+
+// Distribution describes how the values of a parameter are spread. Which
+// fields are used depends on the type:
+//
+//	normal:           mean and sd
+//	lognormal:        mean and sd of the values, not of their logarithm
+//	gamma, weibull:   shape and scale
+//	truncated_normal: mean and sd, resampled until within min and max. A max of 0 means no upper bound
+//	fixed:            value
+type Distribution struct {
+	Type  DistributionType `json:"type"`
+	Mean  float64          `json:"mean,omitempty"`
+	Sd    float64          `json:"sd,omitempty"`
+	Shape float64          `json:"shape,omitempty"`
+	Scale float64          `json:"scale,omitempty"`
+	Min   float64          `json:"min,omitempty"`
+	Max   float64          `json:"max,omitempty"`
+	Value float64          `json:"value,omitempty"`
+}
+
+// the mean and sd fields of every parameter, which describe a normal
+// distribution unless Config.Distributions names another
+var parameter_fields = map[Parameter]func(config *Config) (mean, sd float64){
+	MaskFiltrationEfficiency: func(config *Config) (float64, float64) {
+		return config.MaskFiltrationEfficiencyMean, config.MaskFiltrationEfficiencySd
+	},
+	PulmonaryVentilationRate: func(config *Config) (float64, float64) {
+		return config.PulmonaryVentilationRateMean, config.PulmonaryVentilationRateSd
+	},
+	IncubationPeriod: func(config *Config) (float64, float64) {
+		return config.IncubationPeriodMean, config.IncubationPeriodSd
+	},
+	RecoveryPeriod: func(config *Config) (float64, float64) {
+		return config.RecoveryPeriodMean, config.RecoveryPeriodSd
+	},
+	ImmunityPeriod: func(config *Config) (float64, float64) {
+		return config.ImmunityPeriodMean, config.ImmunityPeriodSd
+	},
+	PrehospitalizationPeriod: func(config *Config) (float64, float64) {
+		return config.PrehospitalizationPeriodMean, config.PrehospitalizationPeriodSd
+	},
+	HospitalizationPeriod: func(config *Config) (float64, float64) {
+		return config.HospitalizationPeriodMean, config.HospitalizationPeriodSd
+	},
+	QuantaEmissionRate: func(config *Config) (float64, float64) {
+		return config.QuantaEmissionRateMean, config.QuantaEmissionRateSd
+	},
+	HouseholdCapacity: func(config *Config) (float64, float64) {
+		return config.HouseholdCapacityMean, config.HouseholdCapacitySd
+	},
+	HouseholdAirChangeRate: func(config *Config) (float64, float64) {
+		return config.HouseholdAirChangeRateMean, config.HouseholdAirChangeRateSd
+	},
+	HouseholdVolume: func(config *Config) (float64, float64) {
+		return config.HouseholdVolumeMean, config.HouseholdVolumeSd
+	},
+	OfficeCapacity: func(config *Config) (float64, float64) {
+		return config.OfficeCapacityMean, config.OfficeCapacitySd
+	},
+	OfficeAirChangeRate: func(config *Config) (float64, float64) {
+		return config.OfficeAirChangeRateMean, config.OfficeAirChangeRateSd
+	},
+	OfficeVolume: func(config *Config) (float64, float64) {
+		return config.OfficeVolumeMean, config.OfficeVolumeSd
+	},
+	SocialSpaceCapacity: func(config *Config) (float64, float64) {
+		return config.SocialSpaceCapacityMean, config.SocialSpaceCapacitySd
+	},
+	SocialSpaceAirChangeRate: func(config *Config) (float64, float64) {
+		return config.SocialSpaceAirChangeRateMean, config.SocialSpaceAirChangeRateSd
+	},
+	SocialSpaceVolume: func(config *Config) (float64, float64) {
+		return config.SocialSpaceVolumeMean, config.SocialSpaceVolumeSd
+	},
+	HealthcareSpaceCapacity: func(config *Config) (float64, float64) {
+		return config.HealthcareSpaceCapacityMean, config.HealthcareSpaceCapacitySd
+	},
+	HealthcareSpaceAirChangeRate: func(config *Config) (float64, float64) {
+		return config.HealthcareSpaceAirChangeRateMean, config.HealthcareSpaceAirChangeRateSd
+	},
+	HealthcareSpaceVolume: func(config *Config) (float64, float64) {
+		return config.HealthcareSpaceVolumeMean, config.HealthcareSpaceVolumeSd
+	},
+	TestCapacity: func(config *Config) (float64, float64) {
+		return config.TestCapacityMean, config.TestCapacitySd
+	},
+	TransitCapacity: func(config *Config) (float64, float64) {
+		return config.TransitCapacityMean, config.TransitCapacitySd
+	},
+	TransitAirChangeRate: func(config *Config) (float64, float64) {
+		return config.TransitAirChangeRateMean, config.TransitAirChangeRateSd
+	},
+	TransitVolume: func(config *Config) (float64, float64) {
+		return config.TransitVolumeMean, config.TransitVolumeSd
+	},
+	OutdoorCapacity: func(config *Config) (float64, float64) {
+		return config.OutdoorCapacityMean, config.OutdoorCapacitySd
+	},
+	OutdoorAirChangeRate: func(config *Config) (float64, float64) {
+		return config.OutdoorAirChangeRateMean, config.OutdoorAirChangeRateSd
+	},
+	OutdoorVolume: func(config *Config) (float64, float64) {
+		return config.OutdoorVolumeMean, config.OutdoorVolumeSd
+	},
+}
+
+// truncated normals are resampled at most this often before the sample is
+// clamped, in case the bounds are far out in a tail
+const max_truncated_samples = 100
+
+// Parameters returns the names of the sampled parameters
+func Parameters() []Parameter {
+	parameters := make([]Parameter, 0, len(parameter_fields))
+	for parameter := range parameter_fields {
+		parameters = append(parameters, parameter)
+	}
+	slices.Sort(parameters)
+
+	return parameters
+}
+
+// Distribution returns the distribution named for parameter in
+// Distributions, or the normal distribution of its mean and sd fields
+func (config *Config) Distribution(parameter Parameter) Distribution {
+	if distribution, ok := config.Distributions[parameter]; ok {
+		return distribution
+	}
+
+	mean, sd := parameter_fields[parameter](config)
+
+	return Distribution{Type: NormalDistribution, Mean: mean, Sd: sd}
+}
+
+// sample draws a value of parameter
+func (config *Config) sample(parameter Parameter) float64 {
+	distribution := config.Distribution(parameter)
+
+	return distribution.Sample()
+}
+
+func (distribution *Distribution) Sample() float64 {
+	switch distribution.Type {
+	case LogNormalDistribution:
+		// convert the moments of the values to those of their logarithm
+		variance := math.Log(1 + (distribution.Sd*distribution.Sd)/(distribution.Mean*distribution.Mean))
+		return distuv.LogNormal{
+			Mu:    math.Log(distribution.Mean) - variance/2,
+			Sigma: math.Sqrt(variance),
+		}.Rand()
+	case GammaDistribution:
+		return distuv.Gamma{Alpha: distribution.Shape, Beta: 1 / distribution.Scale}.Rand()
+	case WeibullDistribution:
+		return distuv.Weibull{K: distribution.Shape, Lambda: distribution.Scale}.Rand()
+	case TruncatedNormalDistribution:
+		return distribution.sampleTruncatedNormal()
+	case FixedDistribution:
+		return distribution.Value
+	default:
+		return sampleNormal(distribution.Mean, distribution.Sd)
+	}
+}
+
+func (distribution *Distribution) sampleTruncatedNormal() float64 {
+	max := distribution.Max
+	if max == 0 {
+		max = math.Inf(1)
+	}
+
+	var value float64
+	for i := 0; i < max_truncated_samples; i++ {
+		value = sampleNormal(distribution.Mean, distribution.Sd)
+		if value >= distribution.Min && value <= max {
+			return value
+		}
+	}
+
+	return math.Min(math.Max(value, distribution.Min), max)
+}
+
+// validate reports the problems with the distribution of parameter
+func (distribution *Distribution) validate(v *validator, parameter Parameter) {
+	field := fmt.Sprintf("distributions.%s", parameter)
+
+	// every sampled parameter is a rate, duration, volume or capacity, so
+	// the bounds of the distributions that have them can't be negative
+	switch distribution.Type {
+	case NormalDistribution:
+		v.sd(field+".sd", distribution.Sd)
+	case LogNormalDistribution:
+		v.positive(field+".mean", distribution.Mean)
+		v.sd(field+".sd", distribution.Sd)
+	case GammaDistribution, WeibullDistribution:
+		v.positive(field+".shape", distribution.Shape)
+		v.positive(field+".scale", distribution.Scale)
+	case TruncatedNormalDistribution:
+		v.sd(field+".sd", distribution.Sd)
+		v.nonNegative(field+".min", distribution.Min)
+		v.check(distribution.Max == 0 || distribution.Max >= distribution.Min, field+".max", "0 or at least min", distribution.Max)
+	case FixedDistribution:
+		v.nonNegative(field+".value", distribution.Value)
+	default:
+		v.check(false, field+".type", "one of normal, lognormal, gamma, weibull, truncated_normal or fixed", distribution.Type)
+	}
+}
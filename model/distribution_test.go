@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleMean(distribution Distribution, n int) (mean float64, min float64) {
+	min = distribution.Sample()
+	for i := 0; i < n; i++ {
+		value := distribution.Sample()
+		mean += value / float64(n)
+		min = math.Min(min, value)
+	}
+
+	return mean, min
+}
+
+func TestParametersDefaultToNormalDistributions(t *testing.T) {
+	config := DefaultConfig()
+
+	assert.Equal(t, Distribution{
+		Type: NormalDistribution,
+		Mean: config.IncubationPeriodMean,
+		Sd:   config.IncubationPeriodSd,
+	}, config.Distribution(IncubationPeriod), "Expected the mean and sd fields to describe a normal distribution")
+}
+
+func TestDistributionsAreSampled(t *testing.T) {
+	lognormal, min := sampleMean(Distribution{Type: LogNormalDistribution, Mean: 5, Sd: 3}, 20000)
+	assert.InDelta(t, 5, lognormal, 0.2, "Expected lognormal samples to have the configured mean")
+	assert.Greater(t, min, 0.0, "Expected lognormal samples to be positive")
+
+	gamma, _ := sampleMean(Distribution{Type: GammaDistribution, Shape: 2, Scale: 3}, 20000)
+	assert.InDelta(t, 6, gamma, 0.2, "Expected gamma samples to have a mean of shape times scale")
+
+	weibull, _ := sampleMean(Distribution{Type: WeibullDistribution, Shape: 1, Scale: 4}, 20000)
+	assert.InDelta(t, 4, weibull, 0.2, "Expected weibull samples with a shape of 1 to have a mean of scale")
+
+	_, min = sampleMean(Distribution{Type: TruncatedNormalDistribution, Mean: 1, Sd: 5, Min: 0.5}, 2000)
+	assert.GreaterOrEqual(t, min, 0.5, "Expected truncated normal samples to be within bounds")
+
+	fixed, _ := sampleMean(Distribution{Type: FixedDistribution, Value: 7}, 10)
+	assert.InDelta(t, 7, fixed, 1e-9, "Expected fixed samples to be the value")
+}
+
+func TestDistributionsAreReadAndValidated(t *testing.T) {
+	var config Config
+	err := json.Unmarshal([]byte(`{
+		"distributions": {
+			"incubation_period": {"type": "lognormal", "mean": 432000000, "sd": 216000000},
+			"office_volume": {"type": "gamma", "shape": 0},
+			"recovery_period": {"type": "fixed", "value": -1},
+			"household_volume": {"type": "truncated_normal", "mean": 17, "sd": 2, "min": -5},
+			"smell": {"type": "fixed", "value": 1}
+		}
+	}`), &config)
+	if err != nil {
+		t.Fatalf("Test failed because the config couldn't be decoded: %s", err)
+	}
+
+	assert.Equal(t, LogNormalDistribution, config.Distribution(IncubationPeriod).Type, "Expected the named distribution to be used")
+	assert.Subset(t, SplitErrors(config.Validate()), []string{
+		"distributions.office_volume.shape must be positive, got 0",
+		"distributions.office_volume.scale must be positive, got 0",
+		"distributions.recovery_period.value must be 0 or more, got -1",
+		"distributions.household_volume.min must be 0 or more, got -5",
+		"distributions.smell isn't a sampled parameter",
+	}, "Expected invalid distributions to be reported")
+}
@@ -1,7 +1,7 @@
 package model
 
 type EntityGenerator interface {
-	Generate(config *Config) Entities
+	Generate(config *Config) (Entities, error)
 }
 
 type Entities struct {
@@ -11,4 +11,6 @@ type Entities struct {
 	offices           []*Space
 	social_spaces     []*Space
 	healthcare_spaces []*Space
+	transit_spaces    []*Space
+	outdoor_spaces    []*Space
 }
@@ -8,18 +8,49 @@ import (
 )
 
 const SimulationInitialized logger.EventType = "simulation_initialized"
+const SimulationStateUpdate logger.EventType = "simulation_state_update"
+const SimulationEnded logger.EventType = "simulation_ended"
+const SimulationInitFailed logger.EventType = "simulation_init_failed"
 const EpochEnd logger.EventType = "epoch_end"
 const CommandProcessed logger.EventType = "command_processed"
 const AgentStateUpdate logger.EventType = "agent_state_update"
 const AgentLocationUpdate logger.EventType = "agent_location_update"
 const SpaceOccupancyUpdate logger.EventType = "space_occupancy_update"
-const SpaceTestingUpdate logger.EventType = "space_testing_udpate"
+const SpaceTestingUpdate logger.EventType = "space_testing_update"
 const PolicyUpdate logger.EventType = "policy_update"
 const BudgetUpdate logger.EventType = "budget_update"
 const CaseDetected logger.EventType = "case_detected"
+const AgentTurnedAway logger.EventType = "agent_turned_away"
+const AgentWorkedFromHome logger.EventType = "agent_worked_from_home"
+const ComplianceUpdate logger.EventType = "compliance_update"
 
 type SimulationInitializedPayload struct {
 	Jurisdictions []Jurisdiction `json:"jurisdictions"`
+	Config        Config         `json:"config"` // with the preset and overrides resolved
+
+	// jurisdiction ids mapped to the ids of their parents, so that recordings can restore the hierarchy
+	parents map[string]string
+}
+
+type SimulationStateUpdatePayload struct {
+	Epoch         int64           `json:"epoch"`
+	State         SimulationState `json:"state"`
+	PreviousState SimulationState `json:"previous_state"`
+	Reason        string          `json:"reason,omitempty"`
+}
+
+type SimulationEndedPayload struct {
+	Epoch  int64           `json:"epoch"`
+	Time   time.Time       `json:"time"`
+	State  SimulationState `json:"state"`
+	Reason string          `json:"reason,omitempty"`
+}
+
+// SimulationInitFailedPayload lists why a simulation couldn't be set up,
+// for instance every problem with its config
+type SimulationInitFailedPayload struct {
+	SimulationId uuid.UUID `json:"simulation_id"`
+	Errors       []string  `json:"errors"`
 }
 
 type EpochEndPayload struct {
@@ -31,6 +62,9 @@ type EpochEndPayload struct {
 type CommandProcessedPayload struct {
 	Epoch   int64   `json:"epoch"`
 	Command Command `json:"command"`
+
+	// number of agents in the spaces of the jurisdiction a policy update applies to. needed by the budget
+	affected_population int64
 }
 
 type AgentStateUpdatePayload struct {
@@ -39,6 +73,7 @@ type AgentStateUpdatePayload struct {
 	State               AgentState `json:"state"`
 	PreviousState       AgentState `json:"previous_state"`
 	HasInfectionProfile bool       `json:"has_infection_profile"`
+	InfectionCount      int64      `json:"infection_count"` // including the current infection
 
 	// needed for metrics aggregation. not public and therefore not a json serialized field
 	jurisdiction *Jurisdiction
@@ -50,7 +85,11 @@ type AgentLocationUpdatePayload struct {
 	LocationId         uuid.UUID `json:"location_id"`
 	PreviousLocationId uuid.UUID `json:"previous_location_id"`
 
-	agent *Agent
+	// needed for metrics and budget aggregation. captured when the agent moves
+	// since the agent keeps changing after the event is logged
+	jurisdiction    *Jurisdiction
+	location_type   SpaceType
+	next_move_epoch int64
 }
 
 type SpaceOccupancyUpdatePayload struct {
@@ -60,6 +99,9 @@ type SpaceOccupancyUpdatePayload struct {
 		Id    uuid.UUID  `json:"id"`
 		State AgentState `json:"state"`
 	} `json:"occupants"`
+
+	// infectious doses per cubic meter of air
+	DoseConcentration float64 `json:"dose_concentration"`
 }
 
 type SpaceTestingUpdatePayload struct {
@@ -90,6 +132,39 @@ type CaseDetectedPayload struct {
 	jurisdiction *Jurisdiction
 }
 
+// AgentTurnedAwayPayload reports an agent that couldn't enter a space
+// because it was closed or at its capacity cap
+type AgentTurnedAwayPayload struct {
+	Epoch          int64          `json:"epoch"`
+	Id             uuid.UUID      `json:"id"`
+	SpaceId        uuid.UUID      `json:"space_id"`
+	SpaceType      SpaceType      `json:"space_type"`
+	Reason         TurnAwayReason `json:"reason"`
+	JurisdictionId string         `json:"jurisdiction_id"` // of the space
+
+	jurisdiction *Jurisdiction
+}
+
+// AgentWorkedFromHomePayload reports an agent that works from home until
+// NextMoveEpoch instead of going to its office
+type AgentWorkedFromHomePayload struct {
+	Epoch         int64      `json:"epoch"`
+	Id            uuid.UUID  `json:"id"`
+	Occupation    Occupation `json:"occupation"`
+	NextMoveEpoch int64      `json:"next_move_epoch"`
+}
+
+// ComplianceUpdatePayload reports the daily compliance rate of a
+// jurisdiction, after fatigue and risk perception
+type ComplianceUpdatePayload struct {
+	Epoch          int64   `json:"epoch"`
+	JurisdictionId string  `json:"jurisdiction_id"`
+	ComplianceRate float64 `json:"compliance_rate"` // averaged over the residents of parent jurisdictions
+	RecentRisk     float64 `json:"recent_risk"`     // recent local cases and deaths, faded by the risk memory
+
+	jurisdiction *Jurisdiction
+}
+
 func (payload *CaseDetectedPayload) Jurisdiction() *Jurisdiction {
 	return payload.jurisdiction
 }
@@ -98,6 +173,20 @@ func (payload *AgentStateUpdatePayload) Jurisdiction() *Jurisdiction {
 	return payload.jurisdiction
 }
 
+// Jurisdiction is the agent's home jurisdiction
+func (payload *AgentLocationUpdatePayload) Jurisdiction() *Jurisdiction {
+	return payload.jurisdiction
+}
+
 func (payload *SpaceTestingUpdatePayload) Jurisdiction() *Jurisdiction {
 	return payload.jurisdiction
 }
+
+// Jurisdiction is the jurisdiction of the space
+func (payload *AgentTurnedAwayPayload) Jurisdiction() *Jurisdiction {
+	return payload.jurisdiction
+}
+
+func (payload *ComplianceUpdatePayload) Jurisdiction() *Jurisdiction {
+	return payload.jurisdiction
+}
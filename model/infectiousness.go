@@ -0,0 +1,53 @@
+package model
+
+import "fmt"
+
+// InfectiousnessCurve scales the quanta emission rate of an infection over
+// time. Its points are ordered by time, the level is interpolated linearly
+// between them and holds after the last point. There is no emission before
+// the first point, so start with a level of 0 for a gradual rise.
+//
+// An empty curve emits at the full rate from symptom onset, i.e. once the
+// agent becomes infectious, and not before.
+type InfectiousnessCurve []InfectiousnessPoint
+
+type InfectiousnessPoint struct {
+	Time  float64 `json:"time"`  // milliseconds since symptom onset, negative before it
+	Level float64 `json:"level"` // fraction of the quanta emission rate of the infection
+}
+
+// level of infectiousness time milliseconds after symptom onset
+func (curve InfectiousnessCurve) level(time float64) float64 {
+	if len(curve) == 0 {
+		if time < 0 {
+			return 0
+		}
+
+		return 1
+	}
+
+	if time < curve[0].Time {
+		return 0
+	}
+
+	for idx := 1; idx < len(curve); idx++ {
+		from, to := curve[idx-1], curve[idx]
+		if time < to.Time {
+			return from.Level + (to.Level-from.Level)*(time-from.Time)/(to.Time-from.Time)
+		}
+	}
+
+	return curve[len(curve)-1].Level
+}
+
+func (curve InfectiousnessCurve) validate(v *validator) {
+	for idx, point := range curve {
+		field := fmt.Sprintf("infectiousness_curve[%d]", idx)
+
+		v.nonNegative(field+".level", point.Level)
+
+		if idx > 0 {
+			v.check(point.Time > curve[idx-1].Time, field+".time", "later than the time of the previous point", point.Time)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfectiousnessCurveIsInterpolated(t *testing.T) {
+	curve := InfectiousnessCurve{
+		{Time: -2, Level: 0},
+		{Time: 0, Level: 1},
+		{Time: 4, Level: 0.2},
+	}
+
+	assert.Equal(t, 0.0, curve.level(-3), "Expected no emission before the first point")
+	assert.Equal(t, 0.5, curve.level(-1), "Expected the level to rise towards symptom onset")
+	assert.InDelta(t, 0.6, curve.level(2), 1e-9, "Expected the level to fall after symptom onset")
+	assert.Equal(t, 0.2, curve.level(10), "Expected the last level to hold")
+
+	assert.Equal(t, 0.0, InfectiousnessCurve{}.level(-1), "Expected an empty curve not to emit before symptom onset")
+	assert.Equal(t, 1.0, InfectiousnessCurve{}.level(0), "Expected an empty curve to emit at the full rate from symptom onset")
+}
+
+func TestEmissionFollowsTheInfectiousnessCurve(t *testing.T) {
+	config := DefaultConfig()
+	config.InfectiousnessCurve = InfectiousnessCurve{{Time: -1000, Level: 0.5}}
+	config.AsymptomaticInfectiousness = 0.2
+
+	pathogen := newPathogen(&config)
+	profile := &InfectionProfile{incubation_period: 5000, quanta_emission_rate: 100}
+
+	assert.Equal(t, 0.0, pathogen.quantaEmissionRate(profile, 3000), "Expected no emission early in the incubation period")
+	assert.Equal(t, 50.0, pathogen.quantaEmissionRate(profile, 4500), "Expected presymptomatic emission")
+
+	profile.is_asymptomatic = true
+
+	assert.Equal(t, 10.0, pathogen.quantaEmissionRate(profile, 4500), "Expected asymptomatic cases to emit less")
+}
@@ -1,7 +1,7 @@
 package model
 
 import (
-	"encoding/json"
+	"math"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/geo"
 	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
@@ -15,6 +15,13 @@ type Jurisdiction struct {
 	// non serialized fields
 	parent   *Jurisdiction
 	children []*Jurisdiction
+
+	// epoch the current restrictions started, -1 while there are none
+	restricted_since int64
+
+	// recent local cases and deaths, faded up to risk_epoch
+	risk       float64
+	risk_epoch int64
 }
 
 func (jur *Jurisdiction) Parent() *Jurisdiction {
@@ -29,8 +36,13 @@ func newJurisdiction(config *Config, id string, feature *geo.Feature) *Jurisdict
 			TestStrategy:           TestNone,
 			TestCapacityMultiplier: 1,
 			ComplianceProbability:  config.ComplianceProbability,
+			ComplianceFatigue:      config.ComplianceFatigue,
+			RiskPerception:         config.RiskPerception,
+			VentilationImprovement: make(map[SpaceType]float64),
+			ClosedSpaces:           make(map[SpaceType]bool),
 		},
-		Feature: feature,
+		Feature:          feature,
+		restricted_since: -1,
 	}
 
 	return &jur
@@ -56,6 +68,10 @@ func (jur *Jurisdiction) applyPolicyUpdate(sim *Simulation, update *ApplyPolicyU
 		jur.Policy.IsLockdown = *update.IsLockdown
 	}
 
+	if update.IsWorkFromHomeMandate != nil {
+		jur.Policy.IsWorkFromHomeMandate = *update.IsWorkFromHomeMandate
+	}
+
 	if update.IsMaskMandate != nil {
 		jur.Policy.IsMaskMandate = *update.IsMaskMandate
 	}
@@ -80,15 +96,60 @@ func (jur *Jurisdiction) applyPolicyUpdate(sim *Simulation, update *ApplyPolicyU
 		jur.Policy.ComplianceProbability = *update.ComplianceProbability
 	}
 
-	var updatedPolicy Policy
-	policyBytes, _ := json.Marshal(jur.Policy)
-	json.Unmarshal(policyBytes, &updatedPolicy)
+	if update.ComplianceFatigue != nil {
+		jur.Policy.ComplianceFatigue = math.Max(*update.ComplianceFatigue, 0)
+	}
+
+	if update.RiskPerception != nil {
+		jur.Policy.RiskPerception = math.Max(*update.RiskPerception, 0)
+	}
+
+	if len(update.VentilationImprovement) > 0 && jur.Policy.VentilationImprovement == nil {
+		jur.Policy.VentilationImprovement = make(map[SpaceType]float64)
+	}
+
+	for space_type, improvement := range update.VentilationImprovement {
+		jur.Policy.VentilationImprovement[space_type] = math.Max(improvement, 0)
+	}
+
+	if update.IsTransitMaskMandate != nil {
+		jur.Policy.IsTransitMaskMandate = *update.IsTransitMaskMandate
+	}
+
+	if update.TransitCapacityCap != nil {
+		jur.Policy.TransitCapacityCap = capacityCapFromUpdate(*update.TransitCapacityCap)
+	}
+
+	if update.OutdoorCapacityCap != nil {
+		jur.Policy.OutdoorCapacityCap = capacityCapFromUpdate(*update.OutdoorCapacityCap)
+	}
+
+	if update.VenueCapacityCap != nil {
+		jur.Policy.VenueCapacityCap = capacityCapFromUpdate(*update.VenueCapacityCap)
+	}
+
+	if len(update.ClosedSpaces) > 0 && jur.Policy.ClosedSpaces == nil {
+		jur.Policy.ClosedSpaces = make(map[SpaceType]bool)
+	}
+
+	for space_type, is_closed := range update.ClosedSpaces {
+		jur.Policy.ClosedSpaces[space_type] = is_closed
+	}
+
+	// fatigue builds up from the first restriction and only resets once
+	// all of them are lifted
+	switch is_restrictive := jur.Policy.isRestrictive(); {
+	case is_restrictive && jur.restricted_since < 0:
+		jur.restricted_since = sim.epoch
+	case !is_restrictive:
+		jur.restricted_since = -1
+	}
 
 	sim.logger.Log(logger.Event{
 		Type: PolicyUpdate,
 		Payload: PolicyUpdatePayload{
 			JurisdictionId: jur.Id,
-			Policy:         updatedPolicy,
+			Policy:         jur.Policy.clone(),
 		},
 	})
 
@@ -98,6 +159,111 @@ func (jur *Jurisdiction) applyPolicyUpdate(sim *Simulation, update *ApplyPolicyU
 	}
 }
 
+// capacityCapFromUpdate lifts the cap when the update is negative
+func capacityCapFromUpdate(capacity_cap float64) *float64 {
+	if capacity_cap < 0 {
+		return nil
+	}
+
+	return &capacity_cap
+}
+
+// complianceRate is the fraction of agents that comply with the policy. It
+// falls exponentially with the days restrictions have been in place and rises
+// with the cases and deaths residents recently heard of.
+func (jur *Jurisdiction) complianceRate(sim *Simulation) float64 {
+	rate := jur.Policy.ComplianceProbability
+
+	if jur.restricted_since >= 0 {
+		days_restricted := float64(sim.epoch-jur.restricted_since) / float64(sim.epochsPerDay())
+		rate *= math.Exp(-jur.Policy.ComplianceFatigue * days_restricted)
+	}
+
+	rate += jur.Policy.RiskPerception * jur.recentRisk(sim)
+
+	return math.Min(math.Max(rate, 0), 1)
+}
+
+// recentRisk is the number of local cases and deaths, each fading by a
+// factor of e every risk memory
+func (jur *Jurisdiction) recentRisk(sim *Simulation) float64 {
+	if jur.risk == 0 || sim.config.RiskMemory <= 0 {
+		return 0
+	}
+
+	time_since := float64((sim.epoch - jur.risk_epoch) * sim.time_step)
+
+	return jur.risk * math.Exp(-time_since/sim.config.RiskMemory)
+}
+
+// perceiveRisk adds a case or death of a resident to the risk perceived in
+// the jurisdiction and the ones containing it
+func (jur *Jurisdiction) perceiveRisk(sim *Simulation) {
+	for ; jur != nil; jur = jur.parent {
+		jur.risk = jur.recentRisk(sim) + 1
+		jur.risk_epoch = sim.epoch
+	}
+}
+
+// reportedComplianceRates averages the compliance rates of the jurisdictions
+// agents live in over each jurisdiction containing them, weighted by the number
+// of residents. Jurisdictions without residents report their own rate.
+func reportedComplianceRates(sim *Simulation) map[*Jurisdiction]float64 {
+	residents := make(map[*Jurisdiction]int64)
+	for _, agent := range sim.agents {
+		if agent.household != nil && agent.household.jurisdiction != nil {
+			residents[agent.household.jurisdiction] += 1
+		}
+	}
+
+	weighted_rates := make(map[*Jurisdiction]float64)
+	weights := make(map[*Jurisdiction]int64)
+	for home, count := range residents {
+		rate := home.complianceRate(sim)
+
+		for jur := home; jur != nil; jur = jur.parent {
+			weighted_rates[jur] += rate * float64(count)
+			weights[jur] += count
+		}
+	}
+
+	rates := make(map[*Jurisdiction]float64, len(sim.jurisdictions))
+	for _, jur := range sim.jurisdictions {
+		if weights[jur] > 0 {
+			rates[jur] = weighted_rates[jur] / float64(weights[jur])
+		} else {
+			rates[jur] = jur.complianceRate(sim)
+		}
+	}
+
+	return rates
+}
+
+func (jur *Jurisdiction) dispatchComplianceUpdateEvent(sim *Simulation, compliance_rate float64) {
+	sim.logger.Log(logger.Event{
+		Type: ComplianceUpdate,
+		Payload: ComplianceUpdatePayload{
+			Epoch:          sim.epoch,
+			JurisdictionId: jur.Id,
+			ComplianceRate: compliance_rate,
+			RecentRisk:     jur.recentRisk(sim),
+
+			jurisdiction: jur,
+		},
+	})
+}
+
+// contains reports whether other is this jurisdiction or one of its descendants
+func (jur *Jurisdiction) contains(other *Jurisdiction) bool {
+	for ; other != nil; other = other.parent {
+		if other == jur {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (jur *Jurisdiction) resolvePolicy() (policy *Policy) {
 	// if jur.parent != nil {
 	// 	policy = jur.parent.resolvePolicy()
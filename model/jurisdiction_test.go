@@ -0,0 +1,177 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplianceFadesWithRestrictionsAndRisesWithRisk(t *testing.T) {
+	config := DefaultConfig()
+	config.ComplianceProbability = 0.8
+	config.ComplianceFatigue = 0.1
+	config.RiskPerception = 0.05
+	config.RiskMemory = 24 * 60 * 60 * 1000
+	sim := NewSimulation(config, nil)
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+	sim.epoch = 10
+	assert.Equal(t, 0.8, jur.complianceRate(&sim), "Expected no fatigue without restrictions")
+
+	is_lockdown := true
+	jur.applyPolicyUpdate(&sim, &ApplyPolicyUpdatePayload{IsLockdown: &is_lockdown})
+
+	is_mask_mandate := true
+	sim.epoch += 2 * sim.epochsPerDay()
+	jur.applyPolicyUpdate(&sim, &ApplyPolicyUpdatePayload{IsMaskMandate: &is_mask_mandate})
+	assert.InDelta(t, 0.8*math.Exp(-0.2), jur.complianceRate(&sim), 1e-9, "Expected fatigue to build up from the first restriction")
+
+	jur.perceiveRisk(&sim)
+	jur.perceiveRisk(&sim)
+	assert.InDelta(t, 0.8*math.Exp(-0.2)+0.1, jur.complianceRate(&sim), 1e-9, "Expected local cases and deaths to raise compliance")
+
+	sim.epoch += sim.epochsPerDay()
+	assert.InDelta(t, 2*math.Exp(-1), jur.recentRisk(&sim), 1e-9, "Expected risk to fade by a factor of e every risk memory")
+
+	is_lockdown, is_mask_mandate = false, false
+	jur.applyPolicyUpdate(&sim, &ApplyPolicyUpdatePayload{IsLockdown: &is_lockdown, IsMaskMandate: &is_mask_mandate})
+	assert.InDelta(t, 0.8+0.1*math.Exp(-1), jur.complianceRate(&sim), 1e-9, "Expected fatigue to reset once restrictions are lifted")
+
+	for i := 0; i < 100; i++ {
+		jur.perceiveRisk(&sim)
+	}
+	assert.Equal(t, 1.0, jur.complianceRate(&sim), "Expected the compliance rate to be capped")
+}
+
+func TestComplianceIsFiniteForTimeStepsLongerThanADay(t *testing.T) {
+	config := DefaultConfig()
+	config.TimeStep = 2 * 24 * 60 * 60 * 1000
+	sim := NewSimulation(config, nil)
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+	sim.jurisdictions = []*Jurisdiction{jur}
+
+	is_lockdown := true
+	jur.applyPolicyUpdate(&sim, &ApplyPolicyUpdatePayload{IsLockdown: &is_lockdown})
+
+	assert.NotPanics(t, sim.simulateEpoch, "Expected the day boundary to be found without dividing by 0")
+	assert.False(t, math.IsNaN(jur.complianceRate(&sim)), "Expected fatigue to be measured in whole days")
+}
+
+func TestRiskIsPerceivedByParentJurisdictions(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	country := newJurisdiction(&config, "E92000001", nil)
+	msoa := newJurisdiction(&config, "E02000001", nil)
+	msoa.assignParent(country)
+
+	msoa.perceiveRisk(&sim)
+
+	assert.Equal(t, 1.0, msoa.recentRisk(&sim))
+	assert.Equal(t, 1.0, country.recentRisk(&sim))
+}
+
+func TestParentJurisdictionsReportTheComplianceOfTheirResidents(t *testing.T) {
+	config := DefaultConfig()
+	config.ComplianceProbability = 0.5
+	config.RiskPerception = 0.1
+	config.RiskMemory = 24 * 60 * 60 * 1000
+	sim := NewSimulation(config, nil)
+
+	country := newJurisdiction(&config, "E92000001", nil)
+	calm := newJurisdiction(&config, "E02000001", nil)
+	alarmed := newJurisdiction(&config, "E02000002", nil)
+	calm.assignParent(country)
+	alarmed.assignParent(country)
+	sim.jurisdictions = []*Jurisdiction{country, calm, alarmed}
+
+	calm_household := newHousehold(&config, 3)
+	calm_household.jurisdiction = calm
+	alarmed_household := newHousehold(&config, 1)
+	alarmed_household.jurisdiction = alarmed
+
+	for i := 0; i < 4; i++ {
+		agent := newAgent(&config)
+		agent.household = &calm_household
+		if i == 3 {
+			agent.household = &alarmed_household
+		}
+
+		sim.agents = append(sim.agents, &agent)
+	}
+
+	for i := 0; i < 10; i++ {
+		alarmed.perceiveRisk(&sim)
+	}
+
+	rates := reportedComplianceRates(&sim)
+
+	assert.Equal(t, 0.5, rates[calm])
+	assert.Equal(t, 1.0, rates[alarmed])
+	assert.InDelta(t, (3*0.5+1.0)/4, rates[country], 1e-9, "Expected parents to report the compliance of their residents")
+}
+
+func TestQueriedPolicyDoesNotShareMapsWithTheJurisdiction(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+	sim.jurisdictions = []*Jurisdiction{jur}
+
+	result, err := sim.queryPolicy(&QueryJurisdictionPayload{JurisdictionId: "GLOBAL"})
+	if err != nil {
+		t.Fatalf("Test failed because the policy couldn't be queried: %s", err)
+	}
+
+	jur.Policy.ClosedSpaces[Office] = true
+	jur.Policy.VentilationImprovement[Office] = 2
+
+	policy := result.(PolicyUpdatePayload).Policy
+	assert.Empty(t, policy.ClosedSpaces, "Expected later policy updates not to change the queried policy")
+	assert.Empty(t, policy.VentilationImprovement, "Expected later policy updates not to change the queried policy")
+}
+
+func TestCapacityCapOfZeroClosesAndNegativeCapLiftsIt(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	reasons := make([]TurnAwayReason, 0)
+	sim.Subscribe(func(event *logger.Event) {
+		if payload, ok := event.Payload.(AgentTurnedAwayPayload); ok {
+			reasons = append(reasons, payload.Reason)
+		}
+	})
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+	transit := newTransit(&config, 10)
+	transit.jurisdiction = jur
+
+	closed := 0.0
+	jur.applyPolicyUpdate(&sim, &ApplyPolicyUpdatePayload{TransitCapacityCap: &closed})
+	agent := newAgent(&config)
+	assert.False(t, transit.admit(&sim, &agent), "Expected a cap of 0 to turn everyone away")
+
+	lifted := -1.0
+	jur.applyPolicyUpdate(&sim, &ApplyPolicyUpdatePayload{TransitCapacityCap: &lifted})
+	assert.Nil(t, jur.Policy.TransitCapacityCap, "Expected a negative cap to lift the cap")
+
+	for i := 0; i < 20; i++ {
+		transit.occupants = append(transit.occupants, nil)
+	}
+	assert.True(t, transit.hasRoom(), "Expected transit without a cap to have room")
+
+	sim.logger.Close()
+	assert.Equal(t, []TurnAwayReason{TurnedAwayClosed}, reasons, "Expected a cap of 0 to be reported as a closure")
+}
@@ -1,24 +1,21 @@
 package model
 
 type Pathogen struct {
-	incubation_period_mean         float64
-	incubation_period_sd           float64
-	recovery_period_mean           float64
-	recovery_period_sd             float64
-	immunity_period_mean           float64
-	immunity_period_sd             float64
-	prehospitalization_period_mean float64
-	prehospitalization_period_sd   float64
-	hospitalization_period_mean    float64
-	hospitalization_period_sd      float64
-	quanta_emission_rate_mean      float64
-	quanta_emission_rate_sd        float64
-	hospitalization_probability    float64
-	death_probability              float64 // conditional on hospitalized
-	asymptomatic_probability       float64
+	incubation_period           Distribution
+	recovery_period             Distribution
+	immunity_period             Distribution
+	prehospitalization_period   Distribution
+	hospitalization_period      Distribution
+	quanta_emission_rate        Distribution
+	hospitalization_probability float64
+	death_probability           float64 // conditional on hospitalized
+	asymptomatic_probability    float64
+	infectiousness              InfectiousnessCurve
+	asymptomatic_infectiousness float64
 }
 
 type InfectionProfile struct {
+	infection_epoch           int64
 	incubation_period         float64
 	recovery_period           float64
 	immunity_period           float64
@@ -32,27 +29,25 @@ type InfectionProfile struct {
 
 func newPathogen(config *Config) *Pathogen {
 	return &Pathogen{
-		incubation_period_mean:         config.IncubationPeriodMean,
-		incubation_period_sd:           config.IncubationPeriodSd,
-		recovery_period_mean:           config.RecoveryPeriodMean,
-		recovery_period_sd:             config.RecoveryPeriodSd,
-		immunity_period_mean:           config.ImmunityPeriodMean,
-		immunity_period_sd:             config.ImmunityPeriodSd,
-		prehospitalization_period_mean: config.PrehospitalizationPeriodMean,
-		prehospitalization_period_sd:   config.PrehospitalizationPeriodSd,
-		hospitalization_period_mean:    config.HospitalizationPeriodMean,
-		hospitalization_period_sd:      config.HospitalizationPeriodSd,
-		quanta_emission_rate_mean:      config.QuantaEmissionRateMean,
-		quanta_emission_rate_sd:        config.QuantaEmissionRateSd,
-		hospitalization_probability:    config.HospitalizationProbability,
-		death_probability:              config.DeathProbability,
-		asymptomatic_probability:       config.AsymptomaticProbability,
+		incubation_period:           config.Distribution(IncubationPeriod),
+		recovery_period:             config.Distribution(RecoveryPeriod),
+		immunity_period:             config.Distribution(ImmunityPeriod),
+		prehospitalization_period:   config.Distribution(PrehospitalizationPeriod),
+		hospitalization_period:      config.Distribution(HospitalizationPeriod),
+		quanta_emission_rate:        config.Distribution(QuantaEmissionRate),
+		hospitalization_probability: config.HospitalizationProbability,
+		death_probability:           config.DeathProbability,
+		asymptomatic_probability:    config.AsymptomaticProbability,
+		infectiousness:              config.InfectiousnessCurve,
+		asymptomatic_infectiousness: config.AsymptomaticInfectiousness,
 	}
 }
 
-func (pathogen *Pathogen) generateInfectionProfile() *InfectionProfile {
+// generateInfectionProfile of an infection at infection_epoch. The severity
+// protection left by past infections reduces the hospitalization probability.
+func (pathogen *Pathogen) generateInfectionProfile(infection_epoch int64, severity_protection float64) *InfectionProfile {
 	is_hospitalized := false
-	if sampleBernoulli(pathogen.hospitalization_probability) == 1 {
+	if sampleBernoulli(pathogen.hospitalization_probability*(1-severity_protection)) == 1 {
 		is_hospitalized = true
 	}
 
@@ -64,8 +59,8 @@ func (pathogen *Pathogen) generateInfectionProfile() *InfectionProfile {
 	prehospitalization_period := 0.0
 	hospitalization_period := 0.0
 	if is_hospitalized {
-		prehospitalization_period = sampleNormal(pathogen.prehospitalization_period_mean, pathogen.prehospitalization_period_sd)
-		hospitalization_period = sampleNormal(pathogen.hospitalization_period_mean, pathogen.hospitalization_period_sd)
+		prehospitalization_period = pathogen.prehospitalization_period.Sample()
+		hospitalization_period = pathogen.hospitalization_period.Sample()
 	}
 
 	is_asymptomatic := false
@@ -74,14 +69,26 @@ func (pathogen *Pathogen) generateInfectionProfile() *InfectionProfile {
 	}
 
 	return &InfectionProfile{
-		incubation_period:         sampleNormal(pathogen.incubation_period_mean, pathogen.incubation_period_sd),
-		recovery_period:           sampleNormal(pathogen.recovery_period_mean, pathogen.recovery_period_sd),
-		immunity_period:           sampleNormal(pathogen.immunity_period_mean, pathogen.immunity_period_sd),
+		infection_epoch:           infection_epoch,
+		incubation_period:         pathogen.incubation_period.Sample(),
+		recovery_period:           pathogen.recovery_period.Sample(),
+		immunity_period:           pathogen.immunity_period.Sample(),
 		prehospitalization_period: prehospitalization_period,
 		hospitalization_period:    hospitalization_period,
-		quanta_emission_rate:      sampleNormal(pathogen.quanta_emission_rate_mean, pathogen.quanta_emission_rate_sd),
+		quanta_emission_rate:      pathogen.quanta_emission_rate.Sample(),
 		is_hospitalized:           is_hospitalized,
 		is_dead:                   is_dead,
 		is_asymptomatic:           is_asymptomatic,
 	}
 }
+
+// quantaEmissionRate of an infection with profile, time milliseconds after
+// the infection
+func (pathogen *Pathogen) quantaEmissionRate(profile *InfectionProfile, time float64) float64 {
+	rate := profile.quanta_emission_rate * pathogen.infectiousness.level(time-profile.incubation_period)
+	if profile.is_asymptomatic {
+		rate *= pathogen.asymptomatic_infectiousness
+	}
+
+	return rate
+}
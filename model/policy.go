@@ -1,5 +1,7 @@
 package model
 
+import "maps"
+
 const TestEveryone TestStrategy = "everyone"
 const TestSymptomatic TestStrategy = "symptomatic"
 const TestNone TestStrategy = "none"
@@ -11,7 +13,89 @@ type Policy struct {
 	IsSelfIsolationMandate bool         `json:"is_self_isolation_mandate"`
 	IsSelfReportingMandate bool         `json:"is_self_reporting_mandate"`
 	IsLockdown             bool         `json:"is_lockdown"`
+	IsWorkFromHomeMandate  bool         `json:"is_work_from_home_mandate"` // keeps remote workers at home
 	TestStrategy           TestStrategy `json:"test_strategy"`
 	TestCapacityMultiplier float64      `json:"test_capacity_multiplier"`
 	ComplianceProbability  float64      `json:"compliance_probability"`
+
+	// compliance falls by this fraction per day of restrictions and rises by
+	// the risk perception for every recent local case or death
+	ComplianceFatigue float64 `json:"compliance_fatigue"`
+	RiskPerception    float64 `json:"risk_perception"`
+
+	// filtration added to the spaces of a type, in air changes per hour
+	VentilationImprovement map[SpaceType]float64 `json:"ventilation_improvement"`
+
+	// caps are fractions of the capacity of the spaces, nil means no cap and 0
+	// turns everyone away
+	IsTransitMaskMandate bool     `json:"is_transit_mask_mandate"`
+	TransitCapacityCap   *float64 `json:"transit_capacity_cap"`
+	OutdoorCapacityCap   *float64 `json:"outdoor_capacity_cap"`
+	VenueCapacityCap     *float64 `json:"venue_capacity_cap"` // of offices and social spaces
+
+	// space types that are closed. households and healthcare spaces can't be closed
+	ClosedSpaces map[SpaceType]bool `json:"closed_spaces"`
+}
+
+// clone deep copies the policy, so that it can be encoded by other
+// goroutines while the simulation keeps updating the original
+func (policy *Policy) clone() Policy {
+	cloned := *policy
+	cloned.VentilationImprovement = maps.Clone(policy.VentilationImprovement)
+	cloned.ClosedSpaces = maps.Clone(policy.ClosedSpaces)
+	cloned.TransitCapacityCap = clonePointer(policy.TransitCapacityCap)
+	cloned.OutdoorCapacityCap = clonePointer(policy.OutdoorCapacityCap)
+	cloned.VenueCapacityCap = clonePointer(policy.VenueCapacityCap)
+
+	return cloned
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	cloned := *value
+	return &cloned
+}
+
+// requiresMask reports whether masks are mandated in spaces of the type
+func (policy *Policy) requiresMask(space_type SpaceType) bool {
+	return policy.IsMaskMandate || (space_type == Transit && policy.IsTransitMaskMandate)
+}
+
+// capacityCap is the fraction of their capacity spaces of the type may fill,
+// nil if they aren't capped
+func (policy *Policy) capacityCap(space_type SpaceType) *float64 {
+	switch space_type {
+	case Transit:
+		return policy.TransitCapacityCap
+	case Outdoor:
+		return policy.OutdoorCapacityCap
+	case Office, SocialSpace:
+		return policy.VenueCapacityCap
+	default:
+		return nil
+	}
+}
+
+// isRestrictive reports whether the policy asks agents to comply with a mandate
+func (policy *Policy) isRestrictive() bool {
+	return policy.IsLockdown ||
+		policy.IsWorkFromHomeMandate ||
+		policy.IsMaskMandate ||
+		policy.IsTransitMaskMandate ||
+		policy.IsSelfIsolationMandate ||
+		policy.IsSelfReportingMandate
+}
+
+func (policy *Policy) isClosed(space_type SpaceType) bool {
+	if space_type == Household || space_type == HealthCareSpace {
+		return false
+	}
+
+	// a capacity cap of 0 closes the spaces as well
+	capacity_cap := policy.capacityCap(space_type)
+
+	return policy.ClosedSpaces[space_type] || (capacity_cap != nil && *capacity_cap <= 0)
 }
@@ -0,0 +1,230 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const DefaultPreset = "covid-like-2020"
+
+// the built-in configs an init message can name, see Config.UnmarshalJSON.
+// Presets differ in their pathogen, the population and its spaces are the
+// same.
+var presets = map[string]func() Config{
+	"covid-like-2020": covidLike2020,
+	"influenza":       influenza,
+	"measles":         measles,
+}
+
+// Presets returns the names of the built-in presets in alphabetical order
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// PresetConfig returns the config of the named preset. The empty name
+// stands for DefaultPreset.
+func PresetConfig(name string) (Config, error) {
+	if name == "" {
+		name = DefaultPreset
+	}
+
+	preset, ok := presets[name]
+	if !ok {
+		return Config{}, fmt.Errorf("unknown preset %q, expected one of %s", name, strings.Join(Presets(), ", "))
+	}
+
+	return preset(), nil
+}
+
+// a SARS-CoV-2 like pathogen before vaccines were available
+func covidLike2020() Config {
+	return Config{
+		Preset: "covid-like-2020",
+
+		// Global Params
+		TimeStep:  15 * 60 * 1000,
+		NumAgents: 150000,
+
+		// Agent Params
+		ComplianceProbability:        0.65,
+		SeeksTreatmentProbability:    0.4,
+		MaskFiltrationEfficiencyMean: 0.75,
+		MaskFiltrationEfficiencySd:   0.2,
+		PulmonaryVentilationRateMean: 0.36,
+		PulmonaryVentilationRateSd:   0.01,
+
+		// Occupation Params
+		KeyWorkerProbability:    0.33,
+		RemoteWorkerProbability: 0.4,
+
+		// Compliance Params
+		ComplianceFatigue: 0.01,
+		RiskPerception:    0.002,
+		RiskMemory:        7 * 24 * 60 * 60 * 1000,
+
+		// Pathogen Params
+		IncubationPeriodMean:         3 * 24 * 60 * 60 * 1000,
+		IncubationPeriodSd:           8 * 60 * 60 * 1000,
+		RecoveryPeriodMean:           7 * 24 * 60 * 60 * 1000,
+		RecoveryPeriodSd:             8 * 60 * 60 * 1000,
+		ImmunityPeriodMean:           330 * 24 * 60 * 60 * 1000,
+		ImmunityPeriodSd:             90 * 24 * 60 * 60 * 1000,
+		PrehospitalizationPeriodMean: 3 * 24 * 60 * 60 * 1000,
+		PrehospitalizationPeriodSd:   8 * 60 * 60 * 1000,
+		HospitalizationPeriodMean:    7 * 24 * 60 * 60 * 1000,
+		HospitalizationPeriodSd:      3 * 24 * 60 * 60 * 1000,
+		QuantaEmissionRateMean:       500,
+		QuantaEmissionRateSd:         150,
+		HospitalizationProbability:   0.15,
+		DeathProbability:             0.75,
+		AsymptomaticProbability:      0.10,
+
+		// Infectiousness Params, infectious from about 2 days before symptom onset
+		InfectiousnessCurve: InfectiousnessCurve{
+			{Time: -3 * 24 * 60 * 60 * 1000, Level: 0},
+			{Time: -1 * 24 * 60 * 60 * 1000, Level: 1},
+			{Time: 2 * 24 * 60 * 60 * 1000, Level: 0.6},
+			{Time: 7 * 24 * 60 * 60 * 1000, Level: 0.1},
+		},
+		AsymptomaticInfectiousness: 0.35,
+
+		// Immunity Params
+		ImmunityProtection: 0.9,
+		SeverityProtection: 0.7,
+
+		// Air Params
+		ViralDecayRate: 0.63,
+		DepositionRate: 0.24,
+		FiltrationRate: 0,
+
+		// Household Params
+		HouseholdCapacityMean:      4,
+		HouseholdCapacitySd:        2,
+		HouseholdAirChangeRateMean: 7,
+		HouseholdAirChangeRateSd:   1,
+		HouseholdVolumeMean:        17,
+		HouseholdVolumeSd:          2,
+
+		// Office Params
+		OfficeCapacityMean:      10,
+		OfficeCapacitySd:        2,
+		OfficeAirChangeRateMean: 20,
+		OfficeAirChangeRateSd:   5,
+		OfficeVolumeMean:        60,
+		OfficeVolumeSd:          20,
+
+		// Social Space Params
+		SocialSpaceCapacityMean:      10,
+		SocialSpaceCapacitySd:        2,
+		SocialSpaceAirChangeRateMean: 20,
+		SocialSpaceAirChangeRateSd:   5,
+		SocialSpaceVolumeMean:        60,
+		SocialSpaceVolumeSd:          10,
+
+		// Healthcare Space Params
+		HealthcareSpaceCapacityMean:      173,
+		HealthcareSpaceCapacitySd:        25,
+		HealthcareSpaceAirChangeRateMean: 20,
+		HealthcareSpaceAirChangeRateSd:   5,
+		HealthcareSpaceVolumeMean:        120,
+		HealthcareSpaceVolumeSd:          30,
+		TestCapacityMean:                 300,
+		TestCapacitySd:                   150,
+		TestSensitivity:                  0.7,
+		TestSpecificity:                  0.999,
+
+		// Transit Params
+		TransitCapacityMean:      60,
+		TransitCapacitySd:        20,
+		TransitAirChangeRateMean: 10,
+		TransitAirChangeRateSd:   3,
+		TransitVolumeMean:        150,
+		TransitVolumeSd:          50,
+		TransitSpeed:             25,
+		TransitWaitTime:          10 * 60 * 1000,
+
+		// Outdoor Params, the air change rate stands for the dilution by wind
+		OutdoorCapacityMean:      50,
+		OutdoorCapacitySd:        20,
+		OutdoorAirChangeRateMean: 1000,
+		OutdoorAirChangeRateSd:   200,
+		OutdoorVolumeMean:        2000,
+		OutdoorVolumeSd:          500,
+		OutdoorVisitProbability:  0.2,
+	}
+}
+
+// seasonal influenza: shorter incubation, milder and less transmissible
+func influenza() Config {
+	config := covidLike2020()
+	config.Preset = "influenza"
+
+	config.IncubationPeriodMean = 2 * 24 * 60 * 60 * 1000
+	config.IncubationPeriodSd = 12 * 60 * 60 * 1000
+	config.RecoveryPeriodMean = 5 * 24 * 60 * 60 * 1000
+	config.RecoveryPeriodSd = 24 * 60 * 60 * 1000
+	config.ImmunityPeriodMean = 365 * 24 * 60 * 60 * 1000
+	config.ImmunityPeriodSd = 120 * 24 * 60 * 60 * 1000
+	config.PrehospitalizationPeriodMean = 3 * 24 * 60 * 60 * 1000
+	config.PrehospitalizationPeriodSd = 24 * 60 * 60 * 1000
+	config.HospitalizationPeriodMean = 5 * 24 * 60 * 60 * 1000
+	config.HospitalizationPeriodSd = 2 * 24 * 60 * 60 * 1000
+	config.QuantaEmissionRateMean = 100
+	config.QuantaEmissionRateSd = 40
+	config.HospitalizationProbability = 0.01
+	config.DeathProbability = 0.1
+	config.AsymptomaticProbability = 0.16
+	config.InfectiousnessCurve = InfectiousnessCurve{
+		{Time: -1 * 24 * 60 * 60 * 1000, Level: 0},
+		{Time: 1 * 24 * 60 * 60 * 1000, Level: 1},
+		{Time: 5 * 24 * 60 * 60 * 1000, Level: 0.2},
+	}
+	config.AsymptomaticInfectiousness = 0.5
+	config.ImmunityProtection = 0.7
+	config.SeverityProtection = 0.5
+	config.ViralDecayRate = 1.1
+
+	return config
+}
+
+// measles in an unvaccinated population: long incubation, extremely
+// transmissible and lifelong immunity
+func measles() Config {
+	config := covidLike2020()
+	config.Preset = "measles"
+
+	config.IncubationPeriodMean = 11 * 24 * 60 * 60 * 1000
+	config.IncubationPeriodSd = 2 * 24 * 60 * 60 * 1000
+	config.RecoveryPeriodMean = 8 * 24 * 60 * 60 * 1000
+	config.RecoveryPeriodSd = 2 * 24 * 60 * 60 * 1000
+	config.ImmunityPeriodMean = 50 * 365 * 24 * 60 * 60 * 1000
+	config.ImmunityPeriodSd = 5 * 365 * 24 * 60 * 60 * 1000
+	config.PrehospitalizationPeriodMean = 5 * 24 * 60 * 60 * 1000
+	config.PrehospitalizationPeriodSd = 2 * 24 * 60 * 60 * 1000
+	config.HospitalizationPeriodMean = 7 * 24 * 60 * 60 * 1000
+	config.HospitalizationPeriodSd = 3 * 24 * 60 * 60 * 1000
+	config.QuantaEmissionRateMean = 3000
+	config.QuantaEmissionRateSd = 1000
+	config.HospitalizationProbability = 0.2
+	config.DeathProbability = 0.01
+	config.AsymptomaticProbability = 0.02
+	config.InfectiousnessCurve = InfectiousnessCurve{
+		{Time: -4 * 24 * 60 * 60 * 1000, Level: 0},
+		{Time: -2 * 24 * 60 * 60 * 1000, Level: 1},
+		{Time: 4 * 24 * 60 * 60 * 1000, Level: 1},
+		{Time: 8 * 24 * 60 * 60 * 1000, Level: 0.2},
+	}
+	config.AsymptomaticInfectiousness = 0.5
+	config.ImmunityProtection = 0.99
+	config.SeverityProtection = 0.9
+	config.ViralDecayRate = 0.5
+
+	return config
+}
@@ -0,0 +1,209 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type QueryResponse struct {
+	Type    CommandType `json:"type"`
+	Payload interface{} `json:"payload"`
+	Error   string      `json:"error,omitempty"`
+}
+
+type PopulationQueryResult struct {
+	JurisdictionId string               `json:"jurisdiction_id"`
+	Total          int64                `json:"total"`
+	Counts         map[AgentState]int64 `json:"counts"`
+}
+
+type SpaceQueryResult struct {
+	Id                   uuid.UUID   `json:"id"`
+	Type                 SpaceType   `json:"type"`
+	JurisdictionId       string      `json:"jurisdiction_id"`
+	Volume               float64     `json:"volume"`
+	AirChangeRate        float64     `json:"air_change_rate"`
+	TotalInfectiousDoses float64     `json:"total_infectious_doses"`
+	Occupants            []uuid.UUID `json:"occupants"`
+}
+
+type AgentQueryResult struct {
+	Id                  uuid.UUID  `json:"id"`
+	State               AgentState `json:"state"`
+	StateChangeEpoch    int64      `json:"state_change_epoch"`
+	LocationId          uuid.UUID  `json:"location_id"`
+	LocationType        SpaceType  `json:"location_type"`
+	HouseholdId         uuid.UUID  `json:"household_id"`
+	OfficeId            uuid.UUID  `json:"office_id"`
+	JurisdictionId      string     `json:"jurisdiction_id"`
+	HasInfectionProfile bool       `json:"has_infection_profile"`
+	HasSelfReported     bool       `json:"has_self_reported"`
+	InfectionCount      int64      `json:"infection_count"`
+	Occupation          Occupation `json:"occupation"`
+}
+
+// SendQuery sends a query command to the simulation and blocks until the
+// simulation loop has answered it.
+func (sim *Simulation) SendQuery(command Command) QueryResponse {
+	command.reply = make(chan QueryResponse, 1)
+
+	select {
+	case sim.commands <- command:
+		return <-command.reply
+	case <-sim.done:
+		return QueryResponse{Type: command.Type, Error: "simulation has ended"}
+	}
+}
+
+func (sim *Simulation) processQuery(command Command) {
+	response := QueryResponse{Type: command.Type}
+
+	var err error
+	switch command.Type {
+	case QueryPolicy:
+		response.Payload, err = sim.queryPolicy(command.Payload)
+	case QueryBudget:
+		response.Payload = BudgetUpdatePayload{
+			CurrentBudget: sim.budget.currentBudget(),
+		}
+	case QueryTime:
+		response.Payload = EpochEndPayload{
+			Epoch:    sim.epoch,
+			TimeStep: sim.time_step,
+			Time:     sim.time(),
+		}
+	case QueryPopulation:
+		response.Payload, err = sim.queryPopulation(command.Payload)
+	case QuerySpace:
+		response.Payload, err = sim.querySpace(command.Payload)
+	case QueryAgent:
+		response.Payload, err = sim.queryAgent(command.Payload)
+	}
+
+	if err != nil {
+		response.Error = err.Error()
+	}
+
+	// commands that didn't come through SendQuery have nobody to answer
+	if command.reply != nil {
+		command.reply <- response
+	}
+}
+
+func (sim *Simulation) queryPolicy(payload interface{}) (interface{}, error) {
+	jur, err := sim.queriedJurisdiction(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return PolicyUpdatePayload{
+		JurisdictionId: jur.Id,
+		Policy:         jur.resolvePolicy().clone(), // encoded after the simulation has moved on
+	}, nil
+}
+
+func (sim *Simulation) queryPopulation(payload interface{}) (interface{}, error) {
+	jur, err := sim.queriedJurisdiction(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	result := PopulationQueryResult{
+		JurisdictionId: jur.Id,
+		Counts: map[AgentState]int64{
+			Susceptible:  0,
+			Infected:     0,
+			Infectious:   0,
+			Hospitalized: 0,
+			Dead:         0,
+			Immune:       0,
+		},
+	}
+
+	for _, agent := range sim.agents {
+		if jur.contains(agent.household.jurisdiction) {
+			result.Total += 1
+			result.Counts[agent.state] += 1
+		}
+	}
+
+	return result, nil
+}
+
+func (sim *Simulation) querySpace(payload interface{}) (interface{}, error) {
+	query, ok := payload.(*QueryEntityPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected a space id")
+	}
+
+	for _, spaces := range [][]*Space{sim.households, sim.offices, sim.social_spaces, sim.healthcare_spaces, sim.transit_spaces, sim.outdoor_spaces} {
+		for _, space := range spaces {
+			if space.id != query.Id {
+				continue
+			}
+
+			occupants := make([]uuid.UUID, 0, len(space.occupants))
+			for _, occupant := range space.occupants {
+				occupants = append(occupants, occupant.id)
+			}
+
+			return SpaceQueryResult{
+				Id:                   space.id,
+				Type:                 space.type_,
+				JurisdictionId:       space.jurisdiction.Id,
+				Volume:               space.volume,
+				AirChangeRate:        space.air_change_rate,
+				TotalInfectiousDoses: space.total_infectious_doses,
+				Occupants:            occupants,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("space %s not found", query.Id)
+}
+
+func (sim *Simulation) queryAgent(payload interface{}) (interface{}, error) {
+	query, ok := payload.(*QueryEntityPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected an agent id")
+	}
+
+	for _, agent := range sim.agents {
+		if agent.id != query.Id {
+			continue
+		}
+
+		return AgentQueryResult{
+			Id:                  agent.id,
+			State:               agent.state,
+			StateChangeEpoch:    agent.state_change_epoch,
+			LocationId:          agent.location.id,
+			LocationType:        agent.location.type_,
+			HouseholdId:         agent.household.id,
+			OfficeId:            agent.office.id,
+			JurisdictionId:      agent.household.jurisdiction.Id,
+			HasInfectionProfile: agent.infection_profile != nil,
+			HasSelfReported:     agent.has_self_reported,
+			InfectionCount:      agent.infection_count,
+			Occupation:          agent.occupation,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("agent %s not found", query.Id)
+}
+
+func (sim *Simulation) queriedJurisdiction(payload interface{}) (*Jurisdiction, error) {
+	query, ok := payload.(*QueryJurisdictionPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected a jurisdiction id")
+	}
+
+	for _, jur := range sim.jurisdictions {
+		if jur.Id == query.JurisdictionId {
+			return jur, nil
+		}
+	}
+
+	return nil, fmt.Errorf("jurisdiction %s not found", query.JurisdictionId)
+}
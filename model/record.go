@@ -0,0 +1,195 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+)
+
+// EventRecord is an event as it is written to a recording. Payloads keep
+// some of what subscribers need in unexported fields that aren't part of
+// their JSON, so records carry those fields alongside in Context.
+type EventRecord struct {
+	Type    logger.EventType `json:"type"`
+	Payload json.RawMessage  `json:"payload"`
+	Context *EventContext    `json:"context,omitempty"`
+}
+
+type EventContext struct {
+	JurisdictionId     string            `json:"jurisdiction_id,omitempty"`
+	LocationType       SpaceType         `json:"location_type,omitempty"`
+	NextMoveEpoch      int64             `json:"next_move_epoch,omitempty"`
+	AffectedPopulation int64             `json:"affected_population,omitempty"`
+	Parents            map[string]string `json:"parents,omitempty"`
+}
+
+// EventDecoder restores recorded events. Events refer to the jurisdictions
+// of the simulation they were recorded from, which are rebuilt from its
+// SimulationInitialized event, so records must be decoded in order.
+type EventDecoder struct {
+	jurisdictions map[string]*Jurisdiction
+}
+
+func NewEventRecord(event *logger.Event) (EventRecord, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return EventRecord{}, err
+	}
+
+	return EventRecord{
+		Type:    event.Type,
+		Payload: payload,
+		Context: eventContext(event.Payload),
+	}, nil
+}
+
+func eventContext(payload interface{}) *EventContext {
+	switch payload := payload.(type) {
+	case SimulationInitializedPayload:
+		return &EventContext{Parents: payload.parents}
+	case CommandProcessedPayload:
+		if payload.affected_population == 0 {
+			return nil
+		}
+
+		return &EventContext{AffectedPopulation: payload.affected_population}
+	case AgentStateUpdatePayload:
+		return &EventContext{JurisdictionId: jurisdictionId(payload.jurisdiction)}
+	case AgentLocationUpdatePayload:
+		return &EventContext{
+			JurisdictionId: jurisdictionId(payload.jurisdiction),
+			LocationType:   payload.location_type,
+			NextMoveEpoch:  payload.next_move_epoch,
+		}
+	case SpaceTestingUpdatePayload:
+		return &EventContext{JurisdictionId: jurisdictionId(payload.jurisdiction)}
+	case CaseDetectedPayload:
+		return &EventContext{JurisdictionId: jurisdictionId(payload.jurisdiction)}
+	default:
+		return nil
+	}
+}
+
+func NewEventDecoder() *EventDecoder {
+	return &EventDecoder{
+		jurisdictions: make(map[string]*Jurisdiction),
+	}
+}
+
+// Decode restores the payload of a record to the type the simulation
+// logged it with. Payloads of unknown event types are decoded generically.
+func (decoder *EventDecoder) Decode(record EventRecord) (*logger.Event, error) {
+	event_type := CanonicalEventType(record.Type)
+	context := record.Context
+	if context == nil {
+		context = &EventContext{}
+	}
+
+	var payload interface{}
+	var err error
+
+	switch event_type {
+	case SimulationInitialized:
+		var initialized SimulationInitializedPayload
+		err = json.Unmarshal(record.Payload, &initialized)
+		initialized.parents = context.Parents
+		decoder.restoreJurisdictions(&initialized)
+		payload = initialized
+	case SimulationStateUpdate:
+		payload, err = decodePayload[SimulationStateUpdatePayload](record.Payload)
+	case SimulationEnded:
+		payload, err = decodePayload[SimulationEndedPayload](record.Payload)
+	case SimulationInitFailed:
+		payload, err = decodePayload[SimulationInitFailedPayload](record.Payload)
+	case EpochEnd:
+		payload, err = decodePayload[EpochEndPayload](record.Payload)
+	case CommandProcessed:
+		var processed CommandProcessedPayload
+		err = json.Unmarshal(record.Payload, &processed)
+		processed.affected_population = context.AffectedPopulation
+		payload = processed
+	case AgentStateUpdate:
+		var update AgentStateUpdatePayload
+		err = json.Unmarshal(record.Payload, &update)
+		update.jurisdiction = decoder.jurisdictions[context.JurisdictionId]
+		payload = update
+	case AgentLocationUpdate:
+		var update AgentLocationUpdatePayload
+		err = json.Unmarshal(record.Payload, &update)
+		update.jurisdiction = decoder.jurisdictions[context.JurisdictionId]
+		update.location_type = context.LocationType
+		update.next_move_epoch = context.NextMoveEpoch
+		payload = update
+	case SpaceOccupancyUpdate:
+		payload, err = decodePayload[SpaceOccupancyUpdatePayload](record.Payload)
+	case SpaceTestingUpdate:
+		var update SpaceTestingUpdatePayload
+		err = json.Unmarshal(record.Payload, &update)
+		update.jurisdiction = decoder.jurisdictions[context.JurisdictionId]
+		payload = update
+	case PolicyUpdate:
+		payload, err = decodePayload[PolicyUpdatePayload](record.Payload)
+	case BudgetUpdate:
+		payload, err = decodePayload[BudgetUpdatePayload](record.Payload)
+	case CaseDetected:
+		var detected CaseDetectedPayload
+		err = json.Unmarshal(record.Payload, &detected)
+		detected.jurisdiction = decoder.jurisdictions[detected.JurisdictionId]
+		payload = detected
+	case AgentWorkedFromHome:
+		payload, err = decodePayload[AgentWorkedFromHomePayload](record.Payload)
+	case AgentTurnedAway:
+		var turned_away AgentTurnedAwayPayload
+		err = json.Unmarshal(record.Payload, &turned_away)
+		turned_away.jurisdiction = decoder.jurisdictions[turned_away.JurisdictionId]
+		payload = turned_away
+	case ComplianceUpdate:
+		var compliance ComplianceUpdatePayload
+		err = json.Unmarshal(record.Payload, &compliance)
+		compliance.jurisdiction = decoder.jurisdictions[compliance.JurisdictionId]
+		payload = compliance
+	default:
+		payload, err = decodePayload[interface{}](record.Payload)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &logger.Event{Type: event_type, Payload: payload}, nil
+}
+
+func (decoder *EventDecoder) restoreJurisdictions(payload *SimulationInitializedPayload) {
+	decoder.jurisdictions = make(map[string]*Jurisdiction, len(payload.Jurisdictions))
+
+	for i := range payload.Jurisdictions {
+		jur := &payload.Jurisdictions[i]
+		jur.children = make([]*Jurisdiction, 0)
+
+		decoder.jurisdictions[jur.Id] = jur
+	}
+
+	for id, parent_id := range payload.parents {
+		jur, ok := decoder.jurisdictions[id]
+		parent, parent_ok := decoder.jurisdictions[parent_id]
+
+		if ok && parent_ok {
+			jur.assignParent(parent)
+		}
+	}
+}
+
+func decodePayload[T any](data json.RawMessage) (T, error) {
+	var payload T
+	err := json.Unmarshal(data, &payload)
+
+	return payload, err
+}
+
+func jurisdictionId(jur *Jurisdiction) string {
+	if jur == nil {
+		return ""
+	}
+
+	return jur.Id
+}
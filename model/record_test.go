@@ -0,0 +1,87 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTrip writes events the way a recording does and decodes them again
+func roundTrip(t *testing.T, events ...logger.Event) []*logger.Event {
+	decoder := NewEventDecoder()
+	decoded := make([]*logger.Event, 0, len(events))
+
+	for _, event := range events {
+		record, err := NewEventRecord(&event)
+		if err != nil {
+			t.Fatalf("Test failed because the event couldn't be recorded: %s", err)
+		}
+
+		data, _ := json.Marshal(record)
+
+		var read EventRecord
+		json.Unmarshal(data, &read)
+
+		restored, err := decoder.Decode(read)
+		if err != nil {
+			t.Fatalf("Test failed because the record couldn't be decoded: %s", err)
+		}
+
+		decoded = append(decoded, restored)
+	}
+
+	return decoded
+}
+
+func TestRecordedEventsRestoreUnserializedFields(t *testing.T) {
+	country := &Jurisdiction{Id: "E92000001", Policy: &Policy{}}
+	msoa := &Jurisdiction{Id: "E02000001", Policy: &Policy{}, parent: country}
+
+	events := roundTrip(t,
+		logger.Event{
+			Type: SimulationInitialized,
+			Payload: SimulationInitializedPayload{
+				Jurisdictions: []Jurisdiction{*country, *msoa},
+				parents:       map[string]string{msoa.Id: country.Id},
+			},
+		},
+		logger.Event{
+			Type:    AgentStateUpdate,
+			Payload: AgentStateUpdatePayload{Id: uuid.New(), State: Infected, PreviousState: Susceptible, jurisdiction: msoa},
+		},
+		logger.Event{
+			Type:    AgentLocationUpdate,
+			Payload: AgentLocationUpdatePayload{Epoch: 4, jurisdiction: msoa, location_type: Office, next_move_epoch: 36},
+		},
+		logger.Event{
+			Type:    CommandProcessed,
+			Payload: CommandProcessedPayload{Epoch: 4, Command: Command{Type: Pause}, affected_population: 12},
+		},
+	)
+
+	state_update := events[1].Payload.(AgentStateUpdatePayload)
+	location_update := events[2].Payload.(AgentLocationUpdatePayload)
+	command := events[3].Payload.(CommandProcessedPayload)
+
+	assert.Equal(t, msoa.Id, state_update.Jurisdiction().Id, "Expected the jurisdiction to be restored")
+	assert.Equal(t, country.Id, state_update.Jurisdiction().Parent().Id, "Expected the jurisdiction hierarchy to be restored")
+	assert.Equal(t, Office, location_update.location_type, "Expected the location type to be restored")
+	assert.Equal(t, int64(36), location_update.next_move_epoch, "Expected the next move epoch to be restored")
+	assert.Equal(t, Pause, command.Command.Type, "Expected the command to be restored")
+	assert.Equal(t, int64(12), command.affected_population, "Expected the affected population to be restored")
+}
+
+func TestRecordedEventsUseCurrentEventTypes(t *testing.T) {
+	decoder := NewEventDecoder()
+
+	event, err := decoder.Decode(EventRecord{Type: "space_testing_udpate", Payload: json.RawMessage(`{"positives":3}`)})
+	if err != nil {
+		t.Fatalf("Test failed because the record couldn't be decoded: %s", err)
+	}
+
+	assert.Equal(t, SpaceTestingUpdate, event.Type, "Expected the deprecated event type to be renamed")
+	assert.Equal(t, int64(3), event.Payload.(SpaceTestingUpdatePayload).Positives, "Expected the payload to be decoded")
+}
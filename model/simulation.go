@@ -2,13 +2,25 @@ package model
 
 import (
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime/debug"
 	"time"
+	"unsafe"
 
 	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
 	"github.com/google/uuid"
 )
 
+const Initializing SimulationState = "initializing"
+const Running SimulationState = "running"
+const Paused SimulationState = "paused"
+const Finished SimulationState = "finished"
+const Failed SimulationState = "failed"
+
+type SimulationState string
+
 type Simulation struct {
 	config            Config
 	pathogen          *Pathogen
@@ -22,23 +34,35 @@ type Simulation struct {
 	offices           []*Space
 	social_spaces     []*Space
 	healthcare_spaces []*Space
-	is_paused         bool
-	should_quit       bool
+	transit_spaces    []*Space
+	outdoor_spaces    []*Space
+	state             SimulationState
+	end_reason        string
+	active_infections int64
+	speed             float64
+	next_epoch_time   time.Time
+	pending_steps     int64
+	run_until_epoch   int64
 	commands          chan Command
-	logger            logger.Logger
+	cancelled         chan struct{}
+	done              chan struct{}
+	logger            *logger.Logger
+	budget            *BudgetConfig
+	log               *slog.Logger
 }
 
 func NewSimulation(config Config, entity_generator EntityGenerator) Simulation {
 	// note we are creating a logger named logger_ to avoid shadowing the package
 	logger_ := logger.NewLogger()
+	log := slog.With("sim_id", config.Id)
 
 	// attach an internal logger to log processed commands for debugging
 	logger_.Subscribe(func(event *logger.Event) {
-		switch event.Type {
-		case SimulationInitialized:
-			log.Print("simulation initialized")
-		case CommandProcessed:
-			log.Printf("processed command of type %s", event.Payload.(CommandProcessedPayload).Command.Type)
+		switch payload := event.Payload.(type) {
+		case SimulationInitializedPayload:
+			log.Info("simulation initialized", "jurisdictions", len(payload.Jurisdictions))
+		case CommandProcessedPayload:
+			log.Info("processed command", "epoch", payload.Epoch, "command_type", payload.Command.Type)
 		}
 	})
 
@@ -49,25 +73,67 @@ func NewSimulation(config Config, entity_generator EntityGenerator) Simulation {
 		start_time:       time.Now(),
 		epoch:            0,
 		time_step:        config.TimeStep,
+		state:            Initializing,
 		commands:         make(chan Command),
+		cancelled:        make(chan struct{}, 1),
+		done:             make(chan struct{}),
 		logger:           logger_,
+		log:              log,
 	}
 }
 
+// Start initializes the simulation and runs it until it finishes or fails.
+// Before returning, every subscriber has handled every logged event, so it is
+// safe to tear down whatever the subscribers publish to.
 func (sim *Simulation) Start() {
-	sim.initialize()
-	sim.infectRandomAgent()
+	defer sim.end()
+
+	if err := sim.initialize(); err != nil {
+		sim.log.Error("failed to initialize simulation", "error", err)
+		sim.logger.Log(logger.Event{
+			Type: SimulationInitFailed,
+			Payload: SimulationInitFailedPayload{
+				SimulationId: sim.config.Id,
+				Errors:       SplitErrors(err),
+			},
+		})
+		sim.setState(Failed, err.Error())
+		return
+	}
 
-	for {
-		if sim.should_quit {
-			return
+	// simulations cancelled while initializing end right away
+	select {
+	case <-sim.cancelled:
+		sim.setState(Finished, "quit")
+		return
+	default:
+	}
+
+	sim.infectRandomAgent()
+	sim.setState(Running, "")
+
+	for sim.state == Running || sim.state == Paused {
+		// while paused there is nothing to simulate, so block until a command arrives
+		if sim.state == Paused && sim.pending_steps == 0 {
+			select {
+			case command := <-sim.commands:
+				sim.processCommand(command)
+			case <-sim.cancelled:
+				sim.setState(Finished, "quit")
+			}
+
+			continue
 		}
 
 		select {
 		case command := <-sim.commands:
 			sim.processCommand(command)
-		default:
+		case <-sim.cancelled:
+			sim.setState(Finished, "quit")
+		case now := <-sim.epochDue():
+			sim.scheduleNextEpoch(now)
 			sim.simulateEpoch()
+			sim.checkStopConditions()
 		}
 	}
 }
@@ -76,16 +142,68 @@ func (sim *Simulation) Subscribe(subscriber func(event *logger.Event)) {
 	sim.logger.Subscribe(subscriber)
 }
 
+// SendCommand delivers a command to the simulation loop. Commands sent
+// after the simulation has ended are dropped.
 func (sim *Simulation) SendCommand(command Command) {
-	sim.commands <- command
+	select {
+	case sim.commands <- command:
+	case <-sim.done:
+	}
+}
+
+// Cancel ends the simulation like a quit command, without waiting for the
+// simulation loop to take commands. A simulation cancelled while it is
+// initializing ends once initialization is done.
+func (sim *Simulation) Cancel() {
+	select {
+	case sim.cancelled <- struct{}{}:
+	default:
+	}
+}
+
+// Done is closed once the simulation has stopped processing commands.
+func (sim *Simulation) Done() <-chan struct{} {
+	return sim.done
 }
 
 func (sim *Simulation) Id() uuid.UUID {
 	return sim.config.Id
 }
 
-func (sim *Simulation) initialize() {
+func (sim *Simulation) Config() Config {
+	return sim.config
+}
+
+// QueueDepths reports the events waiting for each subscriber of the
+// simulation, see logger.Logger.QueueDepths
+func (sim *Simulation) QueueDepths() []int64 {
+	return sim.logger.QueueDepths()
+}
+
+// LogBacklog reports the events waiting to be broadcast to the subscribers
+func (sim *Simulation) LogBacklog() int64 {
+	return sim.logger.Backlog()
+}
+
+// EstimateMemory gives a rough upper bound, in bytes, of the memory a
+// simulation with the given config needs once its entities are generated.
+func EstimateMemory(config *Config) int64 {
+	const bytes_per_agent = 2 * 1024
+
+	// every healthcare space buffers up to one test result per agent
+	healthcare_spaces := int64(1)
+	if config.HealthcareSpaceCapacityMean > 0 {
+		healthcare_spaces = int64(math.Ceil(float64((config.NumAgents/1000)*100) / config.HealthcareSpaceCapacityMean))
+	}
+
+	test_backlog := healthcare_spaces * config.NumAgents * int64(unsafe.Sizeof(TestResult{}))
+
+	return config.NumAgents*bytes_per_agent + test_backlog
+}
+
+func (sim *Simulation) initialize() error {
 	budgetConfig := InitialiseBudget(sim)
+	sim.budget = budgetConfig
 
 	// InitialiseBudget(&sim.logger)
 	sim.logger.Subscribe(budgetConfig.NewEventSubscriber())
@@ -93,22 +211,45 @@ func (sim *Simulation) initialize() {
 	// start broadcasting logged events to listeners
 	go sim.logger.Broadcast()
 
-	sim.generateEntities()
+	sim.logStateUpdate("", "")
+
+	if err := sim.config.Validate(); err != nil {
+		return err
+	}
+
+	if err := sim.generateEntities(); err != nil {
+		return err
+	}
 
 	var jurisdictions []Jurisdiction
 	jurisdictionsBytes, _ := json.Marshal(sim.jurisdictions)
 	json.Unmarshal(jurisdictionsBytes, &jurisdictions)
 
+	parents := make(map[string]string)
+	for _, jur := range sim.jurisdictions {
+		if jur.parent != nil {
+			parents[jur.Id] = jur.parent.Id
+		}
+	}
+
 	sim.logger.Log(logger.Event{
 		Type: SimulationInitialized,
 		Payload: SimulationInitializedPayload{
 			Jurisdictions: jurisdictions,
+			Config:        sim.config,
+
+			parents: parents,
 		},
 	})
+
+	return nil
 }
 
-func (sim *Simulation) generateEntities() {
-	entities := sim.entity_generator.Generate(&sim.config)
+func (sim *Simulation) generateEntities() error {
+	entities, err := sim.entity_generator.Generate(&sim.config)
+	if err != nil {
+		return fmt.Errorf("failed to generate entities: %w", err)
+	}
 
 	sim.agents = entities.agents
 	sim.jurisdictions = entities.jurisdictions
@@ -116,36 +257,80 @@ func (sim *Simulation) generateEntities() {
 	sim.offices = entities.offices
 	sim.social_spaces = entities.social_spaces
 	sim.healthcare_spaces = entities.healthcare_spaces
+	sim.transit_spaces = entities.transit_spaces
+	sim.outdoor_spaces = entities.outdoor_spaces
+
+	return nil
 }
 
 func (sim *Simulation) processCommand(command Command) {
+	// queries don't change the simulation, so they are answered directly
+	// instead of being logged as processed commands
+	if command.IsQuery() {
+		sim.processQuery(command)
+		return
+	}
+
 	switch command.Type {
 	case Quit:
-		sim.should_quit = true
+		sim.setState(Finished, "quit")
 	case Pause:
-		sim.is_paused = true
+		sim.setState(Paused, "")
 	case Resume:
-		sim.is_paused = false
+		sim.setState(Running, "")
+		sim.pending_steps = 0
 	case ApplyPolicyUpdate:
 		if payload, ok := command.Payload.(*ApplyPolicyUpdatePayload); ok {
 			sim.applyPolicyUpdate(*payload)
 		}
+	case SetSpeed:
+		if payload, ok := command.Payload.(*SetSpeedPayload); ok {
+			sim.speed = math.Max(payload.Speed, 0)
+			sim.next_epoch_time = time.Now()
+		}
+	case Step:
+		if payload, ok := command.Payload.(*StepPayload); ok && payload.Epochs > 0 {
+			sim.setState(Paused, "")
+			sim.pending_steps += payload.Epochs
+		}
+	case RunUntil:
+		if payload, ok := command.Payload.(*RunUntilPayload); ok {
+			if payload.Day <= 0 {
+				sim.log.Warn("ignoring run_until, the day must be 1 or more", "day", payload.Day)
+				break
+			}
+
+			sim.pending_steps = 0
+
+			// a day that has already been reached must not pause the next resume
+			if run_until_epoch := payload.Day * sim.epochsPerDay(); sim.epoch >= run_until_epoch {
+				sim.run_until_epoch = 0
+				sim.setState(Paused, "run_until reached")
+			} else {
+				sim.run_until_epoch = run_until_epoch
+				sim.setState(Running, "")
+			}
+		}
+	case SetAgentStream:
+		// the notification publishers pick this up from the processed command
+	}
+
+	processed := CommandProcessedPayload{
+		Epoch:   sim.epoch,
+		Command: command,
+	}
+
+	if payload, ok := command.Payload.(*ApplyPolicyUpdatePayload); ok {
+		processed.affected_population = sim.affectedPopulation(payload.JurisdictionId)
 	}
 
 	sim.logger.Log(logger.Event{
-		Type: CommandProcessed,
-		Payload: CommandProcessedPayload{
-			Epoch:   sim.epoch,
-			Command: command,
-		},
+		Type:    CommandProcessed,
+		Payload: processed,
 	})
 }
 
 func (sim *Simulation) simulateEpoch() {
-	if sim.is_paused {
-		return
-	}
-
 	sim.epoch = sim.epoch + 1
 
 	for _, agent := range sim.agents {
@@ -168,6 +353,22 @@ func (sim *Simulation) simulateEpoch() {
 		healthcare_space.update(sim)
 	}
 
+	for _, transit := range sim.transit_spaces {
+		transit.update(sim)
+	}
+
+	for _, outdoor_space := range sim.outdoor_spaces {
+		outdoor_space.update(sim)
+	}
+
+	// report the compliance rates with the rest of the daily metrics
+	if sim.epoch%sim.epochsPerDay() == 0 {
+		compliance_rates := reportedComplianceRates(sim)
+		for _, jur := range sim.jurisdictions {
+			jur.dispatchComplianceUpdateEvent(sim, compliance_rates[jur])
+		}
+	}
+
 	sim.logger.Log(logger.Event{
 		Type: EpochEnd,
 		Payload: EpochEndPayload{
@@ -176,6 +377,115 @@ func (sim *Simulation) simulateEpoch() {
 			Time:     sim.time(),
 		},
 	})
+
+	if sim.state == Paused && sim.pending_steps > 0 {
+		sim.pending_steps -= 1
+	}
+
+	if sim.run_until_epoch > 0 && sim.epoch >= sim.run_until_epoch {
+		sim.run_until_epoch = 0
+		sim.setState(Paused, "run_until reached")
+	}
+}
+
+func (sim *Simulation) checkStopConditions() {
+	if sim.config.MaxDays > 0 && sim.epoch >= sim.config.MaxDays*sim.epochsPerDay() {
+		sim.setState(Finished, "max_days reached")
+	}
+
+	if sim.config.StopWhenNoInfections && sim.active_infections == 0 {
+		sim.setState(Finished, "no infections left")
+	}
+}
+
+// setState moves the simulation to a new lifecycle state. Finished and
+// Failed are terminal, so once reached the state can't change again.
+func (sim *Simulation) setState(state SimulationState, reason string) {
+	if sim.state == state || sim.state == Finished || sim.state == Failed {
+		return
+	}
+
+	if state == Finished || state == Failed {
+		sim.end_reason = reason
+	}
+
+	previous_state := sim.state
+	sim.state = state
+	sim.logStateUpdate(previous_state, reason)
+}
+
+func (sim *Simulation) logStateUpdate(previous_state SimulationState, reason string) {
+	sim.logger.Log(logger.Event{
+		Type: SimulationStateUpdate,
+		Payload: SimulationStateUpdatePayload{
+			Epoch:         sim.epoch,
+			State:         sim.state,
+			PreviousState: previous_state,
+			Reason:        reason,
+		},
+	})
+}
+
+// end runs when Start returns or panics. A panic fails this simulation only,
+// rather than the whole process. The final event is logged and the logger is
+// drained so that subscribers see everything before their transports close.
+func (sim *Simulation) end() {
+	if r := recover(); r != nil {
+		sim.log.Error("simulation failed", "epoch", sim.epoch, "error", r, "stack", string(debug.Stack()))
+		sim.setState(Failed, fmt.Sprint(r))
+	}
+
+	close(sim.done)
+
+	sim.logger.Log(logger.Event{
+		Type: SimulationEnded,
+		Payload: SimulationEndedPayload{
+			Epoch:  sim.epoch,
+			Time:   sim.time(),
+			State:  sim.state,
+			Reason: sim.end_reason,
+		},
+	})
+
+	sim.logger.Close()
+}
+
+// epochDue returns a channel that is ready once the next epoch should be
+// simulated given the current speed. When the speed is unset the channel is
+// ready immediately.
+func (sim *Simulation) epochDue() <-chan time.Time {
+	now := time.Now()
+
+	if sim.speed == 0 || !sim.next_epoch_time.After(now) {
+		ready := make(chan time.Time, 1)
+		ready <- now
+
+		return ready
+	}
+
+	return time.After(sim.next_epoch_time.Sub(now))
+}
+
+func (sim *Simulation) scheduleNextEpoch(now time.Time) {
+	if sim.speed == 0 {
+		return
+	}
+
+	// wall clock duration of one epoch at the current speed
+	interval := time.Duration(float64(sim.time_step) / sim.speed * float64(time.Millisecond))
+
+	// if we have fallen behind, don't try to catch up with a burst of epochs
+	if sim.next_epoch_time.Before(now) {
+		sim.next_epoch_time = now
+	}
+
+	sim.next_epoch_time = sim.next_epoch_time.Add(interval)
+}
+
+// epochsPerDay is at least 1, so that it can be divided by even for time
+// steps Validate rejects because they are longer than a day
+func (sim *Simulation) epochsPerDay() int64 {
+	return max((24*60*60*1000)/sim.time_step, 1)
 }
 
 func (sim *Simulation) infectRandomAgent() {
@@ -2,80 +2,145 @@ package model
 
 import (
 	"testing"
+	"time"
 
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSimulationInitialization(t *testing.T) {
-	config := Config{
-		Id: uuid.New(),
-
-		// Global Params
-		TimeStep:  15 * 60 * 1000,
-		NumAgents: 150000,
-
-		// Agent Params
-		ComplianceProbability:        0.65,
-		SeeksTreatmentProbability:    0.4,
-		MaskFiltrationEfficiencyMean: 0.75,
-		MaskFiltrationEfficiencySd:   0.2,
-		PulmonaryVentilationRateMean: 0.36,
-		PulmonaryVentilationRateSd:   0.01,
-
-		// Pathogen Config
-		IncubationPeriodMean:         3 * 24 * 60 * 60 * 1000,
-		IncubationPeriodSd:           8 * 60 * 60 * 1000,
-		RecoveryPeriodMean:           7 * 24 * 60 * 60 * 1000,
-		RecoveryPeriodSd:             8 * 60 * 60 * 1000,
-		ImmunityPeriodMean:           330 * 24 * 60 * 60 * 1000,
-		ImmunityPeriodSd:             90 * 24 * 60 * 60 * 1000,
-		PrehospitalizationPeriodMean: 3 * 24 * 60 * 60 * 1000,
-		PrehospitalizationPeriodSd:   8 * 60 * 60 * 1000,
-		HospitalizationPeriodMean:    7 * 24 * 60 * 60 * 1000,
-		HospitalizationPeriodSd:      3 * 24 * 60 * 60 * 1000,
-		QuantaEmissionRateMean:       500,
-		QuantaEmissionRateSd:         150,
-		HospitalizationProbability:   0.15,
-		DeathProbability:             0.75,
-		AsymptomaticProbability:      0.10,
-
-		// Household Params
-		HouseholdCapacityMean:      4,
-		HouseholdCapacitySd:        2,
-		HouseholdAirChangeRateMean: 7,
-		HouseholdAirChangeRateSd:   1,
-		HouseholdVolumeMean:        17,
-		HouseholdVolumeSd:          2,
-
-		// Office Params
-		OfficeCapacityMean:      10,
-		OfficeCapacitySd:        2,
-		OfficeAirChangeRateMean: 20,
-		OfficeAirChangeRateSd:   5,
-		OfficeVolumeMean:        60,
-		OfficeVolumeSd:          20,
-
-		// Social Space Params
-		SocialSpaceCapacityMean:      10,
-		SocialSpaceCapacitySd:        2,
-		SocialSpaceAirChangeRateMean: 20,
-		SocialSpaceAirChangeRateSd:   5,
-		SocialSpaceVolumeMean:        60,
-		SocialSpaceVolumeSd:          10,
-
-		// Healthcare Space Params
-		HealthcareSpaceCapacityMean:      173,
-		HealthcareSpaceCapacitySd:        25,
-		HealthcareSpaceAirChangeRateMean: 20,
-		HealthcareSpaceAirChangeRateSd:   5,
-		HealthcareSpaceVolumeMean:        60,
-		HealthcareSpaceVolumeSd:          10,
-		TestCapacityMean:                 300,
-		TestCapacitySd:                   150,
-		TestSensitivity:                  0.99,
-		TestSpecificity:                  0.95,
+	config := DefaultConfig()
+	config.Id = uuid.New()
+
+	sim := NewSimulation(config, NewDefaultEntityGenerator())
+	if err := sim.initialize(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInvalidConfigFailsInitialization(t *testing.T) {
+	sim := NewSimulation(Config{Id: uuid.New()}, NewDefaultEntityGenerator())
+
+	var failed *SimulationInitFailedPayload
+	var ended *SimulationEndedPayload
+	sim.Subscribe(func(event *logger.Event) {
+		switch payload := event.Payload.(type) {
+		case SimulationInitFailedPayload:
+			failed = &payload
+		case SimulationEndedPayload:
+			ended = &payload
+		}
+	})
+
+	sim.Start()
+
+	if assert.NotNil(t, failed, "Expected the init failure to be logged") {
+		assert.Contains(t, failed.Errors, "time_step must be positive, got 0", "Expected the problems with the config to be listed")
+	}
+
+	if assert.NotNil(t, ended, "Expected the simulation to end") {
+		assert.Equal(t, Failed, ended.State, "Expected the simulation to fail")
 	}
+}
 
+func TestSimulationCancelledWhileInitializingEndsAfterInitialization(t *testing.T) {
+	config := DefaultConfig()
+	config.Id = uuid.New()
+	config.NumAgents = 1000
 	sim := NewSimulation(config, NewDefaultEntityGenerator())
-	sim.initialize()
+
+	var ended *SimulationEndedPayload
+	sim.Subscribe(func(event *logger.Event) {
+		if payload, ok := event.Payload.(SimulationEndedPayload); ok {
+			ended = &payload
+		}
+	})
+
+	sim.Cancel()
+	sim.Start()
+
+	if assert.NotNil(t, ended, "Expected the simulation to end") {
+		assert.Equal(t, Finished, ended.State)
+		assert.Equal(t, "quit", ended.Reason)
+		assert.Equal(t, int64(0), ended.Epoch, "Expected no epochs to be simulated")
+	}
+}
+
+func TestStepSimulatesEpochsWhilePaused(t *testing.T) {
+	sim := NewSimulation(DefaultConfig(), nil)
+	sim.state = Running
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	sim.processCommand(Command{Type: Step, Payload: &StepPayload{Epochs: 2}})
+	assert.Equal(t, Paused, sim.state, "Expected step to pause the simulation")
+	assert.Equal(t, int64(2), sim.pending_steps)
+
+	sim.simulateEpoch()
+	sim.simulateEpoch()
+	assert.Equal(t, int64(0), sim.pending_steps, "Expected every simulated epoch to use up a step")
+	assert.Equal(t, Paused, sim.state, "Expected the simulation to stay paused after stepping")
+
+	sim.processCommand(Command{Type: Resume})
+	assert.Equal(t, Running, sim.state)
+}
+
+func TestSetSpeedPacesEpochs(t *testing.T) {
+	sim := NewSimulation(DefaultConfig(), nil)
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	// a 15 minute time step at 900 simulated seconds per second takes a second
+	sim.processCommand(Command{Type: SetSpeed, Payload: &SetSpeedPayload{Speed: 900}})
+
+	now := time.Now()
+	sim.scheduleNextEpoch(now)
+	assert.Equal(t, now.Add(time.Second), sim.next_epoch_time, "Expected the next epoch to be due after an epoch of wall clock time")
+
+	select {
+	case <-sim.epochDue():
+		t.Fatal("Test failed because the next epoch was due before its time")
+	default:
+	}
+
+	sim.processCommand(Command{Type: SetSpeed, Payload: &SetSpeedPayload{Speed: -1}})
+	assert.Equal(t, 0.0, sim.speed, "Expected a negative speed to remove the throttle")
+
+	select {
+	case <-sim.epochDue():
+	default:
+		t.Fatal("Test failed because the next epoch wasn't due without a throttle")
+	}
+}
+
+func TestRunUntilPausesOnceTheDayIsReached(t *testing.T) {
+	sim := NewSimulation(DefaultConfig(), nil)
+	sim.state = Running
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	sim.processCommand(Command{Type: RunUntil, Payload: &RunUntilPayload{Day: 1}})
+	for sim.epoch < sim.epochsPerDay()-1 {
+		sim.simulateEpoch()
+	}
+	assert.Equal(t, Running, sim.state, "Expected the simulation to run until the end of the day")
+
+	sim.simulateEpoch()
+	assert.Equal(t, Paused, sim.state, "Expected the simulation to pause at the end of the day")
+
+	// a day that has been reached already pauses without holding back the next resume
+	sim.processCommand(Command{Type: RunUntil, Payload: &RunUntilPayload{Day: 1}})
+	assert.Equal(t, Paused, sim.state)
+
+	sim.processCommand(Command{Type: Resume})
+	sim.simulateEpoch()
+	assert.Equal(t, Running, sim.state, "Expected a past day not to pause the simulation after resuming")
+
+	sim.processCommand(Command{Type: RunUntil, Payload: &RunUntilPayload{Day: 0}})
+	assert.Equal(t, int64(0), sim.run_until_epoch, "Expected day 0 to be rejected")
+	assert.Equal(t, Running, sim.state)
 }
@@ -11,6 +11,11 @@ const Household SpaceType = "household"
 const Office SpaceType = "office"
 const SocialSpace SpaceType = "social_space"
 const HealthCareSpace SpaceType = "healthcare_space"
+const Transit SpaceType = "transit"
+const Outdoor SpaceType = "outdoor"
+
+const TurnedAwayClosed TurnAwayReason = "closed"
+const TurnedAwayFull TurnAwayReason = "full"
 
 type Space struct {
 	id                     uuid.UUID
@@ -21,6 +26,9 @@ type Space struct {
 	air_change_rate        float64
 	total_infectious_doses float64
 
+	// the number of occupants capacity caps are relative to
+	capacity int64
+
 	// healthcare related props
 	test_capacity int64
 	test_backlog  chan TestResult
@@ -28,6 +36,8 @@ type Space struct {
 
 type SpaceType string
 
+type TurnAwayReason string
+
 type TestResult struct {
 	sample_epoch int64
 	agent        *Agent
@@ -40,68 +50,102 @@ func newHousehold(config *Config, capacity int64) Space {
 		type_:                  Household,
 		jurisdiction:           nil,
 		occupants:              make([]*Agent, 0, capacity),
-		volume:                 sampleNormal(config.HouseholdVolumeMean, config.HouseholdVolumeSd),
-		air_change_rate:        sampleNormal(config.HouseholdAirChangeRateMean, config.HouseholdAirChangeRateSd),
+		volume:                 config.sample(HouseholdVolume),
+		air_change_rate:        config.sample(HouseholdAirChangeRate),
 		total_infectious_doses: 0,
+		capacity:               capacity,
 	}
 }
 
-func newOffice(config *Config) Space {
+func newOffice(config *Config, capacity int64) Space {
 	return Space{
 		id:                     uuid.New(),
 		type_:                  Office,
 		jurisdiction:           nil,
 		occupants:              make([]*Agent, 0),
-		volume:                 sampleNormal(config.OfficeVolumeMean, config.OfficeVolumeSd),
-		air_change_rate:        sampleNormal(config.OfficeAirChangeRateMean, config.OfficeAirChangeRateSd),
+		volume:                 config.sample(OfficeVolume),
+		air_change_rate:        config.sample(OfficeAirChangeRate),
 		total_infectious_doses: 0,
+		capacity:               capacity,
 	}
 }
-func newSocialSpace(config *Config) Space {
+func newSocialSpace(config *Config, capacity int64) Space {
 	return Space{
 		id:                     uuid.New(),
 		type_:                  SocialSpace,
 		jurisdiction:           nil,
 		occupants:              make([]*Agent, 0),
-		volume:                 sampleNormal(config.SocialSpaceVolumeMean, config.SocialSpaceVolumeSd),
-		air_change_rate:        sampleNormal(config.SocialSpaceAirChangeRateMean, config.SocialSpaceAirChangeRateSd),
+		volume:                 config.sample(SocialSpaceVolume),
+		air_change_rate:        config.sample(SocialSpaceAirChangeRate),
 		total_infectious_doses: 0,
+		capacity:               capacity,
 	}
 }
 
-func newHealthcareSpace(config *Config) Space {
+func newHealthcareSpace(config *Config, capacity int64) Space {
 	return Space{
 		id:                     uuid.New(),
 		type_:                  HealthCareSpace,
 		jurisdiction:           nil,
 		occupants:              make([]*Agent, 0),
-		volume:                 sampleNormal(config.HealthcareSpaceVolumeMean, config.HealthcareSpaceVolumeSd),
-		air_change_rate:        sampleNormal(config.HealthcareSpaceAirChangeRateMean, config.HealthcareSpaceAirChangeRateSd),
+		volume:                 config.sample(HealthcareSpaceVolume),
+		air_change_rate:        config.sample(HealthcareSpaceAirChangeRate),
 		total_infectious_doses: 0,
+		capacity:               capacity,
 
-		test_capacity: int64(math.Max(1, math.Floor(sampleNormal(config.TestCapacityMean, config.TestCapacitySd)))),
+		test_capacity: int64(math.Max(1, math.Floor(config.sample(TestCapacity)))),
 		test_backlog:  make(chan TestResult, config.NumAgents),
 	}
 }
 
+func newTransit(config *Config, capacity int64) Space {
+	return Space{
+		id:                     uuid.New(),
+		type_:                  Transit,
+		jurisdiction:           nil,
+		occupants:              make([]*Agent, 0),
+		volume:                 config.sample(TransitVolume),
+		air_change_rate:        config.sample(TransitAirChangeRate),
+		total_infectious_doses: 0,
+		capacity:               capacity,
+	}
+}
+
+func newOutdoorSpace(config *Config, capacity int64) Space {
+	return Space{
+		id:                     uuid.New(),
+		type_:                  Outdoor,
+		jurisdiction:           nil,
+		occupants:              make([]*Agent, 0),
+		volume:                 config.sample(OutdoorVolume),
+		air_change_rate:        config.sample(OutdoorAirChangeRate),
+		total_infectious_doses: 0,
+		capacity:               capacity,
+	}
+}
+
 func (space *Space) update(sim *Simulation) {
 	policy := space.resolvePolicy()
 
-	// introduce new infectious doses from infectious occupants
+	// introduce new infectious doses from infectious occupants, following
+	// the infectiousness curve of their infection
 	for _, occupant := range space.occupants {
-		if occupant.state == Infectious {
-			filtration_efficiency := 0.0
-			if policy.IsMaskMandate && occupant.isCompliant() {
-				filtration_efficiency = occupant.mask_filtration_efficiency
-			}
+		emission_rate := occupant.quantaEmissionRate(sim)
+		if emission_rate == 0 {
+			continue
+		}
 
-			quanta_emission_rate := (1 - filtration_efficiency) * occupant.infection_profile.quanta_emission_rate / 3600
-			space.total_infectious_doses += quanta_emission_rate * float64(sim.time_step) / 1000
+		filtration_efficiency := 0.0
+		if policy.requiresMask(space.type_) && occupant.isCompliant(sim) {
+			filtration_efficiency = occupant.mask_filtration_efficiency
 		}
+
+		quanta_emission_rate := (1 - filtration_efficiency) * emission_rate / 3600
+		space.total_infectious_doses += quanta_emission_rate * float64(sim.time_step) / 1000
 	}
 
-	// remove infectious doses due to ventilation
-	space.total_infectious_doses = space.total_infectious_doses * math.Exp(-1*(space.air_change_rate/3600)*float64(sim.time_step)/1000)
+	// remove infectious doses due to ventilation, viral decay, deposition and filtration
+	space.total_infectious_doses = space.total_infectious_doses * math.Exp(-1*(space.removalRate(sim, policy)/3600)*float64(sim.time_step)/1000)
 
 	// if it is the end of a day and the space is a healthcare space, report test results
 	if space.type_ == HealthCareSpace && (sim.epoch*sim.time_step)%(24*60*60*1000) == 0 {
@@ -109,6 +153,65 @@ func (space *Space) update(sim *Simulation) {
 	}
 }
 
+// removalRate is the rate at which infectious doses leave the air of the
+// space, per hour
+func (space *Space) removalRate(sim *Simulation, policy *Policy) float64 {
+	rate := space.air_change_rate + sim.config.ViralDecayRate + sim.config.DepositionRate + sim.config.FiltrationRate
+
+	if policy != nil {
+		rate += policy.VentilationImprovement[space.type_]
+	}
+
+	return rate
+}
+
+// admit reports whether the agent may enter the space, and logs why it is
+// turned away if it may not
+func (space *Space) admit(sim *Simulation, agent *Agent) bool {
+	switch {
+	case !space.isOpen():
+		space.dispatchTurnAwayEvent(sim, agent, TurnedAwayClosed)
+		return false
+	case !space.hasRoom():
+		space.dispatchTurnAwayEvent(sim, agent, TurnedAwayFull)
+		return false
+	default:
+		return true
+	}
+}
+
+// admits is admit without logging, for agents looking for an alternative
+func (space *Space) admits() bool {
+	return space.isOpen() && space.hasRoom()
+}
+
+func (space *Space) isOpen() bool {
+	policy := space.resolvePolicy()
+
+	return policy == nil || !policy.isClosed(space.type_)
+}
+
+// hasRoom reports whether another agent may enter under the capacity cap of
+// the policy
+func (space *Space) hasRoom() bool {
+	policy := space.resolvePolicy()
+	if policy == nil || space.capacity == 0 {
+		return true
+	}
+
+	capacity_cap := policy.capacityCap(space.type_)
+	if capacity_cap == nil {
+		return true
+	}
+
+	return float64(len(space.occupants)) < math.Ceil(*capacity_cap*float64(space.capacity))
+}
+
+// doseConcentration is the number of infectious doses per cubic meter of air
+func (space *Space) doseConcentration() float64 {
+	return space.total_infectious_doses / space.volume
+}
+
 func (space *Space) addAgent(sim *Simulation, agent *Agent) {
 	space.occupants = append(space.occupants, agent)
 
@@ -170,6 +273,8 @@ loop:
 			if result.is_positive {
 				positives += 1
 
+				result.agent.household.jurisdiction.perceiveRisk(sim)
+
 				sim.logger.Log(logger.Event{
 					Type: CaseDetected,
 					Payload: CaseDetectedPayload{
@@ -207,6 +312,22 @@ loop:
 	sim.logger.Log(event)
 }
 
+func (space *Space) dispatchTurnAwayEvent(sim *Simulation, agent *Agent, reason TurnAwayReason) {
+	sim.logger.Log(logger.Event{
+		Type: AgentTurnedAway,
+		Payload: AgentTurnedAwayPayload{
+			Epoch:          sim.epoch,
+			Id:             agent.id,
+			SpaceId:        space.id,
+			SpaceType:      space.type_,
+			Reason:         reason,
+			JurisdictionId: space.jurisdiction.Id,
+
+			jurisdiction: space.jurisdiction,
+		},
+	})
+}
+
 func (space *Space) dispatchOccupancyUpdateEvent(sim *Simulation) {
 	occupants := make([]struct {
 		Id    uuid.UUID  `json:"id"`
@@ -229,6 +350,8 @@ func (space *Space) dispatchOccupancyUpdateEvent(sim *Simulation) {
 			Epoch:     sim.epoch,
 			Id:        space.id,
 			Occupants: occupants,
+
+			DoseConcentration: space.doseConcentration(),
 		},
 	}
 
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"math"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfectiousDosesAreRemovedByEveryTerm(t *testing.T) {
+	config := DefaultConfig()
+	config.ViralDecayRate = 0.5
+	config.DepositionRate = 0.25
+	config.FiltrationRate = 1
+	sim := NewSimulation(config, nil)
+
+	office := newOffice(&config, 10)
+	office.air_change_rate = 4
+	office.volume = 50
+	office.jurisdiction = newJurisdiction(&config, "GLOBAL", nil)
+
+	policy := office.resolvePolicy()
+	assert.Equal(t, 5.75, office.removalRate(&sim, policy), "Expected ventilation, viral decay, deposition and filtration to add up")
+
+	policy.VentilationImprovement[Office] = 2.25
+	policy.VentilationImprovement[Household] = 100
+	assert.Equal(t, 8.0, office.removalRate(&sim, policy), "Expected the ventilation improvement of the space type to add filtration")
+
+	office.total_infectious_doses = 100
+	assert.Equal(t, 2.0, office.doseConcentration())
+
+	// an hour at 8 removals per hour
+	sim.time_step = 60 * 60 * 1000
+	office.update(&sim)
+	assert.InDelta(t, 100*math.Exp(-8), office.total_infectious_doses, 1e-9)
+}
+
+func TestClosedAndFullSpacesTurnAgentsAway(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	turned_away := make([]AgentTurnedAwayPayload, 0)
+	sim.Subscribe(func(event *logger.Event) {
+		if payload, ok := event.Payload.(AgentTurnedAwayPayload); ok {
+			turned_away = append(turned_away, payload)
+		}
+	})
+
+	go sim.logger.Broadcast()
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+	venue_cap := 0.5
+	jur.Policy.VenueCapacityCap = &venue_cap
+	jur.Policy.ClosedSpaces[SocialSpace] = true
+	jur.Policy.ClosedSpaces[Household] = true
+
+	household := newHousehold(&config, 1)
+	office := newOffice(&config, 4)
+	social_space := newSocialSpace(&config, 10)
+	for _, space := range []*Space{&household, &office, &social_space} {
+		space.jurisdiction = jur
+	}
+
+	first, second := newAgent(&config), newAgent(&config)
+
+	assert.True(t, office.admit(&sim, &first), "Expected offices to admit agents below the capacity cap")
+	office.occupants = append(office.occupants, &first, &first)
+	assert.False(t, office.admit(&sim, &second), "Expected offices at the capacity cap to turn agents away")
+	assert.False(t, social_space.admit(&sim, &second), "Expected closed space types to turn agents away")
+	assert.True(t, household.admits(), "Expected households to stay open")
+
+	sim.logger.Close()
+
+	if assert.Len(t, turned_away, 2, "Expected every turned away agent to be reported") {
+		assert.Equal(t, TurnedAwayFull, turned_away[0].Reason)
+		assert.Equal(t, Office, turned_away[0].SpaceType)
+		assert.Equal(t, TurnedAwayClosed, turned_away[1].Reason)
+		assert.Equal(t, "GLOBAL", turned_away[1].JurisdictionId)
+	}
+}
+
+func TestSpacesAreUncappedWithoutAPolicy(t *testing.T) {
+	config := DefaultConfig()
+	sim := NewSimulation(config, nil)
+
+	go sim.logger.Broadcast()
+	defer sim.logger.Close()
+
+	jur := newJurisdiction(&config, "GLOBAL", nil)
+
+	office := newOffice(&config, 2)
+	social_space := newSocialSpace(&config, 2)
+	transit := newTransit(&config, 2)
+	outdoor_space := newOutdoorSpace(&config, 2)
+
+	agent := newAgent(&config)
+	for _, space := range []*Space{&office, &social_space, &transit, &outdoor_space} {
+		space.jurisdiction = jur
+		space.occupants = append(space.occupants, &agent, &agent, &agent)
+
+		assert.True(t, space.admit(&sim, &agent), "Expected %s spaces above their capacity to admit agents without a capacity cap", space.type_)
+	}
+}
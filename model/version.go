@@ -0,0 +1,36 @@
+package model
+
+import "github.com/CoralCoralCoralCoral/simulation-engine/logger"
+
+// ProtocolVersion is the version of the init, command and notification
+// messages exchanged with clients. It is bumped with every change that
+// existing clients can't handle. The schemas in the schemas directory
+// describe the current version.
+const ProtocolVersion = 1
+
+// event types that have been renamed, mapped to their current names. the
+// old names are accepted alongside the new ones until the next protocol
+// version drops them.
+var deprecated_event_types = map[logger.EventType]logger.EventType{
+	"space_testing_udpate": SpaceTestingUpdate,
+}
+
+// CanonicalEventType resolves deprecated event type names to their current name.
+func CanonicalEventType(event_type logger.EventType) logger.EventType {
+	if current, ok := deprecated_event_types[event_type]; ok {
+		return current
+	}
+
+	return event_type
+}
+
+// DeprecatedEventTypes returns the deprecated event type names that are
+// still accepted, mapped to their current names.
+func DeprecatedEventTypes() map[logger.EventType]logger.EventType {
+	deprecated := make(map[logger.EventType]logger.EventType, len(deprecated_event_types))
+	for old, current := range deprecated_event_types {
+		deprecated[old] = current
+	}
+
+	return deprecated
+}
@@ -0,0 +1,5 @@
+// Package simulationpb holds the code generated from proto/simulation.proto.
+// Run go generate after editing the proto file.
+package simulationpb
+
+//go:generate protoc -I .. --go_out=../.. --go_opt=module=github.com/CoralCoralCoralCoral/simulation-engine --go-grpc_out=../.. --go-grpc_opt=module=github.com/CoralCoralCoralCoral/simulation-engine simulation.proto
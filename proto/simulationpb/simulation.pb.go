@@ -0,0 +1,5016 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: simulation.proto
+
+package simulationpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StartSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	SimulationId  string                 `protobuf:"bytes,2,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSimulationResponse) Reset() {
+	*x = StartSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSimulationResponse) ProtoMessage() {}
+
+func (x *StartSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSimulationResponse.ProtoReflect.Descriptor instead.
+func (*StartSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StartSimulationResponse) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *StartSimulationResponse) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+type SendCommandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Command       *Command               `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendCommandRequest) Reset() {
+	*x = SendCommandRequest{}
+	mi := &file_simulation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendCommandRequest) ProtoMessage() {}
+
+func (x *SendCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendCommandRequest.ProtoReflect.Descriptor instead.
+func (*SendCommandRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendCommandRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *SendCommandRequest) GetCommand() *Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+// empty ids match every simulation
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	SimulationId  string                 `protobuf:"bytes,2,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_simulation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeRequest) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+// fields that are left unset take the value of the preset, or
+// model.DefaultConfig
+type Config struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Preset string                 `protobuf:"bytes,58,opt,name=preset,proto3" json:"preset,omitempty"` // the preset unset fields default to, see model.Presets
+	// Global Params
+	TimeStep  *int64 `protobuf:"varint,2,opt,name=time_step,json=timeStep,proto3,oneof" json:"time_step,omitempty"`
+	NumAgents *int64 `protobuf:"varint,3,opt,name=num_agents,json=numAgents,proto3,oneof" json:"num_agents,omitempty"`
+	// Stop Conditions
+	MaxDays              *int64 `protobuf:"varint,4,opt,name=max_days,json=maxDays,proto3,oneof" json:"max_days,omitempty"`
+	StopWhenNoInfections *bool  `protobuf:"varint,5,opt,name=stop_when_no_infections,json=stopWhenNoInfections,proto3,oneof" json:"stop_when_no_infections,omitempty"`
+	// Notification Params (only apply to the AMQP and HTTP transports)
+	NotificationEncoding string             `protobuf:"bytes,55,opt,name=notification_encoding,json=notificationEncoding,proto3" json:"notification_encoding,omitempty"` // json (default) or msgpack
+	BatchEvents          *bool              `protobuf:"varint,56,opt,name=batch_events,json=batchEvents,proto3,oneof" json:"batch_events,omitempty"`
+	AgentStream          *AgentStreamConfig `protobuf:"bytes,57,opt,name=agent_stream,json=agentStream,proto3" json:"agent_stream,omitempty"`
+	// Agent Params
+	ComplianceProbability        *float64 `protobuf:"fixed64,6,opt,name=compliance_probability,json=complianceProbability,proto3,oneof" json:"compliance_probability,omitempty"`
+	SeeksTreatmentProbability    *float64 `protobuf:"fixed64,7,opt,name=seeks_treatment_probability,json=seeksTreatmentProbability,proto3,oneof" json:"seeks_treatment_probability,omitempty"`
+	MaskFiltrationEfficiencyMean *float64 `protobuf:"fixed64,8,opt,name=mask_filtration_efficiency_mean,json=maskFiltrationEfficiencyMean,proto3,oneof" json:"mask_filtration_efficiency_mean,omitempty"`
+	MaskFiltrationEfficiencySd   *float64 `protobuf:"fixed64,9,opt,name=mask_filtration_efficiency_sd,json=maskFiltrationEfficiencySd,proto3,oneof" json:"mask_filtration_efficiency_sd,omitempty"`
+	PulmonaryVentilationRateMean *float64 `protobuf:"fixed64,10,opt,name=pulmonary_ventilation_rate_mean,json=pulmonaryVentilationRateMean,proto3,oneof" json:"pulmonary_ventilation_rate_mean,omitempty"`
+	PulmonaryVentilationRateSd   *float64 `protobuf:"fixed64,11,opt,name=pulmonary_ventilation_rate_sd,json=pulmonaryVentilationRateSd,proto3,oneof" json:"pulmonary_ventilation_rate_sd,omitempty"`
+	// Occupation Params
+	KeyWorkerProbability    *float64 `protobuf:"fixed64,82,opt,name=key_worker_probability,json=keyWorkerProbability,proto3,oneof" json:"key_worker_probability,omitempty"`
+	RemoteWorkerProbability *float64 `protobuf:"fixed64,83,opt,name=remote_worker_probability,json=remoteWorkerProbability,proto3,oneof" json:"remote_worker_probability,omitempty"`
+	// Compliance Params
+	ComplianceFatigue *float64 `protobuf:"fixed64,84,opt,name=compliance_fatigue,json=complianceFatigue,proto3,oneof" json:"compliance_fatigue,omitempty"` // fraction of compliance lost per day of restrictions
+	RiskPerception    *float64 `protobuf:"fixed64,85,opt,name=risk_perception,json=riskPerception,proto3,oneof" json:"risk_perception,omitempty"`          // compliance gained per recent local case or death
+	RiskMemory        *float64 `protobuf:"fixed64,86,opt,name=risk_memory,json=riskMemory,proto3,oneof" json:"risk_memory,omitempty"`                      // ms
+	// Pathogen Params
+	IncubationPeriodMean         *float64 `protobuf:"fixed64,12,opt,name=incubation_period_mean,json=incubationPeriodMean,proto3,oneof" json:"incubation_period_mean,omitempty"`
+	IncubationPeriodSd           *float64 `protobuf:"fixed64,13,opt,name=incubation_period_sd,json=incubationPeriodSd,proto3,oneof" json:"incubation_period_sd,omitempty"`
+	RecoveryPeriodMean           *float64 `protobuf:"fixed64,14,opt,name=recovery_period_mean,json=recoveryPeriodMean,proto3,oneof" json:"recovery_period_mean,omitempty"`
+	RecoveryPeriodSd             *float64 `protobuf:"fixed64,15,opt,name=recovery_period_sd,json=recoveryPeriodSd,proto3,oneof" json:"recovery_period_sd,omitempty"`
+	ImmunityPeriodMean           *float64 `protobuf:"fixed64,16,opt,name=immunity_period_mean,json=immunityPeriodMean,proto3,oneof" json:"immunity_period_mean,omitempty"`
+	ImmunityPeriodSd             *float64 `protobuf:"fixed64,17,opt,name=immunity_period_sd,json=immunityPeriodSd,proto3,oneof" json:"immunity_period_sd,omitempty"`
+	PrehospitalizationPeriodMean *float64 `protobuf:"fixed64,18,opt,name=prehospitalization_period_mean,json=prehospitalizationPeriodMean,proto3,oneof" json:"prehospitalization_period_mean,omitempty"`
+	PrehospitalizationPeriodSd   *float64 `protobuf:"fixed64,19,opt,name=prehospitalization_period_sd,json=prehospitalizationPeriodSd,proto3,oneof" json:"prehospitalization_period_sd,omitempty"`
+	HospitalizationPeriodMean    *float64 `protobuf:"fixed64,20,opt,name=hospitalization_period_mean,json=hospitalizationPeriodMean,proto3,oneof" json:"hospitalization_period_mean,omitempty"`
+	HospitalizationPeriodSd      *float64 `protobuf:"fixed64,21,opt,name=hospitalization_period_sd,json=hospitalizationPeriodSd,proto3,oneof" json:"hospitalization_period_sd,omitempty"`
+	QuantaEmissionRateMean       *float64 `protobuf:"fixed64,22,opt,name=quanta_emission_rate_mean,json=quantaEmissionRateMean,proto3,oneof" json:"quanta_emission_rate_mean,omitempty"`
+	QuantaEmissionRateSd         *float64 `protobuf:"fixed64,23,opt,name=quanta_emission_rate_sd,json=quantaEmissionRateSd,proto3,oneof" json:"quanta_emission_rate_sd,omitempty"`
+	HospitalizationProbability   *float64 `protobuf:"fixed64,24,opt,name=hospitalization_probability,json=hospitalizationProbability,proto3,oneof" json:"hospitalization_probability,omitempty"`
+	DeathProbability             *float64 `protobuf:"fixed64,25,opt,name=death_probability,json=deathProbability,proto3,oneof" json:"death_probability,omitempty"`
+	AsymptomaticProbability      *float64 `protobuf:"fixed64,26,opt,name=asymptomatic_probability,json=asymptomaticProbability,proto3,oneof" json:"asymptomatic_probability,omitempty"`
+	// Infectiousness Params
+	InfectiousnessCurve        []*InfectiousnessPoint `protobuf:"bytes,60,rep,name=infectiousness_curve,json=infectiousnessCurve,proto3" json:"infectiousness_curve,omitempty"` // see model.InfectiousnessCurve
+	AsymptomaticInfectiousness *float64               `protobuf:"fixed64,61,opt,name=asymptomatic_infectiousness,json=asymptomaticInfectiousness,proto3,oneof" json:"asymptomatic_infectiousness,omitempty"`
+	// Immunity Params, protection right after recovery
+	ImmunityProtection *float64 `protobuf:"fixed64,62,opt,name=immunity_protection,json=immunityProtection,proto3,oneof" json:"immunity_protection,omitempty"`
+	SeverityProtection *float64 `protobuf:"fixed64,63,opt,name=severity_protection,json=severityProtection,proto3,oneof" json:"severity_protection,omitempty"`
+	// Air Params, removal rates per hour on top of the air change rate of a space
+	ViralDecayRate *float64 `protobuf:"fixed64,64,opt,name=viral_decay_rate,json=viralDecayRate,proto3,oneof" json:"viral_decay_rate,omitempty"`
+	DepositionRate *float64 `protobuf:"fixed64,65,opt,name=deposition_rate,json=depositionRate,proto3,oneof" json:"deposition_rate,omitempty"`
+	FiltrationRate *float64 `protobuf:"fixed64,66,opt,name=filtration_rate,json=filtrationRate,proto3,oneof" json:"filtration_rate,omitempty"`
+	// Household Params
+	HouseholdCapacityMean      *float64 `protobuf:"fixed64,27,opt,name=household_capacity_mean,json=householdCapacityMean,proto3,oneof" json:"household_capacity_mean,omitempty"`
+	HouseholdCapacitySd        *float64 `protobuf:"fixed64,28,opt,name=household_capacity_sd,json=householdCapacitySd,proto3,oneof" json:"household_capacity_sd,omitempty"`
+	HouseholdAirChangeRateMean *float64 `protobuf:"fixed64,29,opt,name=household_air_change_rate_mean,json=householdAirChangeRateMean,proto3,oneof" json:"household_air_change_rate_mean,omitempty"`
+	HouseholdAirChangeRateSd   *float64 `protobuf:"fixed64,30,opt,name=household_air_change_rate_sd,json=householdAirChangeRateSd,proto3,oneof" json:"household_air_change_rate_sd,omitempty"`
+	HouseholdVolumeMean        *float64 `protobuf:"fixed64,31,opt,name=household_volume_mean,json=householdVolumeMean,proto3,oneof" json:"household_volume_mean,omitempty"`
+	HouseholdVolumeSd          *float64 `protobuf:"fixed64,32,opt,name=household_volume_sd,json=householdVolumeSd,proto3,oneof" json:"household_volume_sd,omitempty"`
+	// Office Params
+	OfficeCapacityMean      *float64 `protobuf:"fixed64,33,opt,name=office_capacity_mean,json=officeCapacityMean,proto3,oneof" json:"office_capacity_mean,omitempty"`
+	OfficeCapacitySd        *float64 `protobuf:"fixed64,34,opt,name=office_capacity_sd,json=officeCapacitySd,proto3,oneof" json:"office_capacity_sd,omitempty"`
+	OfficeAirChangeRateMean *float64 `protobuf:"fixed64,35,opt,name=office_air_change_rate_mean,json=officeAirChangeRateMean,proto3,oneof" json:"office_air_change_rate_mean,omitempty"`
+	OfficeAirChangeRateSd   *float64 `protobuf:"fixed64,36,opt,name=office_air_change_rate_sd,json=officeAirChangeRateSd,proto3,oneof" json:"office_air_change_rate_sd,omitempty"`
+	OfficeVolumeMean        *float64 `protobuf:"fixed64,37,opt,name=office_volume_mean,json=officeVolumeMean,proto3,oneof" json:"office_volume_mean,omitempty"`
+	OfficeVolumeSd          *float64 `protobuf:"fixed64,38,opt,name=office_volume_sd,json=officeVolumeSd,proto3,oneof" json:"office_volume_sd,omitempty"`
+	// Social Space Params
+	SocialSpaceCapacityMean      *float64 `protobuf:"fixed64,39,opt,name=social_space_capacity_mean,json=socialSpaceCapacityMean,proto3,oneof" json:"social_space_capacity_mean,omitempty"`
+	SocialSpaceCapacitySd        *float64 `protobuf:"fixed64,40,opt,name=social_space_capacity_sd,json=socialSpaceCapacitySd,proto3,oneof" json:"social_space_capacity_sd,omitempty"`
+	SocialSpaceAirChangeRateMean *float64 `protobuf:"fixed64,41,opt,name=social_space_air_change_rate_mean,json=socialSpaceAirChangeRateMean,proto3,oneof" json:"social_space_air_change_rate_mean,omitempty"`
+	SocialSpaceAirChangeRateSd   *float64 `protobuf:"fixed64,42,opt,name=social_space_air_change_rate_sd,json=socialSpaceAirChangeRateSd,proto3,oneof" json:"social_space_air_change_rate_sd,omitempty"`
+	SocialSpaceVolumeMean        *float64 `protobuf:"fixed64,43,opt,name=social_space_volume_mean,json=socialSpaceVolumeMean,proto3,oneof" json:"social_space_volume_mean,omitempty"`
+	SocialSpaceVolumeSd          *float64 `protobuf:"fixed64,44,opt,name=social_space_volume_sd,json=socialSpaceVolumeSd,proto3,oneof" json:"social_space_volume_sd,omitempty"`
+	// Healthcare Space Params
+	HealthcareSpaceCapacityMean      *float64 `protobuf:"fixed64,45,opt,name=healthcare_space_capacity_mean,json=healthcareSpaceCapacityMean,proto3,oneof" json:"healthcare_space_capacity_mean,omitempty"`
+	HealthcareSpaceCapacitySd        *float64 `protobuf:"fixed64,46,opt,name=healthcare_space_capacity_sd,json=healthcareSpaceCapacitySd,proto3,oneof" json:"healthcare_space_capacity_sd,omitempty"`
+	HealthcareSpaceAirChangeRateMean *float64 `protobuf:"fixed64,47,opt,name=healthcare_space_air_change_rate_mean,json=healthcareSpaceAirChangeRateMean,proto3,oneof" json:"healthcare_space_air_change_rate_mean,omitempty"`
+	HealthcareSpaceAirChangeRateSd   *float64 `protobuf:"fixed64,48,opt,name=healthcare_space_air_change_rate_sd,json=healthcareSpaceAirChangeRateSd,proto3,oneof" json:"healthcare_space_air_change_rate_sd,omitempty"`
+	HealthcareSpaceVolumeMean        *float64 `protobuf:"fixed64,49,opt,name=healthcare_space_volume_mean,json=healthcareSpaceVolumeMean,proto3,oneof" json:"healthcare_space_volume_mean,omitempty"`
+	HealthcareSpaceVolumeSd          *float64 `protobuf:"fixed64,50,opt,name=healthcare_space_volume_sd,json=healthcareSpaceVolumeSd,proto3,oneof" json:"healthcare_space_volume_sd,omitempty"`
+	TestCapacityMean                 *float64 `protobuf:"fixed64,51,opt,name=test_capacity_mean,json=testCapacityMean,proto3,oneof" json:"test_capacity_mean,omitempty"`
+	TestCapacitySd                   *float64 `protobuf:"fixed64,52,opt,name=test_capacity_sd,json=testCapacitySd,proto3,oneof" json:"test_capacity_sd,omitempty"`
+	TestSensitivity                  *float64 `protobuf:"fixed64,53,opt,name=test_sensitivity,json=testSensitivity,proto3,oneof" json:"test_sensitivity,omitempty"`
+	TestSpecificity                  *float64 `protobuf:"fixed64,54,opt,name=test_specificity,json=testSpecificity,proto3,oneof" json:"test_specificity,omitempty"`
+	// Transit Params
+	TransitCapacityMean      *float64 `protobuf:"fixed64,67,opt,name=transit_capacity_mean,json=transitCapacityMean,proto3,oneof" json:"transit_capacity_mean,omitempty"`
+	TransitCapacitySd        *float64 `protobuf:"fixed64,68,opt,name=transit_capacity_sd,json=transitCapacitySd,proto3,oneof" json:"transit_capacity_sd,omitempty"`
+	TransitAirChangeRateMean *float64 `protobuf:"fixed64,69,opt,name=transit_air_change_rate_mean,json=transitAirChangeRateMean,proto3,oneof" json:"transit_air_change_rate_mean,omitempty"`
+	TransitAirChangeRateSd   *float64 `protobuf:"fixed64,70,opt,name=transit_air_change_rate_sd,json=transitAirChangeRateSd,proto3,oneof" json:"transit_air_change_rate_sd,omitempty"`
+	TransitVolumeMean        *float64 `protobuf:"fixed64,71,opt,name=transit_volume_mean,json=transitVolumeMean,proto3,oneof" json:"transit_volume_mean,omitempty"`
+	TransitVolumeSd          *float64 `protobuf:"fixed64,72,opt,name=transit_volume_sd,json=transitVolumeSd,proto3,oneof" json:"transit_volume_sd,omitempty"`
+	TransitSpeed             *float64 `protobuf:"fixed64,73,opt,name=transit_speed,json=transitSpeed,proto3,oneof" json:"transit_speed,omitempty"`            // km/h
+	TransitWaitTime          *float64 `protobuf:"fixed64,74,opt,name=transit_wait_time,json=transitWaitTime,proto3,oneof" json:"transit_wait_time,omitempty"` // milliseconds
+	// Outdoor Params
+	OutdoorCapacityMean      *float64 `protobuf:"fixed64,75,opt,name=outdoor_capacity_mean,json=outdoorCapacityMean,proto3,oneof" json:"outdoor_capacity_mean,omitempty"`
+	OutdoorCapacitySd        *float64 `protobuf:"fixed64,76,opt,name=outdoor_capacity_sd,json=outdoorCapacitySd,proto3,oneof" json:"outdoor_capacity_sd,omitempty"`
+	OutdoorAirChangeRateMean *float64 `protobuf:"fixed64,77,opt,name=outdoor_air_change_rate_mean,json=outdoorAirChangeRateMean,proto3,oneof" json:"outdoor_air_change_rate_mean,omitempty"`
+	OutdoorAirChangeRateSd   *float64 `protobuf:"fixed64,78,opt,name=outdoor_air_change_rate_sd,json=outdoorAirChangeRateSd,proto3,oneof" json:"outdoor_air_change_rate_sd,omitempty"`
+	OutdoorVolumeMean        *float64 `protobuf:"fixed64,79,opt,name=outdoor_volume_mean,json=outdoorVolumeMean,proto3,oneof" json:"outdoor_volume_mean,omitempty"`
+	OutdoorVolumeSd          *float64 `protobuf:"fixed64,80,opt,name=outdoor_volume_sd,json=outdoorVolumeSd,proto3,oneof" json:"outdoor_volume_sd,omitempty"`
+	OutdoorVisitProbability  *float64 `protobuf:"fixed64,81,opt,name=outdoor_visit_probability,json=outdoorVisitProbability,proto3,oneof" json:"outdoor_visit_probability,omitempty"`
+	// keyed by sampled parameter, e.g. incubation_period
+	Distributions map[string]*Distribution `protobuf:"bytes,59,rep,name=distributions,proto3" json:"distributions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_simulation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Config) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Config) GetPreset() string {
+	if x != nil {
+		return x.Preset
+	}
+	return ""
+}
+
+func (x *Config) GetTimeStep() int64 {
+	if x != nil && x.TimeStep != nil {
+		return *x.TimeStep
+	}
+	return 0
+}
+
+func (x *Config) GetNumAgents() int64 {
+	if x != nil && x.NumAgents != nil {
+		return *x.NumAgents
+	}
+	return 0
+}
+
+func (x *Config) GetMaxDays() int64 {
+	if x != nil && x.MaxDays != nil {
+		return *x.MaxDays
+	}
+	return 0
+}
+
+func (x *Config) GetStopWhenNoInfections() bool {
+	if x != nil && x.StopWhenNoInfections != nil {
+		return *x.StopWhenNoInfections
+	}
+	return false
+}
+
+func (x *Config) GetNotificationEncoding() string {
+	if x != nil {
+		return x.NotificationEncoding
+	}
+	return ""
+}
+
+func (x *Config) GetBatchEvents() bool {
+	if x != nil && x.BatchEvents != nil {
+		return *x.BatchEvents
+	}
+	return false
+}
+
+func (x *Config) GetAgentStream() *AgentStreamConfig {
+	if x != nil {
+		return x.AgentStream
+	}
+	return nil
+}
+
+func (x *Config) GetComplianceProbability() float64 {
+	if x != nil && x.ComplianceProbability != nil {
+		return *x.ComplianceProbability
+	}
+	return 0
+}
+
+func (x *Config) GetSeeksTreatmentProbability() float64 {
+	if x != nil && x.SeeksTreatmentProbability != nil {
+		return *x.SeeksTreatmentProbability
+	}
+	return 0
+}
+
+func (x *Config) GetMaskFiltrationEfficiencyMean() float64 {
+	if x != nil && x.MaskFiltrationEfficiencyMean != nil {
+		return *x.MaskFiltrationEfficiencyMean
+	}
+	return 0
+}
+
+func (x *Config) GetMaskFiltrationEfficiencySd() float64 {
+	if x != nil && x.MaskFiltrationEfficiencySd != nil {
+		return *x.MaskFiltrationEfficiencySd
+	}
+	return 0
+}
+
+func (x *Config) GetPulmonaryVentilationRateMean() float64 {
+	if x != nil && x.PulmonaryVentilationRateMean != nil {
+		return *x.PulmonaryVentilationRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetPulmonaryVentilationRateSd() float64 {
+	if x != nil && x.PulmonaryVentilationRateSd != nil {
+		return *x.PulmonaryVentilationRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetKeyWorkerProbability() float64 {
+	if x != nil && x.KeyWorkerProbability != nil {
+		return *x.KeyWorkerProbability
+	}
+	return 0
+}
+
+func (x *Config) GetRemoteWorkerProbability() float64 {
+	if x != nil && x.RemoteWorkerProbability != nil {
+		return *x.RemoteWorkerProbability
+	}
+	return 0
+}
+
+func (x *Config) GetComplianceFatigue() float64 {
+	if x != nil && x.ComplianceFatigue != nil {
+		return *x.ComplianceFatigue
+	}
+	return 0
+}
+
+func (x *Config) GetRiskPerception() float64 {
+	if x != nil && x.RiskPerception != nil {
+		return *x.RiskPerception
+	}
+	return 0
+}
+
+func (x *Config) GetRiskMemory() float64 {
+	if x != nil && x.RiskMemory != nil {
+		return *x.RiskMemory
+	}
+	return 0
+}
+
+func (x *Config) GetIncubationPeriodMean() float64 {
+	if x != nil && x.IncubationPeriodMean != nil {
+		return *x.IncubationPeriodMean
+	}
+	return 0
+}
+
+func (x *Config) GetIncubationPeriodSd() float64 {
+	if x != nil && x.IncubationPeriodSd != nil {
+		return *x.IncubationPeriodSd
+	}
+	return 0
+}
+
+func (x *Config) GetRecoveryPeriodMean() float64 {
+	if x != nil && x.RecoveryPeriodMean != nil {
+		return *x.RecoveryPeriodMean
+	}
+	return 0
+}
+
+func (x *Config) GetRecoveryPeriodSd() float64 {
+	if x != nil && x.RecoveryPeriodSd != nil {
+		return *x.RecoveryPeriodSd
+	}
+	return 0
+}
+
+func (x *Config) GetImmunityPeriodMean() float64 {
+	if x != nil && x.ImmunityPeriodMean != nil {
+		return *x.ImmunityPeriodMean
+	}
+	return 0
+}
+
+func (x *Config) GetImmunityPeriodSd() float64 {
+	if x != nil && x.ImmunityPeriodSd != nil {
+		return *x.ImmunityPeriodSd
+	}
+	return 0
+}
+
+func (x *Config) GetPrehospitalizationPeriodMean() float64 {
+	if x != nil && x.PrehospitalizationPeriodMean != nil {
+		return *x.PrehospitalizationPeriodMean
+	}
+	return 0
+}
+
+func (x *Config) GetPrehospitalizationPeriodSd() float64 {
+	if x != nil && x.PrehospitalizationPeriodSd != nil {
+		return *x.PrehospitalizationPeriodSd
+	}
+	return 0
+}
+
+func (x *Config) GetHospitalizationPeriodMean() float64 {
+	if x != nil && x.HospitalizationPeriodMean != nil {
+		return *x.HospitalizationPeriodMean
+	}
+	return 0
+}
+
+func (x *Config) GetHospitalizationPeriodSd() float64 {
+	if x != nil && x.HospitalizationPeriodSd != nil {
+		return *x.HospitalizationPeriodSd
+	}
+	return 0
+}
+
+func (x *Config) GetQuantaEmissionRateMean() float64 {
+	if x != nil && x.QuantaEmissionRateMean != nil {
+		return *x.QuantaEmissionRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetQuantaEmissionRateSd() float64 {
+	if x != nil && x.QuantaEmissionRateSd != nil {
+		return *x.QuantaEmissionRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetHospitalizationProbability() float64 {
+	if x != nil && x.HospitalizationProbability != nil {
+		return *x.HospitalizationProbability
+	}
+	return 0
+}
+
+func (x *Config) GetDeathProbability() float64 {
+	if x != nil && x.DeathProbability != nil {
+		return *x.DeathProbability
+	}
+	return 0
+}
+
+func (x *Config) GetAsymptomaticProbability() float64 {
+	if x != nil && x.AsymptomaticProbability != nil {
+		return *x.AsymptomaticProbability
+	}
+	return 0
+}
+
+func (x *Config) GetInfectiousnessCurve() []*InfectiousnessPoint {
+	if x != nil {
+		return x.InfectiousnessCurve
+	}
+	return nil
+}
+
+func (x *Config) GetAsymptomaticInfectiousness() float64 {
+	if x != nil && x.AsymptomaticInfectiousness != nil {
+		return *x.AsymptomaticInfectiousness
+	}
+	return 0
+}
+
+func (x *Config) GetImmunityProtection() float64 {
+	if x != nil && x.ImmunityProtection != nil {
+		return *x.ImmunityProtection
+	}
+	return 0
+}
+
+func (x *Config) GetSeverityProtection() float64 {
+	if x != nil && x.SeverityProtection != nil {
+		return *x.SeverityProtection
+	}
+	return 0
+}
+
+func (x *Config) GetViralDecayRate() float64 {
+	if x != nil && x.ViralDecayRate != nil {
+		return *x.ViralDecayRate
+	}
+	return 0
+}
+
+func (x *Config) GetDepositionRate() float64 {
+	if x != nil && x.DepositionRate != nil {
+		return *x.DepositionRate
+	}
+	return 0
+}
+
+func (x *Config) GetFiltrationRate() float64 {
+	if x != nil && x.FiltrationRate != nil {
+		return *x.FiltrationRate
+	}
+	return 0
+}
+
+func (x *Config) GetHouseholdCapacityMean() float64 {
+	if x != nil && x.HouseholdCapacityMean != nil {
+		return *x.HouseholdCapacityMean
+	}
+	return 0
+}
+
+func (x *Config) GetHouseholdCapacitySd() float64 {
+	if x != nil && x.HouseholdCapacitySd != nil {
+		return *x.HouseholdCapacitySd
+	}
+	return 0
+}
+
+func (x *Config) GetHouseholdAirChangeRateMean() float64 {
+	if x != nil && x.HouseholdAirChangeRateMean != nil {
+		return *x.HouseholdAirChangeRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetHouseholdAirChangeRateSd() float64 {
+	if x != nil && x.HouseholdAirChangeRateSd != nil {
+		return *x.HouseholdAirChangeRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetHouseholdVolumeMean() float64 {
+	if x != nil && x.HouseholdVolumeMean != nil {
+		return *x.HouseholdVolumeMean
+	}
+	return 0
+}
+
+func (x *Config) GetHouseholdVolumeSd() float64 {
+	if x != nil && x.HouseholdVolumeSd != nil {
+		return *x.HouseholdVolumeSd
+	}
+	return 0
+}
+
+func (x *Config) GetOfficeCapacityMean() float64 {
+	if x != nil && x.OfficeCapacityMean != nil {
+		return *x.OfficeCapacityMean
+	}
+	return 0
+}
+
+func (x *Config) GetOfficeCapacitySd() float64 {
+	if x != nil && x.OfficeCapacitySd != nil {
+		return *x.OfficeCapacitySd
+	}
+	return 0
+}
+
+func (x *Config) GetOfficeAirChangeRateMean() float64 {
+	if x != nil && x.OfficeAirChangeRateMean != nil {
+		return *x.OfficeAirChangeRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetOfficeAirChangeRateSd() float64 {
+	if x != nil && x.OfficeAirChangeRateSd != nil {
+		return *x.OfficeAirChangeRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetOfficeVolumeMean() float64 {
+	if x != nil && x.OfficeVolumeMean != nil {
+		return *x.OfficeVolumeMean
+	}
+	return 0
+}
+
+func (x *Config) GetOfficeVolumeSd() float64 {
+	if x != nil && x.OfficeVolumeSd != nil {
+		return *x.OfficeVolumeSd
+	}
+	return 0
+}
+
+func (x *Config) GetSocialSpaceCapacityMean() float64 {
+	if x != nil && x.SocialSpaceCapacityMean != nil {
+		return *x.SocialSpaceCapacityMean
+	}
+	return 0
+}
+
+func (x *Config) GetSocialSpaceCapacitySd() float64 {
+	if x != nil && x.SocialSpaceCapacitySd != nil {
+		return *x.SocialSpaceCapacitySd
+	}
+	return 0
+}
+
+func (x *Config) GetSocialSpaceAirChangeRateMean() float64 {
+	if x != nil && x.SocialSpaceAirChangeRateMean != nil {
+		return *x.SocialSpaceAirChangeRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetSocialSpaceAirChangeRateSd() float64 {
+	if x != nil && x.SocialSpaceAirChangeRateSd != nil {
+		return *x.SocialSpaceAirChangeRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetSocialSpaceVolumeMean() float64 {
+	if x != nil && x.SocialSpaceVolumeMean != nil {
+		return *x.SocialSpaceVolumeMean
+	}
+	return 0
+}
+
+func (x *Config) GetSocialSpaceVolumeSd() float64 {
+	if x != nil && x.SocialSpaceVolumeSd != nil {
+		return *x.SocialSpaceVolumeSd
+	}
+	return 0
+}
+
+func (x *Config) GetHealthcareSpaceCapacityMean() float64 {
+	if x != nil && x.HealthcareSpaceCapacityMean != nil {
+		return *x.HealthcareSpaceCapacityMean
+	}
+	return 0
+}
+
+func (x *Config) GetHealthcareSpaceCapacitySd() float64 {
+	if x != nil && x.HealthcareSpaceCapacitySd != nil {
+		return *x.HealthcareSpaceCapacitySd
+	}
+	return 0
+}
+
+func (x *Config) GetHealthcareSpaceAirChangeRateMean() float64 {
+	if x != nil && x.HealthcareSpaceAirChangeRateMean != nil {
+		return *x.HealthcareSpaceAirChangeRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetHealthcareSpaceAirChangeRateSd() float64 {
+	if x != nil && x.HealthcareSpaceAirChangeRateSd != nil {
+		return *x.HealthcareSpaceAirChangeRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetHealthcareSpaceVolumeMean() float64 {
+	if x != nil && x.HealthcareSpaceVolumeMean != nil {
+		return *x.HealthcareSpaceVolumeMean
+	}
+	return 0
+}
+
+func (x *Config) GetHealthcareSpaceVolumeSd() float64 {
+	if x != nil && x.HealthcareSpaceVolumeSd != nil {
+		return *x.HealthcareSpaceVolumeSd
+	}
+	return 0
+}
+
+func (x *Config) GetTestCapacityMean() float64 {
+	if x != nil && x.TestCapacityMean != nil {
+		return *x.TestCapacityMean
+	}
+	return 0
+}
+
+func (x *Config) GetTestCapacitySd() float64 {
+	if x != nil && x.TestCapacitySd != nil {
+		return *x.TestCapacitySd
+	}
+	return 0
+}
+
+func (x *Config) GetTestSensitivity() float64 {
+	if x != nil && x.TestSensitivity != nil {
+		return *x.TestSensitivity
+	}
+	return 0
+}
+
+func (x *Config) GetTestSpecificity() float64 {
+	if x != nil && x.TestSpecificity != nil {
+		return *x.TestSpecificity
+	}
+	return 0
+}
+
+func (x *Config) GetTransitCapacityMean() float64 {
+	if x != nil && x.TransitCapacityMean != nil {
+		return *x.TransitCapacityMean
+	}
+	return 0
+}
+
+func (x *Config) GetTransitCapacitySd() float64 {
+	if x != nil && x.TransitCapacitySd != nil {
+		return *x.TransitCapacitySd
+	}
+	return 0
+}
+
+func (x *Config) GetTransitAirChangeRateMean() float64 {
+	if x != nil && x.TransitAirChangeRateMean != nil {
+		return *x.TransitAirChangeRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetTransitAirChangeRateSd() float64 {
+	if x != nil && x.TransitAirChangeRateSd != nil {
+		return *x.TransitAirChangeRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetTransitVolumeMean() float64 {
+	if x != nil && x.TransitVolumeMean != nil {
+		return *x.TransitVolumeMean
+	}
+	return 0
+}
+
+func (x *Config) GetTransitVolumeSd() float64 {
+	if x != nil && x.TransitVolumeSd != nil {
+		return *x.TransitVolumeSd
+	}
+	return 0
+}
+
+func (x *Config) GetTransitSpeed() float64 {
+	if x != nil && x.TransitSpeed != nil {
+		return *x.TransitSpeed
+	}
+	return 0
+}
+
+func (x *Config) GetTransitWaitTime() float64 {
+	if x != nil && x.TransitWaitTime != nil {
+		return *x.TransitWaitTime
+	}
+	return 0
+}
+
+func (x *Config) GetOutdoorCapacityMean() float64 {
+	if x != nil && x.OutdoorCapacityMean != nil {
+		return *x.OutdoorCapacityMean
+	}
+	return 0
+}
+
+func (x *Config) GetOutdoorCapacitySd() float64 {
+	if x != nil && x.OutdoorCapacitySd != nil {
+		return *x.OutdoorCapacitySd
+	}
+	return 0
+}
+
+func (x *Config) GetOutdoorAirChangeRateMean() float64 {
+	if x != nil && x.OutdoorAirChangeRateMean != nil {
+		return *x.OutdoorAirChangeRateMean
+	}
+	return 0
+}
+
+func (x *Config) GetOutdoorAirChangeRateSd() float64 {
+	if x != nil && x.OutdoorAirChangeRateSd != nil {
+		return *x.OutdoorAirChangeRateSd
+	}
+	return 0
+}
+
+func (x *Config) GetOutdoorVolumeMean() float64 {
+	if x != nil && x.OutdoorVolumeMean != nil {
+		return *x.OutdoorVolumeMean
+	}
+	return 0
+}
+
+func (x *Config) GetOutdoorVolumeSd() float64 {
+	if x != nil && x.OutdoorVolumeSd != nil {
+		return *x.OutdoorVolumeSd
+	}
+	return 0
+}
+
+func (x *Config) GetOutdoorVisitProbability() float64 {
+	if x != nil && x.OutdoorVisitProbability != nil {
+		return *x.OutdoorVisitProbability
+	}
+	return 0
+}
+
+func (x *Config) GetDistributions() map[string]*Distribution {
+	if x != nil {
+		return x.Distributions
+	}
+	return nil
+}
+
+type InfectiousnessPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          float64                `protobuf:"fixed64,1,opt,name=time,proto3" json:"time,omitempty"` // milliseconds since symptom onset, negative before it
+	Level         float64                `protobuf:"fixed64,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InfectiousnessPoint) Reset() {
+	*x = InfectiousnessPoint{}
+	mi := &file_simulation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InfectiousnessPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfectiousnessPoint) ProtoMessage() {}
+
+func (x *InfectiousnessPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfectiousnessPoint.ProtoReflect.Descriptor instead.
+func (*InfectiousnessPoint) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InfectiousnessPoint) GetTime() float64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *InfectiousnessPoint) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+// which fields are used depends on the type, see model.Distribution
+type Distribution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // normal, lognormal, gamma, weibull, truncated_normal or fixed
+	Mean          float64                `protobuf:"fixed64,2,opt,name=mean,proto3" json:"mean,omitempty"`
+	Sd            float64                `protobuf:"fixed64,3,opt,name=sd,proto3" json:"sd,omitempty"`
+	Shape         float64                `protobuf:"fixed64,4,opt,name=shape,proto3" json:"shape,omitempty"`
+	Scale         float64                `protobuf:"fixed64,5,opt,name=scale,proto3" json:"scale,omitempty"`
+	Min           float64                `protobuf:"fixed64,6,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,7,opt,name=max,proto3" json:"max,omitempty"` // 0 means no upper bound
+	Value         float64                `protobuf:"fixed64,8,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Distribution) Reset() {
+	*x = Distribution{}
+	mi := &file_simulation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Distribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Distribution) ProtoMessage() {}
+
+func (x *Distribution) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Distribution.ProtoReflect.Descriptor instead.
+func (*Distribution) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Distribution) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Distribution) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *Distribution) GetSd() float64 {
+	if x != nil {
+		return x.Sd
+	}
+	return 0
+}
+
+func (x *Distribution) GetShape() float64 {
+	if x != nil {
+		return x.Shape
+	}
+	return 0
+}
+
+func (x *Distribution) GetScale() float64 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *Distribution) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Distribution) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *Distribution) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type Policy struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	IsMaskMandate          bool                   `protobuf:"varint,1,opt,name=is_mask_mandate,json=isMaskMandate,proto3" json:"is_mask_mandate,omitempty"`
+	IsSelfIsolationMandate bool                   `protobuf:"varint,2,opt,name=is_self_isolation_mandate,json=isSelfIsolationMandate,proto3" json:"is_self_isolation_mandate,omitempty"`
+	IsSelfReportingMandate bool                   `protobuf:"varint,3,opt,name=is_self_reporting_mandate,json=isSelfReportingMandate,proto3" json:"is_self_reporting_mandate,omitempty"`
+	IsLockdown             bool                   `protobuf:"varint,4,opt,name=is_lockdown,json=isLockdown,proto3" json:"is_lockdown,omitempty"`
+	TestStrategy           string                 `protobuf:"bytes,5,opt,name=test_strategy,json=testStrategy,proto3" json:"test_strategy,omitempty"` // everyone, symptomatic or none
+	TestCapacityMultiplier float64                `protobuf:"fixed64,6,opt,name=test_capacity_multiplier,json=testCapacityMultiplier,proto3" json:"test_capacity_multiplier,omitempty"`
+	ComplianceProbability  float64                `protobuf:"fixed64,7,opt,name=compliance_probability,json=complianceProbability,proto3" json:"compliance_probability,omitempty"`
+	VentilationImprovement map[string]float64     `protobuf:"bytes,8,rep,name=ventilation_improvement,json=ventilationImprovement,proto3" json:"ventilation_improvement,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // keyed by space type, in air changes per hour
+	IsTransitMaskMandate   bool                   `protobuf:"varint,9,opt,name=is_transit_mask_mandate,json=isTransitMaskMandate,proto3" json:"is_transit_mask_mandate,omitempty"`
+	TransitCapacityCap     *float64               `protobuf:"fixed64,10,opt,name=transit_capacity_cap,json=transitCapacityCap,proto3,oneof" json:"transit_capacity_cap,omitempty"` // fraction of capacity, unset means no cap and 0 closes
+	OutdoorCapacityCap     *float64               `protobuf:"fixed64,11,opt,name=outdoor_capacity_cap,json=outdoorCapacityCap,proto3,oneof" json:"outdoor_capacity_cap,omitempty"`
+	VenueCapacityCap       *float64               `protobuf:"fixed64,12,opt,name=venue_capacity_cap,json=venueCapacityCap,proto3,oneof" json:"venue_capacity_cap,omitempty"`                                                      // of offices and social spaces
+	ClosedSpaces           map[string]bool        `protobuf:"bytes,13,rep,name=closed_spaces,json=closedSpaces,proto3" json:"closed_spaces,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // keyed by space type
+	IsWorkFromHomeMandate  bool                   `protobuf:"varint,14,opt,name=is_work_from_home_mandate,json=isWorkFromHomeMandate,proto3" json:"is_work_from_home_mandate,omitempty"`
+	ComplianceFatigue      float64                `protobuf:"fixed64,15,opt,name=compliance_fatigue,json=complianceFatigue,proto3" json:"compliance_fatigue,omitempty"`
+	RiskPerception         float64                `protobuf:"fixed64,16,opt,name=risk_perception,json=riskPerception,proto3" json:"risk_perception,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	mi := &file_simulation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Policy) GetIsMaskMandate() bool {
+	if x != nil {
+		return x.IsMaskMandate
+	}
+	return false
+}
+
+func (x *Policy) GetIsSelfIsolationMandate() bool {
+	if x != nil {
+		return x.IsSelfIsolationMandate
+	}
+	return false
+}
+
+func (x *Policy) GetIsSelfReportingMandate() bool {
+	if x != nil {
+		return x.IsSelfReportingMandate
+	}
+	return false
+}
+
+func (x *Policy) GetIsLockdown() bool {
+	if x != nil {
+		return x.IsLockdown
+	}
+	return false
+}
+
+func (x *Policy) GetTestStrategy() string {
+	if x != nil {
+		return x.TestStrategy
+	}
+	return ""
+}
+
+func (x *Policy) GetTestCapacityMultiplier() float64 {
+	if x != nil {
+		return x.TestCapacityMultiplier
+	}
+	return 0
+}
+
+func (x *Policy) GetComplianceProbability() float64 {
+	if x != nil {
+		return x.ComplianceProbability
+	}
+	return 0
+}
+
+func (x *Policy) GetVentilationImprovement() map[string]float64 {
+	if x != nil {
+		return x.VentilationImprovement
+	}
+	return nil
+}
+
+func (x *Policy) GetIsTransitMaskMandate() bool {
+	if x != nil {
+		return x.IsTransitMaskMandate
+	}
+	return false
+}
+
+func (x *Policy) GetTransitCapacityCap() float64 {
+	if x != nil && x.TransitCapacityCap != nil {
+		return *x.TransitCapacityCap
+	}
+	return 0
+}
+
+func (x *Policy) GetOutdoorCapacityCap() float64 {
+	if x != nil && x.OutdoorCapacityCap != nil {
+		return *x.OutdoorCapacityCap
+	}
+	return 0
+}
+
+func (x *Policy) GetVenueCapacityCap() float64 {
+	if x != nil && x.VenueCapacityCap != nil {
+		return *x.VenueCapacityCap
+	}
+	return 0
+}
+
+func (x *Policy) GetClosedSpaces() map[string]bool {
+	if x != nil {
+		return x.ClosedSpaces
+	}
+	return nil
+}
+
+func (x *Policy) GetIsWorkFromHomeMandate() bool {
+	if x != nil {
+		return x.IsWorkFromHomeMandate
+	}
+	return false
+}
+
+func (x *Policy) GetComplianceFatigue() float64 {
+	if x != nil {
+		return x.ComplianceFatigue
+	}
+	return 0
+}
+
+func (x *Policy) GetRiskPerception() float64 {
+	if x != nil {
+		return x.RiskPerception
+	}
+	return 0
+}
+
+type Jurisdiction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Policy        *Policy                `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Feature       []byte                 `protobuf:"bytes,3,opt,name=feature,proto3" json:"feature,omitempty"` // GeoJSON feature
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Jurisdiction) Reset() {
+	*x = Jurisdiction{}
+	mi := &file_simulation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Jurisdiction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Jurisdiction) ProtoMessage() {}
+
+func (x *Jurisdiction) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Jurisdiction.ProtoReflect.Descriptor instead.
+func (*Jurisdiction) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Jurisdiction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Jurisdiction) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *Jurisdiction) GetFeature() []byte {
+	if x != nil {
+		return x.Feature
+	}
+	return nil
+}
+
+type Command struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version int32                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"` // protocol version, unset means 1
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Command_ApplyPolicyUpdate
+	//	*Command_SetSpeed
+	//	*Command_Step
+	//	*Command_RunUntil
+	//	*Command_QueryJurisdiction
+	//	*Command_QueryEntity
+	//	*Command_SetAgentStream
+	Payload       isCommand_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_simulation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Command) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Command) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Command) GetPayload() isCommand_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Command) GetApplyPolicyUpdate() *ApplyPolicyUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_ApplyPolicyUpdate); ok {
+			return x.ApplyPolicyUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetSpeed() *SetSpeedPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_SetSpeed); ok {
+			return x.SetSpeed
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetStep() *StepPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_Step); ok {
+			return x.Step
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetRunUntil() *RunUntilPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_RunUntil); ok {
+			return x.RunUntil
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetQueryJurisdiction() *QueryJurisdictionPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_QueryJurisdiction); ok {
+			return x.QueryJurisdiction
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetQueryEntity() *QueryEntityPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_QueryEntity); ok {
+			return x.QueryEntity
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetAgentStream() *AgentStreamConfig {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_SetAgentStream); ok {
+			return x.SetAgentStream
+		}
+	}
+	return nil
+}
+
+type isCommand_Payload interface {
+	isCommand_Payload()
+}
+
+type Command_ApplyPolicyUpdate struct {
+	ApplyPolicyUpdate *ApplyPolicyUpdatePayload `protobuf:"bytes,2,opt,name=apply_policy_update,json=applyPolicyUpdate,proto3,oneof"`
+}
+
+type Command_SetSpeed struct {
+	SetSpeed *SetSpeedPayload `protobuf:"bytes,3,opt,name=set_speed,json=setSpeed,proto3,oneof"`
+}
+
+type Command_Step struct {
+	Step *StepPayload `protobuf:"bytes,4,opt,name=step,proto3,oneof"`
+}
+
+type Command_RunUntil struct {
+	RunUntil *RunUntilPayload `protobuf:"bytes,5,opt,name=run_until,json=runUntil,proto3,oneof"`
+}
+
+type Command_QueryJurisdiction struct {
+	QueryJurisdiction *QueryJurisdictionPayload `protobuf:"bytes,6,opt,name=query_jurisdiction,json=queryJurisdiction,proto3,oneof"` // query_policy and query_population
+}
+
+type Command_QueryEntity struct {
+	QueryEntity *QueryEntityPayload `protobuf:"bytes,7,opt,name=query_entity,json=queryEntity,proto3,oneof"` // query_space, query_agent and kill_simulation
+}
+
+type Command_SetAgentStream struct {
+	SetAgentStream *AgentStreamConfig `protobuf:"bytes,9,opt,name=set_agent_stream,json=setAgentStream,proto3,oneof"`
+}
+
+func (*Command_ApplyPolicyUpdate) isCommand_Payload() {}
+
+func (*Command_SetSpeed) isCommand_Payload() {}
+
+func (*Command_Step) isCommand_Payload() {}
+
+func (*Command_RunUntil) isCommand_Payload() {}
+
+func (*Command_QueryJurisdiction) isCommand_Payload() {}
+
+func (*Command_QueryEntity) isCommand_Payload() {}
+
+func (*Command_SetAgentStream) isCommand_Payload() {}
+
+// unset fields leave the policy unchanged
+type ApplyPolicyUpdatePayload struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	JurisdictionId         string                 `protobuf:"bytes,1,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	IsMaskMandate          *bool                  `protobuf:"varint,2,opt,name=is_mask_mandate,json=isMaskMandate,proto3,oneof" json:"is_mask_mandate,omitempty"`
+	IsSelfIsolationMandate *bool                  `protobuf:"varint,3,opt,name=is_self_isolation_mandate,json=isSelfIsolationMandate,proto3,oneof" json:"is_self_isolation_mandate,omitempty"`
+	IsSelfReportingMandate *bool                  `protobuf:"varint,4,opt,name=is_self_reporting_mandate,json=isSelfReportingMandate,proto3,oneof" json:"is_self_reporting_mandate,omitempty"`
+	IsLockdown             *bool                  `protobuf:"varint,5,opt,name=is_lockdown,json=isLockdown,proto3,oneof" json:"is_lockdown,omitempty"`
+	TestStrategy           *string                `protobuf:"bytes,6,opt,name=test_strategy,json=testStrategy,proto3,oneof" json:"test_strategy,omitempty"`
+	TestCapacityMultiplier *float64               `protobuf:"fixed64,7,opt,name=test_capacity_multiplier,json=testCapacityMultiplier,proto3,oneof" json:"test_capacity_multiplier,omitempty"`
+	ComplianceProbability  *float64               `protobuf:"fixed64,8,opt,name=compliance_probability,json=complianceProbability,proto3,oneof" json:"compliance_probability,omitempty"`
+	VentilationImprovement map[string]float64     `protobuf:"bytes,9,rep,name=ventilation_improvement,json=ventilationImprovement,proto3" json:"ventilation_improvement,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // only the listed space types are updated
+	IsTransitMaskMandate   *bool                  `protobuf:"varint,10,opt,name=is_transit_mask_mandate,json=isTransitMaskMandate,proto3,oneof" json:"is_transit_mask_mandate,omitempty"`
+	TransitCapacityCap     *float64               `protobuf:"fixed64,11,opt,name=transit_capacity_cap,json=transitCapacityCap,proto3,oneof" json:"transit_capacity_cap,omitempty"` // a negative cap lifts it
+	OutdoorCapacityCap     *float64               `protobuf:"fixed64,12,opt,name=outdoor_capacity_cap,json=outdoorCapacityCap,proto3,oneof" json:"outdoor_capacity_cap,omitempty"`
+	VenueCapacityCap       *float64               `protobuf:"fixed64,13,opt,name=venue_capacity_cap,json=venueCapacityCap,proto3,oneof" json:"venue_capacity_cap,omitempty"`
+	ClosedSpaces           map[string]bool        `protobuf:"bytes,14,rep,name=closed_spaces,json=closedSpaces,proto3" json:"closed_spaces,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // only the listed space types are opened or closed
+	IsWorkFromHomeMandate  *bool                  `protobuf:"varint,15,opt,name=is_work_from_home_mandate,json=isWorkFromHomeMandate,proto3,oneof" json:"is_work_from_home_mandate,omitempty"`
+	ComplianceFatigue      *float64               `protobuf:"fixed64,16,opt,name=compliance_fatigue,json=complianceFatigue,proto3,oneof" json:"compliance_fatigue,omitempty"`
+	RiskPerception         *float64               `protobuf:"fixed64,17,opt,name=risk_perception,json=riskPerception,proto3,oneof" json:"risk_perception,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ApplyPolicyUpdatePayload) Reset() {
+	*x = ApplyPolicyUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyPolicyUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyPolicyUpdatePayload) ProtoMessage() {}
+
+func (x *ApplyPolicyUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyPolicyUpdatePayload.ProtoReflect.Descriptor instead.
+func (*ApplyPolicyUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApplyPolicyUpdatePayload) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+func (x *ApplyPolicyUpdatePayload) GetIsMaskMandate() bool {
+	if x != nil && x.IsMaskMandate != nil {
+		return *x.IsMaskMandate
+	}
+	return false
+}
+
+func (x *ApplyPolicyUpdatePayload) GetIsSelfIsolationMandate() bool {
+	if x != nil && x.IsSelfIsolationMandate != nil {
+		return *x.IsSelfIsolationMandate
+	}
+	return false
+}
+
+func (x *ApplyPolicyUpdatePayload) GetIsSelfReportingMandate() bool {
+	if x != nil && x.IsSelfReportingMandate != nil {
+		return *x.IsSelfReportingMandate
+	}
+	return false
+}
+
+func (x *ApplyPolicyUpdatePayload) GetIsLockdown() bool {
+	if x != nil && x.IsLockdown != nil {
+		return *x.IsLockdown
+	}
+	return false
+}
+
+func (x *ApplyPolicyUpdatePayload) GetTestStrategy() string {
+	if x != nil && x.TestStrategy != nil {
+		return *x.TestStrategy
+	}
+	return ""
+}
+
+func (x *ApplyPolicyUpdatePayload) GetTestCapacityMultiplier() float64 {
+	if x != nil && x.TestCapacityMultiplier != nil {
+		return *x.TestCapacityMultiplier
+	}
+	return 0
+}
+
+func (x *ApplyPolicyUpdatePayload) GetComplianceProbability() float64 {
+	if x != nil && x.ComplianceProbability != nil {
+		return *x.ComplianceProbability
+	}
+	return 0
+}
+
+func (x *ApplyPolicyUpdatePayload) GetVentilationImprovement() map[string]float64 {
+	if x != nil {
+		return x.VentilationImprovement
+	}
+	return nil
+}
+
+func (x *ApplyPolicyUpdatePayload) GetIsTransitMaskMandate() bool {
+	if x != nil && x.IsTransitMaskMandate != nil {
+		return *x.IsTransitMaskMandate
+	}
+	return false
+}
+
+func (x *ApplyPolicyUpdatePayload) GetTransitCapacityCap() float64 {
+	if x != nil && x.TransitCapacityCap != nil {
+		return *x.TransitCapacityCap
+	}
+	return 0
+}
+
+func (x *ApplyPolicyUpdatePayload) GetOutdoorCapacityCap() float64 {
+	if x != nil && x.OutdoorCapacityCap != nil {
+		return *x.OutdoorCapacityCap
+	}
+	return 0
+}
+
+func (x *ApplyPolicyUpdatePayload) GetVenueCapacityCap() float64 {
+	if x != nil && x.VenueCapacityCap != nil {
+		return *x.VenueCapacityCap
+	}
+	return 0
+}
+
+func (x *ApplyPolicyUpdatePayload) GetClosedSpaces() map[string]bool {
+	if x != nil {
+		return x.ClosedSpaces
+	}
+	return nil
+}
+
+func (x *ApplyPolicyUpdatePayload) GetIsWorkFromHomeMandate() bool {
+	if x != nil && x.IsWorkFromHomeMandate != nil {
+		return *x.IsWorkFromHomeMandate
+	}
+	return false
+}
+
+func (x *ApplyPolicyUpdatePayload) GetComplianceFatigue() float64 {
+	if x != nil && x.ComplianceFatigue != nil {
+		return *x.ComplianceFatigue
+	}
+	return 0
+}
+
+func (x *ApplyPolicyUpdatePayload) GetRiskPerception() float64 {
+	if x != nil && x.RiskPerception != nil {
+		return *x.RiskPerception
+	}
+	return 0
+}
+
+type SetSpeedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Speed         float64                `protobuf:"fixed64,1,opt,name=speed,proto3" json:"speed,omitempty"` // simulated seconds per wall clock second, 0 is unthrottled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSpeedPayload) Reset() {
+	*x = SetSpeedPayload{}
+	mi := &file_simulation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSpeedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSpeedPayload) ProtoMessage() {}
+
+func (x *SetSpeedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSpeedPayload.ProtoReflect.Descriptor instead.
+func (*SetSpeedPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetSpeedPayload) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type StepPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epochs        int64                  `protobuf:"varint,1,opt,name=epochs,proto3" json:"epochs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepPayload) Reset() {
+	*x = StepPayload{}
+	mi := &file_simulation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepPayload) ProtoMessage() {}
+
+func (x *StepPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepPayload.ProtoReflect.Descriptor instead.
+func (*StepPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StepPayload) GetEpochs() int64 {
+	if x != nil {
+		return x.Epochs
+	}
+	return 0
+}
+
+type RunUntilPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           int64                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunUntilPayload) Reset() {
+	*x = RunUntilPayload{}
+	mi := &file_simulation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunUntilPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunUntilPayload) ProtoMessage() {}
+
+func (x *RunUntilPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunUntilPayload.ProtoReflect.Descriptor instead.
+func (*RunUntilPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RunUntilPayload) GetDay() int64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+// opts into the state and location updates of a sample of agents, which
+// are streamed in batches, one per simulated hour
+type AgentStreamConfig struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Enabled          bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	SampleRate       float64                `protobuf:"fixed64,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // 0 streams every agent
+	JurisdictionId   string                 `protobuf:"bytes,3,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	MaxEventsPerHour int64                  `protobuf:"varint,4,opt,name=max_events_per_hour,json=maxEventsPerHour,proto3" json:"max_events_per_hour,omitempty"` // 0 means no limit
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AgentStreamConfig) Reset() {
+	*x = AgentStreamConfig{}
+	mi := &file_simulation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentStreamConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStreamConfig) ProtoMessage() {}
+
+func (x *AgentStreamConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStreamConfig.ProtoReflect.Descriptor instead.
+func (*AgentStreamConfig) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AgentStreamConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *AgentStreamConfig) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *AgentStreamConfig) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+func (x *AgentStreamConfig) GetMaxEventsPerHour() int64 {
+	if x != nil {
+		return x.MaxEventsPerHour
+	}
+	return 0
+}
+
+type QueryJurisdictionPayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JurisdictionId string                 `protobuf:"bytes,1,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QueryJurisdictionPayload) Reset() {
+	*x = QueryJurisdictionPayload{}
+	mi := &file_simulation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryJurisdictionPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryJurisdictionPayload) ProtoMessage() {}
+
+func (x *QueryJurisdictionPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryJurisdictionPayload.ProtoReflect.Descriptor instead.
+func (*QueryJurisdictionPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QueryJurisdictionPayload) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+type QueryEntityPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryEntityPayload) Reset() {
+	*x = QueryEntityPayload{}
+	mi := &file_simulation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryEntityPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryEntityPayload) ProtoMessage() {}
+
+func (x *QueryEntityPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryEntityPayload.ProtoReflect.Descriptor instead.
+func (*QueryEntityPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *QueryEntityPayload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CommandResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Error string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*CommandResponse_Policy
+	//	*CommandResponse_Budget
+	//	*CommandResponse_Time
+	//	*CommandResponse_Population
+	//	*CommandResponse_Space
+	//	*CommandResponse_Agent
+	//	*CommandResponse_Simulations
+	Payload       isCommandResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandResponse) Reset() {
+	*x = CommandResponse{}
+	mi := &file_simulation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResponse) ProtoMessage() {}
+
+func (x *CommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResponse.ProtoReflect.Descriptor instead.
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CommandResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CommandResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CommandResponse) GetPayload() isCommandResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetPolicy() *PolicyUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*CommandResponse_Policy); ok {
+			return x.Policy
+		}
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetBudget() *BudgetUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*CommandResponse_Budget); ok {
+			return x.Budget
+		}
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetTime() *EpochEndPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*CommandResponse_Time); ok {
+			return x.Time
+		}
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetPopulation() *PopulationQueryResult {
+	if x != nil {
+		if x, ok := x.Payload.(*CommandResponse_Population); ok {
+			return x.Population
+		}
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetSpace() *SpaceQueryResult {
+	if x != nil {
+		if x, ok := x.Payload.(*CommandResponse_Space); ok {
+			return x.Space
+		}
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetAgent() *AgentQueryResult {
+	if x != nil {
+		if x, ok := x.Payload.(*CommandResponse_Agent); ok {
+			return x.Agent
+		}
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetSimulations() *SimulationList {
+	if x != nil {
+		if x, ok := x.Payload.(*CommandResponse_Simulations); ok {
+			return x.Simulations
+		}
+	}
+	return nil
+}
+
+type isCommandResponse_Payload interface {
+	isCommandResponse_Payload()
+}
+
+type CommandResponse_Policy struct {
+	Policy *PolicyUpdatePayload `protobuf:"bytes,3,opt,name=policy,proto3,oneof"`
+}
+
+type CommandResponse_Budget struct {
+	Budget *BudgetUpdatePayload `protobuf:"bytes,4,opt,name=budget,proto3,oneof"`
+}
+
+type CommandResponse_Time struct {
+	Time *EpochEndPayload `protobuf:"bytes,5,opt,name=time,proto3,oneof"`
+}
+
+type CommandResponse_Population struct {
+	Population *PopulationQueryResult `protobuf:"bytes,6,opt,name=population,proto3,oneof"`
+}
+
+type CommandResponse_Space struct {
+	Space *SpaceQueryResult `protobuf:"bytes,7,opt,name=space,proto3,oneof"`
+}
+
+type CommandResponse_Agent struct {
+	Agent *AgentQueryResult `protobuf:"bytes,8,opt,name=agent,proto3,oneof"`
+}
+
+type CommandResponse_Simulations struct {
+	Simulations *SimulationList `protobuf:"bytes,9,opt,name=simulations,proto3,oneof"`
+}
+
+func (*CommandResponse_Policy) isCommandResponse_Payload() {}
+
+func (*CommandResponse_Budget) isCommandResponse_Payload() {}
+
+func (*CommandResponse_Time) isCommandResponse_Payload() {}
+
+func (*CommandResponse_Population) isCommandResponse_Payload() {}
+
+func (*CommandResponse_Space) isCommandResponse_Payload() {}
+
+func (*CommandResponse_Agent) isCommandResponse_Payload() {}
+
+func (*CommandResponse_Simulations) isCommandResponse_Payload() {}
+
+type PopulationQueryResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JurisdictionId string                 `protobuf:"bytes,1,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	Total          int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Counts         map[string]int64       `protobuf:"bytes,3,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // keyed by agent state
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PopulationQueryResult) Reset() {
+	*x = PopulationQueryResult{}
+	mi := &file_simulation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PopulationQueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PopulationQueryResult) ProtoMessage() {}
+
+func (x *PopulationQueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PopulationQueryResult.ProtoReflect.Descriptor instead.
+func (*PopulationQueryResult) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PopulationQueryResult) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+func (x *PopulationQueryResult) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PopulationQueryResult) GetCounts() map[string]int64 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type SpaceQueryResult struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	JurisdictionId       string                 `protobuf:"bytes,3,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	Volume               float64                `protobuf:"fixed64,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	AirChangeRate        float64                `protobuf:"fixed64,5,opt,name=air_change_rate,json=airChangeRate,proto3" json:"air_change_rate,omitempty"`
+	TotalInfectiousDoses float64                `protobuf:"fixed64,6,opt,name=total_infectious_doses,json=totalInfectiousDoses,proto3" json:"total_infectious_doses,omitempty"`
+	Occupants            []string               `protobuf:"bytes,7,rep,name=occupants,proto3" json:"occupants,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SpaceQueryResult) Reset() {
+	*x = SpaceQueryResult{}
+	mi := &file_simulation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceQueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceQueryResult) ProtoMessage() {}
+
+func (x *SpaceQueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceQueryResult.ProtoReflect.Descriptor instead.
+func (*SpaceQueryResult) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SpaceQueryResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SpaceQueryResult) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SpaceQueryResult) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+func (x *SpaceQueryResult) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *SpaceQueryResult) GetAirChangeRate() float64 {
+	if x != nil {
+		return x.AirChangeRate
+	}
+	return 0
+}
+
+func (x *SpaceQueryResult) GetTotalInfectiousDoses() float64 {
+	if x != nil {
+		return x.TotalInfectiousDoses
+	}
+	return 0
+}
+
+func (x *SpaceQueryResult) GetOccupants() []string {
+	if x != nil {
+		return x.Occupants
+	}
+	return nil
+}
+
+type AgentQueryResult struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State               string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	StateChangeEpoch    int64                  `protobuf:"varint,3,opt,name=state_change_epoch,json=stateChangeEpoch,proto3" json:"state_change_epoch,omitempty"`
+	LocationId          string                 `protobuf:"bytes,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	LocationType        string                 `protobuf:"bytes,5,opt,name=location_type,json=locationType,proto3" json:"location_type,omitempty"`
+	HouseholdId         string                 `protobuf:"bytes,6,opt,name=household_id,json=householdId,proto3" json:"household_id,omitempty"`
+	OfficeId            string                 `protobuf:"bytes,7,opt,name=office_id,json=officeId,proto3" json:"office_id,omitempty"`
+	JurisdictionId      string                 `protobuf:"bytes,8,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	HasInfectionProfile bool                   `protobuf:"varint,9,opt,name=has_infection_profile,json=hasInfectionProfile,proto3" json:"has_infection_profile,omitempty"`
+	HasSelfReported     bool                   `protobuf:"varint,10,opt,name=has_self_reported,json=hasSelfReported,proto3" json:"has_self_reported,omitempty"`
+	InfectionCount      int64                  `protobuf:"varint,11,opt,name=infection_count,json=infectionCount,proto3" json:"infection_count,omitempty"`
+	Occupation          string                 `protobuf:"bytes,12,opt,name=occupation,proto3" json:"occupation,omitempty"` // key_worker, remote_worker or on_site_worker
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *AgentQueryResult) Reset() {
+	*x = AgentQueryResult{}
+	mi := &file_simulation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentQueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentQueryResult) ProtoMessage() {}
+
+func (x *AgentQueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentQueryResult.ProtoReflect.Descriptor instead.
+func (*AgentQueryResult) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AgentQueryResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentQueryResult) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *AgentQueryResult) GetStateChangeEpoch() int64 {
+	if x != nil {
+		return x.StateChangeEpoch
+	}
+	return 0
+}
+
+func (x *AgentQueryResult) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *AgentQueryResult) GetLocationType() string {
+	if x != nil {
+		return x.LocationType
+	}
+	return ""
+}
+
+func (x *AgentQueryResult) GetHouseholdId() string {
+	if x != nil {
+		return x.HouseholdId
+	}
+	return ""
+}
+
+func (x *AgentQueryResult) GetOfficeId() string {
+	if x != nil {
+		return x.OfficeId
+	}
+	return ""
+}
+
+func (x *AgentQueryResult) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+func (x *AgentQueryResult) GetHasInfectionProfile() bool {
+	if x != nil {
+		return x.HasInfectionProfile
+	}
+	return false
+}
+
+func (x *AgentQueryResult) GetHasSelfReported() bool {
+	if x != nil {
+		return x.HasSelfReported
+	}
+	return false
+}
+
+func (x *AgentQueryResult) GetInfectionCount() int64 {
+	if x != nil {
+		return x.InfectionCount
+	}
+	return 0
+}
+
+func (x *AgentQueryResult) GetOccupation() string {
+	if x != nil {
+		return x.Occupation
+	}
+	return ""
+}
+
+type SimulationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulations   []*SimulationInfo      `protobuf:"bytes,1,rep,name=simulations,proto3" json:"simulations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationList) Reset() {
+	*x = SimulationList{}
+	mi := &file_simulation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationList) ProtoMessage() {}
+
+func (x *SimulationList) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationList.ProtoReflect.Descriptor instead.
+func (*SimulationList) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SimulationList) GetSimulations() []*SimulationInfo {
+	if x != nil {
+		return x.Simulations
+	}
+	return nil
+}
+
+type SimulationInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ApiId           string                 `protobuf:"bytes,2,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	State           string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Epoch           int64                  `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	NumAgents       int64                  `protobuf:"varint,5,opt,name=num_agents,json=numAgents,proto3" json:"num_agents,omitempty"`
+	EstimatedMemory int64                  `protobuf:"varint,6,opt,name=estimated_memory,json=estimatedMemory,proto3" json:"estimated_memory,omitempty"`
+	QueuedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=queued_at,json=queuedAt,proto3" json:"queued_at,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SimulationInfo) Reset() {
+	*x = SimulationInfo{}
+	mi := &file_simulation_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationInfo) ProtoMessage() {}
+
+func (x *SimulationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationInfo.ProtoReflect.Descriptor instead.
+func (*SimulationInfo) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SimulationInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SimulationInfo) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *SimulationInfo) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SimulationInfo) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SimulationInfo) GetNumAgents() int64 {
+	if x != nil {
+		return x.NumAgents
+	}
+	return 0
+}
+
+func (x *SimulationInfo) GetEstimatedMemory() int64 {
+	if x != nil {
+		return x.EstimatedMemory
+	}
+	return 0
+}
+
+func (x *SimulationInfo) GetQueuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.QueuedAt
+	}
+	return nil
+}
+
+func (x *SimulationInfo) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+type Event struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ApiId        string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	SimulationId string                 `protobuf:"bytes,2,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Type         string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_SimulationInitialized
+	//	*Event_SimulationStateUpdate
+	//	*Event_SimulationEnded
+	//	*Event_EpochEnd
+	//	*Event_CommandProcessed
+	//	*Event_AgentStateUpdate
+	//	*Event_AgentLocationUpdate
+	//	*Event_SpaceOccupancyUpdate
+	//	*Event_SpaceTestingUpdate
+	//	*Event_PolicyUpdate
+	//	*Event_BudgetUpdate
+	//	*Event_CaseDetected
+	//	*Event_Admission
+	//	*Event_SimulationInitFailed
+	//	*Event_AgentTurnedAway
+	//	*Event_AgentWorkedFromHome
+	//	*Event_ComplianceUpdate
+	Payload       isEvent_Payload `protobuf_oneof:"payload"`
+	Version       int32           `protobuf:"varint,17,opt,name=version,proto3" json:"version,omitempty"` // protocol version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_simulation_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Event) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *Event) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetPayload() isEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetSimulationInitialized() *SimulationInitializedPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SimulationInitialized); ok {
+			return x.SimulationInitialized
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSimulationStateUpdate() *SimulationStateUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SimulationStateUpdate); ok {
+			return x.SimulationStateUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSimulationEnded() *SimulationEndedPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SimulationEnded); ok {
+			return x.SimulationEnded
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetEpochEnd() *EpochEndPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_EpochEnd); ok {
+			return x.EpochEnd
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetCommandProcessed() *CommandProcessedPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_CommandProcessed); ok {
+			return x.CommandProcessed
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetAgentStateUpdate() *AgentStateUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_AgentStateUpdate); ok {
+			return x.AgentStateUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetAgentLocationUpdate() *AgentLocationUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_AgentLocationUpdate); ok {
+			return x.AgentLocationUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSpaceOccupancyUpdate() *SpaceOccupancyUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SpaceOccupancyUpdate); ok {
+			return x.SpaceOccupancyUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSpaceTestingUpdate() *SpaceTestingUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SpaceTestingUpdate); ok {
+			return x.SpaceTestingUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPolicyUpdate() *PolicyUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_PolicyUpdate); ok {
+			return x.PolicyUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetBudgetUpdate() *BudgetUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_BudgetUpdate); ok {
+			return x.BudgetUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetCaseDetected() *CaseDetectedPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_CaseDetected); ok {
+			return x.CaseDetected
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetAdmission() *AdmissionPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Admission); ok {
+			return x.Admission
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSimulationInitFailed() *SimulationInitFailedPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SimulationInitFailed); ok {
+			return x.SimulationInitFailed
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetAgentTurnedAway() *AgentTurnedAwayPayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_AgentTurnedAway); ok {
+			return x.AgentTurnedAway
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetAgentWorkedFromHome() *AgentWorkedFromHomePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_AgentWorkedFromHome); ok {
+			return x.AgentWorkedFromHome
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetComplianceUpdate() *ComplianceUpdatePayload {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ComplianceUpdate); ok {
+			return x.ComplianceUpdate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_SimulationInitialized struct {
+	SimulationInitialized *SimulationInitializedPayload `protobuf:"bytes,4,opt,name=simulation_initialized,json=simulationInitialized,proto3,oneof"`
+}
+
+type Event_SimulationStateUpdate struct {
+	SimulationStateUpdate *SimulationStateUpdatePayload `protobuf:"bytes,5,opt,name=simulation_state_update,json=simulationStateUpdate,proto3,oneof"`
+}
+
+type Event_SimulationEnded struct {
+	SimulationEnded *SimulationEndedPayload `protobuf:"bytes,6,opt,name=simulation_ended,json=simulationEnded,proto3,oneof"`
+}
+
+type Event_EpochEnd struct {
+	EpochEnd *EpochEndPayload `protobuf:"bytes,7,opt,name=epoch_end,json=epochEnd,proto3,oneof"`
+}
+
+type Event_CommandProcessed struct {
+	CommandProcessed *CommandProcessedPayload `protobuf:"bytes,8,opt,name=command_processed,json=commandProcessed,proto3,oneof"`
+}
+
+type Event_AgentStateUpdate struct {
+	AgentStateUpdate *AgentStateUpdatePayload `protobuf:"bytes,9,opt,name=agent_state_update,json=agentStateUpdate,proto3,oneof"`
+}
+
+type Event_AgentLocationUpdate struct {
+	AgentLocationUpdate *AgentLocationUpdatePayload `protobuf:"bytes,10,opt,name=agent_location_update,json=agentLocationUpdate,proto3,oneof"`
+}
+
+type Event_SpaceOccupancyUpdate struct {
+	SpaceOccupancyUpdate *SpaceOccupancyUpdatePayload `protobuf:"bytes,11,opt,name=space_occupancy_update,json=spaceOccupancyUpdate,proto3,oneof"`
+}
+
+type Event_SpaceTestingUpdate struct {
+	SpaceTestingUpdate *SpaceTestingUpdatePayload `protobuf:"bytes,12,opt,name=space_testing_update,json=spaceTestingUpdate,proto3,oneof"`
+}
+
+type Event_PolicyUpdate struct {
+	PolicyUpdate *PolicyUpdatePayload `protobuf:"bytes,13,opt,name=policy_update,json=policyUpdate,proto3,oneof"`
+}
+
+type Event_BudgetUpdate struct {
+	BudgetUpdate *BudgetUpdatePayload `protobuf:"bytes,14,opt,name=budget_update,json=budgetUpdate,proto3,oneof"`
+}
+
+type Event_CaseDetected struct {
+	CaseDetected *CaseDetectedPayload `protobuf:"bytes,15,opt,name=case_detected,json=caseDetected,proto3,oneof"`
+}
+
+type Event_Admission struct {
+	Admission *AdmissionPayload `protobuf:"bytes,16,opt,name=admission,proto3,oneof"` // simulation_queued and simulation_rejected
+}
+
+type Event_SimulationInitFailed struct {
+	SimulationInitFailed *SimulationInitFailedPayload `protobuf:"bytes,18,opt,name=simulation_init_failed,json=simulationInitFailed,proto3,oneof"`
+}
+
+type Event_AgentTurnedAway struct {
+	AgentTurnedAway *AgentTurnedAwayPayload `protobuf:"bytes,19,opt,name=agent_turned_away,json=agentTurnedAway,proto3,oneof"`
+}
+
+type Event_AgentWorkedFromHome struct {
+	AgentWorkedFromHome *AgentWorkedFromHomePayload `protobuf:"bytes,20,opt,name=agent_worked_from_home,json=agentWorkedFromHome,proto3,oneof"`
+}
+
+type Event_ComplianceUpdate struct {
+	ComplianceUpdate *ComplianceUpdatePayload `protobuf:"bytes,21,opt,name=compliance_update,json=complianceUpdate,proto3,oneof"`
+}
+
+func (*Event_SimulationInitialized) isEvent_Payload() {}
+
+func (*Event_SimulationStateUpdate) isEvent_Payload() {}
+
+func (*Event_SimulationEnded) isEvent_Payload() {}
+
+func (*Event_EpochEnd) isEvent_Payload() {}
+
+func (*Event_CommandProcessed) isEvent_Payload() {}
+
+func (*Event_AgentStateUpdate) isEvent_Payload() {}
+
+func (*Event_AgentLocationUpdate) isEvent_Payload() {}
+
+func (*Event_SpaceOccupancyUpdate) isEvent_Payload() {}
+
+func (*Event_SpaceTestingUpdate) isEvent_Payload() {}
+
+func (*Event_PolicyUpdate) isEvent_Payload() {}
+
+func (*Event_BudgetUpdate) isEvent_Payload() {}
+
+func (*Event_CaseDetected) isEvent_Payload() {}
+
+func (*Event_Admission) isEvent_Payload() {}
+
+func (*Event_SimulationInitFailed) isEvent_Payload() {}
+
+func (*Event_AgentTurnedAway) isEvent_Payload() {}
+
+func (*Event_AgentWorkedFromHome) isEvent_Payload() {}
+
+func (*Event_ComplianceUpdate) isEvent_Payload() {}
+
+type SimulationInitializedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jurisdictions []*Jurisdiction        `protobuf:"bytes,1,rep,name=jurisdictions,proto3" json:"jurisdictions,omitempty"`
+	Config        *Config                `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"` // with the preset and overrides resolved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationInitializedPayload) Reset() {
+	*x = SimulationInitializedPayload{}
+	mi := &file_simulation_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationInitializedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationInitializedPayload) ProtoMessage() {}
+
+func (x *SimulationInitializedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationInitializedPayload.ProtoReflect.Descriptor instead.
+func (*SimulationInitializedPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SimulationInitializedPayload) GetJurisdictions() []*Jurisdiction {
+	if x != nil {
+		return x.Jurisdictions
+	}
+	return nil
+}
+
+func (x *SimulationInitializedPayload) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type SimulationInitFailedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Errors        []string               `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationInitFailedPayload) Reset() {
+	*x = SimulationInitFailedPayload{}
+	mi := &file_simulation_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationInitFailedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationInitFailedPayload) ProtoMessage() {}
+
+func (x *SimulationInitFailedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationInitFailedPayload.ProtoReflect.Descriptor instead.
+func (*SimulationInitFailedPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SimulationInitFailedPayload) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *SimulationInitFailedPayload) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type SimulationStateUpdatePayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	PreviousState string                 `protobuf:"bytes,3,opt,name=previous_state,json=previousState,proto3" json:"previous_state,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationStateUpdatePayload) Reset() {
+	*x = SimulationStateUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationStateUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationStateUpdatePayload) ProtoMessage() {}
+
+func (x *SimulationStateUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationStateUpdatePayload.ProtoReflect.Descriptor instead.
+func (*SimulationStateUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SimulationStateUpdatePayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SimulationStateUpdatePayload) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SimulationStateUpdatePayload) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+func (x *SimulationStateUpdatePayload) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SimulationEndedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationEndedPayload) Reset() {
+	*x = SimulationEndedPayload{}
+	mi := &file_simulation_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationEndedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationEndedPayload) ProtoMessage() {}
+
+func (x *SimulationEndedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationEndedPayload.ProtoReflect.Descriptor instead.
+func (*SimulationEndedPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SimulationEndedPayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SimulationEndedPayload) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SimulationEndedPayload) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SimulationEndedPayload) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type EpochEndPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	TimeStep      int64                  `protobuf:"varint,2,opt,name=time_step,json=timeStep,proto3" json:"time_step,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EpochEndPayload) Reset() {
+	*x = EpochEndPayload{}
+	mi := &file_simulation_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EpochEndPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EpochEndPayload) ProtoMessage() {}
+
+func (x *EpochEndPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EpochEndPayload.ProtoReflect.Descriptor instead.
+func (*EpochEndPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EpochEndPayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *EpochEndPayload) GetTimeStep() int64 {
+	if x != nil {
+		return x.TimeStep
+	}
+	return 0
+}
+
+func (x *EpochEndPayload) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type CommandProcessedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Command       *Command               `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandProcessedPayload) Reset() {
+	*x = CommandProcessedPayload{}
+	mi := &file_simulation_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandProcessedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandProcessedPayload) ProtoMessage() {}
+
+func (x *CommandProcessedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandProcessedPayload.ProtoReflect.Descriptor instead.
+func (*CommandProcessedPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CommandProcessedPayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *CommandProcessedPayload) GetCommand() *Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+type AgentStateUpdatePayload struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Epoch               int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Id                  string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	State               string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	PreviousState       string                 `protobuf:"bytes,4,opt,name=previous_state,json=previousState,proto3" json:"previous_state,omitempty"`
+	HasInfectionProfile bool                   `protobuf:"varint,5,opt,name=has_infection_profile,json=hasInfectionProfile,proto3" json:"has_infection_profile,omitempty"`
+	InfectionCount      int64                  `protobuf:"varint,6,opt,name=infection_count,json=infectionCount,proto3" json:"infection_count,omitempty"` // including the current infection
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *AgentStateUpdatePayload) Reset() {
+	*x = AgentStateUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentStateUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStateUpdatePayload) ProtoMessage() {}
+
+func (x *AgentStateUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStateUpdatePayload.ProtoReflect.Descriptor instead.
+func (*AgentStateUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AgentStateUpdatePayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *AgentStateUpdatePayload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentStateUpdatePayload) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *AgentStateUpdatePayload) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+func (x *AgentStateUpdatePayload) GetHasInfectionProfile() bool {
+	if x != nil {
+		return x.HasInfectionProfile
+	}
+	return false
+}
+
+func (x *AgentStateUpdatePayload) GetInfectionCount() int64 {
+	if x != nil {
+		return x.InfectionCount
+	}
+	return 0
+}
+
+type AgentLocationUpdatePayload struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Epoch              int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Id                 string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	LocationId         string                 `protobuf:"bytes,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	PreviousLocationId string                 `protobuf:"bytes,4,opt,name=previous_location_id,json=previousLocationId,proto3" json:"previous_location_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AgentLocationUpdatePayload) Reset() {
+	*x = AgentLocationUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentLocationUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentLocationUpdatePayload) ProtoMessage() {}
+
+func (x *AgentLocationUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentLocationUpdatePayload.ProtoReflect.Descriptor instead.
+func (*AgentLocationUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AgentLocationUpdatePayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *AgentLocationUpdatePayload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentLocationUpdatePayload) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *AgentLocationUpdatePayload) GetPreviousLocationId() string {
+	if x != nil {
+		return x.PreviousLocationId
+	}
+	return ""
+}
+
+type SpaceOccupancyUpdatePayload struct {
+	state             protoimpl.MessageState                  `protogen:"open.v1"`
+	Epoch             int64                                   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Id                string                                  `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Occupants         []*SpaceOccupancyUpdatePayload_Occupant `protobuf:"bytes,3,rep,name=occupants,proto3" json:"occupants,omitempty"`
+	DoseConcentration float64                                 `protobuf:"fixed64,4,opt,name=dose_concentration,json=doseConcentration,proto3" json:"dose_concentration,omitempty"` // infectious doses per cubic meter of air
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SpaceOccupancyUpdatePayload) Reset() {
+	*x = SpaceOccupancyUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceOccupancyUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceOccupancyUpdatePayload) ProtoMessage() {}
+
+func (x *SpaceOccupancyUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceOccupancyUpdatePayload.ProtoReflect.Descriptor instead.
+func (*SpaceOccupancyUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SpaceOccupancyUpdatePayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SpaceOccupancyUpdatePayload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SpaceOccupancyUpdatePayload) GetOccupants() []*SpaceOccupancyUpdatePayload_Occupant {
+	if x != nil {
+		return x.Occupants
+	}
+	return nil
+}
+
+func (x *SpaceOccupancyUpdatePayload) GetDoseConcentration() float64 {
+	if x != nil {
+		return x.DoseConcentration
+	}
+	return 0
+}
+
+type SpaceTestingUpdatePayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Positives     int64                  `protobuf:"varint,2,opt,name=positives,proto3" json:"positives,omitempty"`
+	Negatives     int64                  `protobuf:"varint,3,opt,name=negatives,proto3" json:"negatives,omitempty"`
+	Backlog       int64                  `protobuf:"varint,4,opt,name=backlog,proto3" json:"backlog,omitempty"`
+	Capacity      int64                  `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceTestingUpdatePayload) Reset() {
+	*x = SpaceTestingUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceTestingUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceTestingUpdatePayload) ProtoMessage() {}
+
+func (x *SpaceTestingUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceTestingUpdatePayload.ProtoReflect.Descriptor instead.
+func (*SpaceTestingUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SpaceTestingUpdatePayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SpaceTestingUpdatePayload) GetPositives() int64 {
+	if x != nil {
+		return x.Positives
+	}
+	return 0
+}
+
+func (x *SpaceTestingUpdatePayload) GetNegatives() int64 {
+	if x != nil {
+		return x.Negatives
+	}
+	return 0
+}
+
+func (x *SpaceTestingUpdatePayload) GetBacklog() int64 {
+	if x != nil {
+		return x.Backlog
+	}
+	return 0
+}
+
+func (x *SpaceTestingUpdatePayload) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type PolicyUpdatePayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JurisdictionId string                 `protobuf:"bytes,1,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	Policy         *Policy                `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PolicyUpdatePayload) Reset() {
+	*x = PolicyUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyUpdatePayload) ProtoMessage() {}
+
+func (x *PolicyUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyUpdatePayload.ProtoReflect.Descriptor instead.
+func (*PolicyUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PolicyUpdatePayload) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+func (x *PolicyUpdatePayload) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type BudgetUpdatePayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentBudget float64                `protobuf:"fixed64,1,opt,name=current_budget,json=currentBudget,proto3" json:"current_budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetUpdatePayload) Reset() {
+	*x = BudgetUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetUpdatePayload) ProtoMessage() {}
+
+func (x *BudgetUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetUpdatePayload.ProtoReflect.Descriptor instead.
+func (*BudgetUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BudgetUpdatePayload) GetCurrentBudget() float64 {
+	if x != nil {
+		return x.CurrentBudget
+	}
+	return 0
+}
+
+type CaseDetectedPayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Epoch          int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	SampleEpoch    int64                  `protobuf:"varint,2,opt,name=sample_epoch,json=sampleEpoch,proto3" json:"sample_epoch,omitempty"`
+	JurisdictionId string                 `protobuf:"bytes,3,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CaseDetectedPayload) Reset() {
+	*x = CaseDetectedPayload{}
+	mi := &file_simulation_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaseDetectedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseDetectedPayload) ProtoMessage() {}
+
+func (x *CaseDetectedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseDetectedPayload.ProtoReflect.Descriptor instead.
+func (*CaseDetectedPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CaseDetectedPayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *CaseDetectedPayload) GetSampleEpoch() int64 {
+	if x != nil {
+		return x.SampleEpoch
+	}
+	return 0
+}
+
+func (x *CaseDetectedPayload) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+type AgentTurnedAwayPayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Epoch          int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Id             string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SpaceId        string                 `protobuf:"bytes,3,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	SpaceType      string                 `protobuf:"bytes,4,opt,name=space_type,json=spaceType,proto3" json:"space_type,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                                       // closed or full
+	JurisdictionId string                 `protobuf:"bytes,6,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"` // of the space
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AgentTurnedAwayPayload) Reset() {
+	*x = AgentTurnedAwayPayload{}
+	mi := &file_simulation_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentTurnedAwayPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentTurnedAwayPayload) ProtoMessage() {}
+
+func (x *AgentTurnedAwayPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentTurnedAwayPayload.ProtoReflect.Descriptor instead.
+func (*AgentTurnedAwayPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AgentTurnedAwayPayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *AgentTurnedAwayPayload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentTurnedAwayPayload) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *AgentTurnedAwayPayload) GetSpaceType() string {
+	if x != nil {
+		return x.SpaceType
+	}
+	return ""
+}
+
+func (x *AgentTurnedAwayPayload) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AgentTurnedAwayPayload) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+type AgentWorkedFromHomePayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Occupation    string                 `protobuf:"bytes,3,opt,name=occupation,proto3" json:"occupation,omitempty"`
+	NextMoveEpoch int64                  `protobuf:"varint,4,opt,name=next_move_epoch,json=nextMoveEpoch,proto3" json:"next_move_epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentWorkedFromHomePayload) Reset() {
+	*x = AgentWorkedFromHomePayload{}
+	mi := &file_simulation_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentWorkedFromHomePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentWorkedFromHomePayload) ProtoMessage() {}
+
+func (x *AgentWorkedFromHomePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentWorkedFromHomePayload.ProtoReflect.Descriptor instead.
+func (*AgentWorkedFromHomePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AgentWorkedFromHomePayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *AgentWorkedFromHomePayload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentWorkedFromHomePayload) GetOccupation() string {
+	if x != nil {
+		return x.Occupation
+	}
+	return ""
+}
+
+func (x *AgentWorkedFromHomePayload) GetNextMoveEpoch() int64 {
+	if x != nil {
+		return x.NextMoveEpoch
+	}
+	return 0
+}
+
+type ComplianceUpdatePayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Epoch          int64                  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	JurisdictionId string                 `protobuf:"bytes,2,opt,name=jurisdiction_id,json=jurisdictionId,proto3" json:"jurisdiction_id,omitempty"`
+	ComplianceRate float64                `protobuf:"fixed64,3,opt,name=compliance_rate,json=complianceRate,proto3" json:"compliance_rate,omitempty"`
+	RecentRisk     float64                `protobuf:"fixed64,4,opt,name=recent_risk,json=recentRisk,proto3" json:"recent_risk,omitempty"` // recent local cases and deaths, faded by the risk memory
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ComplianceUpdatePayload) Reset() {
+	*x = ComplianceUpdatePayload{}
+	mi := &file_simulation_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceUpdatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceUpdatePayload) ProtoMessage() {}
+
+func (x *ComplianceUpdatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceUpdatePayload.ProtoReflect.Descriptor instead.
+func (*ComplianceUpdatePayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ComplianceUpdatePayload) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ComplianceUpdatePayload) GetJurisdictionId() string {
+	if x != nil {
+		return x.JurisdictionId
+	}
+	return ""
+}
+
+func (x *ComplianceUpdatePayload) GetComplianceRate() float64 {
+	if x != nil {
+		return x.ComplianceRate
+	}
+	return 0
+}
+
+func (x *ComplianceUpdatePayload) GetRecentRisk() float64 {
+	if x != nil {
+		return x.RecentRisk
+	}
+	return 0
+}
+
+type AdmissionPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  string                 `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdmissionPayload) Reset() {
+	*x = AdmissionPayload{}
+	mi := &file_simulation_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdmissionPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdmissionPayload) ProtoMessage() {}
+
+func (x *AdmissionPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdmissionPayload.ProtoReflect.Descriptor instead.
+func (*AdmissionPayload) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AdmissionPayload) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *AdmissionPayload) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type MetricsUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiId         string                 `protobuf:"bytes,1,opt,name=api_id,json=apiId,proto3" json:"api_id,omitempty"`
+	SimulationId  string                 `protobuf:"bytes,2,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Jurisdictions map[string]*Metrics    `protobuf:"bytes,3,rep,name=jurisdictions,proto3" json:"jurisdictions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // keyed by jurisdiction id
+	Version       int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                                                                                      // protocol version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricsUpdate) Reset() {
+	*x = MetricsUpdate{}
+	mi := &file_simulation_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsUpdate) ProtoMessage() {}
+
+func (x *MetricsUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsUpdate.ProtoReflect.Descriptor instead.
+func (*MetricsUpdate) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *MetricsUpdate) GetApiId() string {
+	if x != nil {
+		return x.ApiId
+	}
+	return ""
+}
+
+func (x *MetricsUpdate) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *MetricsUpdate) GetJurisdictions() map[string]*Metrics {
+	if x != nil {
+		return x.Jurisdictions
+	}
+	return nil
+}
+
+func (x *MetricsUpdate) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Metrics struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Day                    int64                  `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	NewInfections          int64                  `protobuf:"varint,2,opt,name=new_infections,json=newInfections,proto3" json:"new_infections,omitempty"`
+	NewHospitalizations    int64                  `protobuf:"varint,3,opt,name=new_hospitalizations,json=newHospitalizations,proto3" json:"new_hospitalizations,omitempty"`
+	NewRecoveries          int64                  `protobuf:"varint,4,opt,name=new_recoveries,json=newRecoveries,proto3" json:"new_recoveries,omitempty"`
+	NewDeaths              int64                  `protobuf:"varint,5,opt,name=new_deaths,json=newDeaths,proto3" json:"new_deaths,omitempty"`
+	InfectedPopulation     int64                  `protobuf:"varint,6,opt,name=infected_population,json=infectedPopulation,proto3" json:"infected_population,omitempty"`
+	InfectiousPopulation   int64                  `protobuf:"varint,7,opt,name=infectious_population,json=infectiousPopulation,proto3" json:"infectious_population,omitempty"`
+	HospitalizedPopulation int64                  `protobuf:"varint,8,opt,name=hospitalized_population,json=hospitalizedPopulation,proto3" json:"hospitalized_population,omitempty"`
+	ImmunePopulation       int64                  `protobuf:"varint,9,opt,name=immune_population,json=immunePopulation,proto3" json:"immune_population,omitempty"`
+	DeadPopulation         int64                  `protobuf:"varint,10,opt,name=dead_population,json=deadPopulation,proto3" json:"dead_population,omitempty"`
+	NewReinfections        int64                  `protobuf:"varint,19,opt,name=new_reinfections,json=newReinfections,proto3" json:"new_reinfections,omitempty"` // the new infections of agents that had been infected before
+	// space surveillance metrics
+	NewTests           int64 `protobuf:"varint,11,opt,name=new_tests,json=newTests,proto3" json:"new_tests,omitempty"`
+	NewPositiveTests   int64 `protobuf:"varint,12,opt,name=new_positive_tests,json=newPositiveTests,proto3" json:"new_positive_tests,omitempty"`
+	TotalTests         int64 `protobuf:"varint,13,opt,name=total_tests,json=totalTests,proto3" json:"total_tests,omitempty"`
+	TotalPositiveTests int64 `protobuf:"varint,14,opt,name=total_positive_tests,json=totalPositiveTests,proto3" json:"total_positive_tests,omitempty"`
+	TestBacklog        int64 `protobuf:"varint,15,opt,name=test_backlog,json=testBacklog,proto3" json:"test_backlog,omitempty"`
+	TestCapacity       int64 `protobuf:"varint,16,opt,name=test_capacity,json=testCapacity,proto3" json:"test_capacity,omitempty"`
+	// cases attributed to the agent's home jurisdiction
+	NewCases   int64 `protobuf:"varint,17,opt,name=new_cases,json=newCases,proto3" json:"new_cases,omitempty"`
+	TotalCases int64 `protobuf:"varint,18,opt,name=total_cases,json=totalCases,proto3" json:"total_cases,omitempty"`
+	// agents turned away by the spaces of the jurisdiction
+	TurnedAway int64 `protobuf:"varint,20,opt,name=turned_away,json=turnedAway,proto3" json:"turned_away,omitempty"`
+	// fraction of agents that comply with the policy
+	ComplianceRate float64 `protobuf:"fixed64,21,opt,name=compliance_rate,json=complianceRate,proto3" json:"compliance_rate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Metrics) Reset() {
+	*x = Metrics{}
+	mi := &file_simulation_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics) ProtoMessage() {}
+
+func (x *Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Metrics) GetDay() int64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewInfections() int64 {
+	if x != nil {
+		return x.NewInfections
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewHospitalizations() int64 {
+	if x != nil {
+		return x.NewHospitalizations
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewRecoveries() int64 {
+	if x != nil {
+		return x.NewRecoveries
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewDeaths() int64 {
+	if x != nil {
+		return x.NewDeaths
+	}
+	return 0
+}
+
+func (x *Metrics) GetInfectedPopulation() int64 {
+	if x != nil {
+		return x.InfectedPopulation
+	}
+	return 0
+}
+
+func (x *Metrics) GetInfectiousPopulation() int64 {
+	if x != nil {
+		return x.InfectiousPopulation
+	}
+	return 0
+}
+
+func (x *Metrics) GetHospitalizedPopulation() int64 {
+	if x != nil {
+		return x.HospitalizedPopulation
+	}
+	return 0
+}
+
+func (x *Metrics) GetImmunePopulation() int64 {
+	if x != nil {
+		return x.ImmunePopulation
+	}
+	return 0
+}
+
+func (x *Metrics) GetDeadPopulation() int64 {
+	if x != nil {
+		return x.DeadPopulation
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewReinfections() int64 {
+	if x != nil {
+		return x.NewReinfections
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewTests() int64 {
+	if x != nil {
+		return x.NewTests
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewPositiveTests() int64 {
+	if x != nil {
+		return x.NewPositiveTests
+	}
+	return 0
+}
+
+func (x *Metrics) GetTotalTests() int64 {
+	if x != nil {
+		return x.TotalTests
+	}
+	return 0
+}
+
+func (x *Metrics) GetTotalPositiveTests() int64 {
+	if x != nil {
+		return x.TotalPositiveTests
+	}
+	return 0
+}
+
+func (x *Metrics) GetTestBacklog() int64 {
+	if x != nil {
+		return x.TestBacklog
+	}
+	return 0
+}
+
+func (x *Metrics) GetTestCapacity() int64 {
+	if x != nil {
+		return x.TestCapacity
+	}
+	return 0
+}
+
+func (x *Metrics) GetNewCases() int64 {
+	if x != nil {
+		return x.NewCases
+	}
+	return 0
+}
+
+func (x *Metrics) GetTotalCases() int64 {
+	if x != nil {
+		return x.TotalCases
+	}
+	return 0
+}
+
+func (x *Metrics) GetTurnedAway() int64 {
+	if x != nil {
+		return x.TurnedAway
+	}
+	return 0
+}
+
+func (x *Metrics) GetComplianceRate() float64 {
+	if x != nil {
+		return x.ComplianceRate
+	}
+	return 0
+}
+
+type SpaceOccupancyUpdatePayload_Occupant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceOccupancyUpdatePayload_Occupant) Reset() {
+	*x = SpaceOccupancyUpdatePayload_Occupant{}
+	mi := &file_simulation_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceOccupancyUpdatePayload_Occupant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceOccupancyUpdatePayload_Occupant) ProtoMessage() {}
+
+func (x *SpaceOccupancyUpdatePayload_Occupant) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceOccupancyUpdatePayload_Occupant.ProtoReflect.Descriptor instead.
+func (*SpaceOccupancyUpdatePayload_Occupant) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{31, 0}
+}
+
+func (x *SpaceOccupancyUpdatePayload_Occupant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SpaceOccupancyUpdatePayload_Occupant) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+var File_simulation_proto protoreflect.FileDescriptor
+
+const file_simulation_proto_rawDesc = "" +
+	"\n" +
+	"\x10simulation.proto\x12\n" +
+	"simulation\x1a\x1fgoogle/protobuf/timestamp.proto\"U\n" +
+	"\x17StartSimulationResponse\x12\x15\n" +
+	"\x06api_id\x18\x01 \x01(\tR\x05apiId\x12#\n" +
+	"\rsimulation_id\x18\x02 \x01(\tR\fsimulationId\"h\n" +
+	"\x12SendCommandRequest\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12-\n" +
+	"\acommand\x18\x02 \x01(\v2\x13.simulation.CommandR\acommand\"N\n" +
+	"\x10SubscribeRequest\x12\x15\n" +
+	"\x06api_id\x18\x01 \x01(\tR\x05apiId\x12#\n" +
+	"\rsimulation_id\x18\x02 \x01(\tR\fsimulationId\"\x849\n" +
+	"\x06Config\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06preset\x18: \x01(\tR\x06preset\x12 \n" +
+	"\ttime_step\x18\x02 \x01(\x03H\x00R\btimeStep\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"num_agents\x18\x03 \x01(\x03H\x01R\tnumAgents\x88\x01\x01\x12\x1e\n" +
+	"\bmax_days\x18\x04 \x01(\x03H\x02R\amaxDays\x88\x01\x01\x12:\n" +
+	"\x17stop_when_no_infections\x18\x05 \x01(\bH\x03R\x14stopWhenNoInfections\x88\x01\x01\x123\n" +
+	"\x15notification_encoding\x187 \x01(\tR\x14notificationEncoding\x12&\n" +
+	"\fbatch_events\x188 \x01(\bH\x04R\vbatchEvents\x88\x01\x01\x12@\n" +
+	"\fagent_stream\x189 \x01(\v2\x1d.simulation.AgentStreamConfigR\vagentStream\x12:\n" +
+	"\x16compliance_probability\x18\x06 \x01(\x01H\x05R\x15complianceProbability\x88\x01\x01\x12C\n" +
+	"\x1bseeks_treatment_probability\x18\a \x01(\x01H\x06R\x19seeksTreatmentProbability\x88\x01\x01\x12J\n" +
+	"\x1fmask_filtration_efficiency_mean\x18\b \x01(\x01H\aR\x1cmaskFiltrationEfficiencyMean\x88\x01\x01\x12F\n" +
+	"\x1dmask_filtration_efficiency_sd\x18\t \x01(\x01H\bR\x1amaskFiltrationEfficiencySd\x88\x01\x01\x12J\n" +
+	"\x1fpulmonary_ventilation_rate_mean\x18\n" +
+	" \x01(\x01H\tR\x1cpulmonaryVentilationRateMean\x88\x01\x01\x12F\n" +
+	"\x1dpulmonary_ventilation_rate_sd\x18\v \x01(\x01H\n" +
+	"R\x1apulmonaryVentilationRateSd\x88\x01\x01\x129\n" +
+	"\x16key_worker_probability\x18R \x01(\x01H\vR\x14keyWorkerProbability\x88\x01\x01\x12?\n" +
+	"\x19remote_worker_probability\x18S \x01(\x01H\fR\x17remoteWorkerProbability\x88\x01\x01\x122\n" +
+	"\x12compliance_fatigue\x18T \x01(\x01H\rR\x11complianceFatigue\x88\x01\x01\x12,\n" +
+	"\x0frisk_perception\x18U \x01(\x01H\x0eR\x0eriskPerception\x88\x01\x01\x12$\n" +
+	"\vrisk_memory\x18V \x01(\x01H\x0fR\n" +
+	"riskMemory\x88\x01\x01\x129\n" +
+	"\x16incubation_period_mean\x18\f \x01(\x01H\x10R\x14incubationPeriodMean\x88\x01\x01\x125\n" +
+	"\x14incubation_period_sd\x18\r \x01(\x01H\x11R\x12incubationPeriodSd\x88\x01\x01\x125\n" +
+	"\x14recovery_period_mean\x18\x0e \x01(\x01H\x12R\x12recoveryPeriodMean\x88\x01\x01\x121\n" +
+	"\x12recovery_period_sd\x18\x0f \x01(\x01H\x13R\x10recoveryPeriodSd\x88\x01\x01\x125\n" +
+	"\x14immunity_period_mean\x18\x10 \x01(\x01H\x14R\x12immunityPeriodMean\x88\x01\x01\x121\n" +
+	"\x12immunity_period_sd\x18\x11 \x01(\x01H\x15R\x10immunityPeriodSd\x88\x01\x01\x12I\n" +
+	"\x1eprehospitalization_period_mean\x18\x12 \x01(\x01H\x16R\x1cprehospitalizationPeriodMean\x88\x01\x01\x12E\n" +
+	"\x1cprehospitalization_period_sd\x18\x13 \x01(\x01H\x17R\x1aprehospitalizationPeriodSd\x88\x01\x01\x12C\n" +
+	"\x1bhospitalization_period_mean\x18\x14 \x01(\x01H\x18R\x19hospitalizationPeriodMean\x88\x01\x01\x12?\n" +
+	"\x19hospitalization_period_sd\x18\x15 \x01(\x01H\x19R\x17hospitalizationPeriodSd\x88\x01\x01\x12>\n" +
+	"\x19quanta_emission_rate_mean\x18\x16 \x01(\x01H\x1aR\x16quantaEmissionRateMean\x88\x01\x01\x12:\n" +
+	"\x17quanta_emission_rate_sd\x18\x17 \x01(\x01H\x1bR\x14quantaEmissionRateSd\x88\x01\x01\x12D\n" +
+	"\x1bhospitalization_probability\x18\x18 \x01(\x01H\x1cR\x1ahospitalizationProbability\x88\x01\x01\x120\n" +
+	"\x11death_probability\x18\x19 \x01(\x01H\x1dR\x10deathProbability\x88\x01\x01\x12>\n" +
+	"\x18asymptomatic_probability\x18\x1a \x01(\x01H\x1eR\x17asymptomaticProbability\x88\x01\x01\x12R\n" +
+	"\x14infectiousness_curve\x18< \x03(\v2\x1f.simulation.InfectiousnessPointR\x13infectiousnessCurve\x12D\n" +
+	"\x1basymptomatic_infectiousness\x18= \x01(\x01H\x1fR\x1aasymptomaticInfectiousness\x88\x01\x01\x124\n" +
+	"\x13immunity_protection\x18> \x01(\x01H R\x12immunityProtection\x88\x01\x01\x124\n" +
+	"\x13severity_protection\x18? \x01(\x01H!R\x12severityProtection\x88\x01\x01\x12-\n" +
+	"\x10viral_decay_rate\x18@ \x01(\x01H\"R\x0eviralDecayRate\x88\x01\x01\x12,\n" +
+	"\x0fdeposition_rate\x18A \x01(\x01H#R\x0edepositionRate\x88\x01\x01\x12,\n" +
+	"\x0ffiltration_rate\x18B \x01(\x01H$R\x0efiltrationRate\x88\x01\x01\x12;\n" +
+	"\x17household_capacity_mean\x18\x1b \x01(\x01H%R\x15householdCapacityMean\x88\x01\x01\x127\n" +
+	"\x15household_capacity_sd\x18\x1c \x01(\x01H&R\x13householdCapacitySd\x88\x01\x01\x12G\n" +
+	"\x1ehousehold_air_change_rate_mean\x18\x1d \x01(\x01H'R\x1ahouseholdAirChangeRateMean\x88\x01\x01\x12C\n" +
+	"\x1chousehold_air_change_rate_sd\x18\x1e \x01(\x01H(R\x18householdAirChangeRateSd\x88\x01\x01\x127\n" +
+	"\x15household_volume_mean\x18\x1f \x01(\x01H)R\x13householdVolumeMean\x88\x01\x01\x123\n" +
+	"\x13household_volume_sd\x18  \x01(\x01H*R\x11householdVolumeSd\x88\x01\x01\x125\n" +
+	"\x14office_capacity_mean\x18! \x01(\x01H+R\x12officeCapacityMean\x88\x01\x01\x121\n" +
+	"\x12office_capacity_sd\x18\" \x01(\x01H,R\x10officeCapacitySd\x88\x01\x01\x12A\n" +
+	"\x1boffice_air_change_rate_mean\x18# \x01(\x01H-R\x17officeAirChangeRateMean\x88\x01\x01\x12=\n" +
+	"\x19office_air_change_rate_sd\x18$ \x01(\x01H.R\x15officeAirChangeRateSd\x88\x01\x01\x121\n" +
+	"\x12office_volume_mean\x18% \x01(\x01H/R\x10officeVolumeMean\x88\x01\x01\x12-\n" +
+	"\x10office_volume_sd\x18& \x01(\x01H0R\x0eofficeVolumeSd\x88\x01\x01\x12@\n" +
+	"\x1asocial_space_capacity_mean\x18' \x01(\x01H1R\x17socialSpaceCapacityMean\x88\x01\x01\x12<\n" +
+	"\x18social_space_capacity_sd\x18( \x01(\x01H2R\x15socialSpaceCapacitySd\x88\x01\x01\x12L\n" +
+	"!social_space_air_change_rate_mean\x18) \x01(\x01H3R\x1csocialSpaceAirChangeRateMean\x88\x01\x01\x12H\n" +
+	"\x1fsocial_space_air_change_rate_sd\x18* \x01(\x01H4R\x1asocialSpaceAirChangeRateSd\x88\x01\x01\x12<\n" +
+	"\x18social_space_volume_mean\x18+ \x01(\x01H5R\x15socialSpaceVolumeMean\x88\x01\x01\x128\n" +
+	"\x16social_space_volume_sd\x18, \x01(\x01H6R\x13socialSpaceVolumeSd\x88\x01\x01\x12H\n" +
+	"\x1ehealthcare_space_capacity_mean\x18- \x01(\x01H7R\x1bhealthcareSpaceCapacityMean\x88\x01\x01\x12D\n" +
+	"\x1chealthcare_space_capacity_sd\x18. \x01(\x01H8R\x19healthcareSpaceCapacitySd\x88\x01\x01\x12T\n" +
+	"%healthcare_space_air_change_rate_mean\x18/ \x01(\x01H9R healthcareSpaceAirChangeRateMean\x88\x01\x01\x12P\n" +
+	"#healthcare_space_air_change_rate_sd\x180 \x01(\x01H:R\x1ehealthcareSpaceAirChangeRateSd\x88\x01\x01\x12D\n" +
+	"\x1chealthcare_space_volume_mean\x181 \x01(\x01H;R\x19healthcareSpaceVolumeMean\x88\x01\x01\x12@\n" +
+	"\x1ahealthcare_space_volume_sd\x182 \x01(\x01H<R\x17healthcareSpaceVolumeSd\x88\x01\x01\x121\n" +
+	"\x12test_capacity_mean\x183 \x01(\x01H=R\x10testCapacityMean\x88\x01\x01\x12-\n" +
+	"\x10test_capacity_sd\x184 \x01(\x01H>R\x0etestCapacitySd\x88\x01\x01\x12.\n" +
+	"\x10test_sensitivity\x185 \x01(\x01H?R\x0ftestSensitivity\x88\x01\x01\x12.\n" +
+	"\x10test_specificity\x186 \x01(\x01H@R\x0ftestSpecificity\x88\x01\x01\x127\n" +
+	"\x15transit_capacity_mean\x18C \x01(\x01HAR\x13transitCapacityMean\x88\x01\x01\x123\n" +
+	"\x13transit_capacity_sd\x18D \x01(\x01HBR\x11transitCapacitySd\x88\x01\x01\x12C\n" +
+	"\x1ctransit_air_change_rate_mean\x18E \x01(\x01HCR\x18transitAirChangeRateMean\x88\x01\x01\x12?\n" +
+	"\x1atransit_air_change_rate_sd\x18F \x01(\x01HDR\x16transitAirChangeRateSd\x88\x01\x01\x123\n" +
+	"\x13transit_volume_mean\x18G \x01(\x01HER\x11transitVolumeMean\x88\x01\x01\x12/\n" +
+	"\x11transit_volume_sd\x18H \x01(\x01HFR\x0ftransitVolumeSd\x88\x01\x01\x12(\n" +
+	"\rtransit_speed\x18I \x01(\x01HGR\ftransitSpeed\x88\x01\x01\x12/\n" +
+	"\x11transit_wait_time\x18J \x01(\x01HHR\x0ftransitWaitTime\x88\x01\x01\x127\n" +
+	"\x15outdoor_capacity_mean\x18K \x01(\x01HIR\x13outdoorCapacityMean\x88\x01\x01\x123\n" +
+	"\x13outdoor_capacity_sd\x18L \x01(\x01HJR\x11outdoorCapacitySd\x88\x01\x01\x12C\n" +
+	"\x1coutdoor_air_change_rate_mean\x18M \x01(\x01HKR\x18outdoorAirChangeRateMean\x88\x01\x01\x12?\n" +
+	"\x1aoutdoor_air_change_rate_sd\x18N \x01(\x01HLR\x16outdoorAirChangeRateSd\x88\x01\x01\x123\n" +
+	"\x13outdoor_volume_mean\x18O \x01(\x01HMR\x11outdoorVolumeMean\x88\x01\x01\x12/\n" +
+	"\x11outdoor_volume_sd\x18P \x01(\x01HNR\x0foutdoorVolumeSd\x88\x01\x01\x12?\n" +
+	"\x19outdoor_visit_probability\x18Q \x01(\x01HOR\x17outdoorVisitProbability\x88\x01\x01\x12K\n" +
+	"\rdistributions\x18; \x03(\v2%.simulation.Config.DistributionsEntryR\rdistributions\x1aZ\n" +
+	"\x12DistributionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.simulation.DistributionR\x05value:\x028\x01B\f\n" +
+	"\n" +
+	"_time_stepB\r\n" +
+	"\v_num_agentsB\v\n" +
+	"\t_max_daysB\x1a\n" +
+	"\x18_stop_when_no_infectionsB\x0f\n" +
+	"\r_batch_eventsB\x19\n" +
+	"\x17_compliance_probabilityB\x1e\n" +
+	"\x1c_seeks_treatment_probabilityB\"\n" +
+	" _mask_filtration_efficiency_meanB \n" +
+	"\x1e_mask_filtration_efficiency_sdB\"\n" +
+	" _pulmonary_ventilation_rate_meanB \n" +
+	"\x1e_pulmonary_ventilation_rate_sdB\x19\n" +
+	"\x17_key_worker_probabilityB\x1c\n" +
+	"\x1a_remote_worker_probabilityB\x15\n" +
+	"\x13_compliance_fatigueB\x12\n" +
+	"\x10_risk_perceptionB\x0e\n" +
+	"\f_risk_memoryB\x19\n" +
+	"\x17_incubation_period_meanB\x17\n" +
+	"\x15_incubation_period_sdB\x17\n" +
+	"\x15_recovery_period_meanB\x15\n" +
+	"\x13_recovery_period_sdB\x17\n" +
+	"\x15_immunity_period_meanB\x15\n" +
+	"\x13_immunity_period_sdB!\n" +
+	"\x1f_prehospitalization_period_meanB\x1f\n" +
+	"\x1d_prehospitalization_period_sdB\x1e\n" +
+	"\x1c_hospitalization_period_meanB\x1c\n" +
+	"\x1a_hospitalization_period_sdB\x1c\n" +
+	"\x1a_quanta_emission_rate_meanB\x1a\n" +
+	"\x18_quanta_emission_rate_sdB\x1e\n" +
+	"\x1c_hospitalization_probabilityB\x14\n" +
+	"\x12_death_probabilityB\x1b\n" +
+	"\x19_asymptomatic_probabilityB\x1e\n" +
+	"\x1c_asymptomatic_infectiousnessB\x16\n" +
+	"\x14_immunity_protectionB\x16\n" +
+	"\x14_severity_protectionB\x13\n" +
+	"\x11_viral_decay_rateB\x12\n" +
+	"\x10_deposition_rateB\x12\n" +
+	"\x10_filtration_rateB\x1a\n" +
+	"\x18_household_capacity_meanB\x18\n" +
+	"\x16_household_capacity_sdB!\n" +
+	"\x1f_household_air_change_rate_meanB\x1f\n" +
+	"\x1d_household_air_change_rate_sdB\x18\n" +
+	"\x16_household_volume_meanB\x16\n" +
+	"\x14_household_volume_sdB\x17\n" +
+	"\x15_office_capacity_meanB\x15\n" +
+	"\x13_office_capacity_sdB\x1e\n" +
+	"\x1c_office_air_change_rate_meanB\x1c\n" +
+	"\x1a_office_air_change_rate_sdB\x15\n" +
+	"\x13_office_volume_meanB\x13\n" +
+	"\x11_office_volume_sdB\x1d\n" +
+	"\x1b_social_space_capacity_meanB\x1b\n" +
+	"\x19_social_space_capacity_sdB$\n" +
+	"\"_social_space_air_change_rate_meanB\"\n" +
+	" _social_space_air_change_rate_sdB\x1b\n" +
+	"\x19_social_space_volume_meanB\x19\n" +
+	"\x17_social_space_volume_sdB!\n" +
+	"\x1f_healthcare_space_capacity_meanB\x1f\n" +
+	"\x1d_healthcare_space_capacity_sdB(\n" +
+	"&_healthcare_space_air_change_rate_meanB&\n" +
+	"$_healthcare_space_air_change_rate_sdB\x1f\n" +
+	"\x1d_healthcare_space_volume_meanB\x1d\n" +
+	"\x1b_healthcare_space_volume_sdB\x15\n" +
+	"\x13_test_capacity_meanB\x13\n" +
+	"\x11_test_capacity_sdB\x13\n" +
+	"\x11_test_sensitivityB\x13\n" +
+	"\x11_test_specificityB\x18\n" +
+	"\x16_transit_capacity_meanB\x16\n" +
+	"\x14_transit_capacity_sdB\x1f\n" +
+	"\x1d_transit_air_change_rate_meanB\x1d\n" +
+	"\x1b_transit_air_change_rate_sdB\x16\n" +
+	"\x14_transit_volume_meanB\x14\n" +
+	"\x12_transit_volume_sdB\x10\n" +
+	"\x0e_transit_speedB\x14\n" +
+	"\x12_transit_wait_timeB\x18\n" +
+	"\x16_outdoor_capacity_meanB\x16\n" +
+	"\x14_outdoor_capacity_sdB\x1f\n" +
+	"\x1d_outdoor_air_change_rate_meanB\x1d\n" +
+	"\x1b_outdoor_air_change_rate_sdB\x16\n" +
+	"\x14_outdoor_volume_meanB\x14\n" +
+	"\x12_outdoor_volume_sdB\x1c\n" +
+	"\x1a_outdoor_visit_probability\"?\n" +
+	"\x13InfectiousnessPoint\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x01R\x04time\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x01R\x05level\"\xac\x01\n" +
+	"\fDistribution\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04mean\x18\x02 \x01(\x01R\x04mean\x12\x0e\n" +
+	"\x02sd\x18\x03 \x01(\x01R\x02sd\x12\x14\n" +
+	"\x05shape\x18\x04 \x01(\x01R\x05shape\x12\x14\n" +
+	"\x05scale\x18\x05 \x01(\x01R\x05scale\x12\x10\n" +
+	"\x03min\x18\x06 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\a \x01(\x01R\x03max\x12\x14\n" +
+	"\x05value\x18\b \x01(\x01R\x05value\"\xd0\b\n" +
+	"\x06Policy\x12&\n" +
+	"\x0fis_mask_mandate\x18\x01 \x01(\bR\risMaskMandate\x129\n" +
+	"\x19is_self_isolation_mandate\x18\x02 \x01(\bR\x16isSelfIsolationMandate\x129\n" +
+	"\x19is_self_reporting_mandate\x18\x03 \x01(\bR\x16isSelfReportingMandate\x12\x1f\n" +
+	"\vis_lockdown\x18\x04 \x01(\bR\n" +
+	"isLockdown\x12#\n" +
+	"\rtest_strategy\x18\x05 \x01(\tR\ftestStrategy\x128\n" +
+	"\x18test_capacity_multiplier\x18\x06 \x01(\x01R\x16testCapacityMultiplier\x125\n" +
+	"\x16compliance_probability\x18\a \x01(\x01R\x15complianceProbability\x12g\n" +
+	"\x17ventilation_improvement\x18\b \x03(\v2..simulation.Policy.VentilationImprovementEntryR\x16ventilationImprovement\x125\n" +
+	"\x17is_transit_mask_mandate\x18\t \x01(\bR\x14isTransitMaskMandate\x125\n" +
+	"\x14transit_capacity_cap\x18\n" +
+	" \x01(\x01H\x00R\x12transitCapacityCap\x88\x01\x01\x125\n" +
+	"\x14outdoor_capacity_cap\x18\v \x01(\x01H\x01R\x12outdoorCapacityCap\x88\x01\x01\x121\n" +
+	"\x12venue_capacity_cap\x18\f \x01(\x01H\x02R\x10venueCapacityCap\x88\x01\x01\x12I\n" +
+	"\rclosed_spaces\x18\r \x03(\v2$.simulation.Policy.ClosedSpacesEntryR\fclosedSpaces\x128\n" +
+	"\x19is_work_from_home_mandate\x18\x0e \x01(\bR\x15isWorkFromHomeMandate\x12-\n" +
+	"\x12compliance_fatigue\x18\x0f \x01(\x01R\x11complianceFatigue\x12'\n" +
+	"\x0frisk_perception\x18\x10 \x01(\x01R\x0eriskPerception\x1aI\n" +
+	"\x1bVentilationImprovementEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a?\n" +
+	"\x11ClosedSpacesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01B\x17\n" +
+	"\x15_transit_capacity_capB\x17\n" +
+	"\x15_outdoor_capacity_capB\x15\n" +
+	"\x13_venue_capacity_cap\"d\n" +
+	"\fJurisdiction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x06policy\x18\x02 \x01(\v2\x12.simulation.PolicyR\x06policy\x12\x18\n" +
+	"\afeature\x18\x03 \x01(\fR\afeature\"\xa8\x04\n" +
+	"\aCommand\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\b \x01(\x05R\aversion\x12V\n" +
+	"\x13apply_policy_update\x18\x02 \x01(\v2$.simulation.ApplyPolicyUpdatePayloadH\x00R\x11applyPolicyUpdate\x12:\n" +
+	"\tset_speed\x18\x03 \x01(\v2\x1b.simulation.SetSpeedPayloadH\x00R\bsetSpeed\x12-\n" +
+	"\x04step\x18\x04 \x01(\v2\x17.simulation.StepPayloadH\x00R\x04step\x12:\n" +
+	"\trun_until\x18\x05 \x01(\v2\x1b.simulation.RunUntilPayloadH\x00R\brunUntil\x12U\n" +
+	"\x12query_jurisdiction\x18\x06 \x01(\v2$.simulation.QueryJurisdictionPayloadH\x00R\x11queryJurisdiction\x12C\n" +
+	"\fquery_entity\x18\a \x01(\v2\x1e.simulation.QueryEntityPayloadH\x00R\vqueryEntity\x12I\n" +
+	"\x10set_agent_stream\x18\t \x01(\v2\x1d.simulation.AgentStreamConfigH\x00R\x0esetAgentStreamB\t\n" +
+	"\apayload\"\xf5\v\n" +
+	"\x18ApplyPolicyUpdatePayload\x12'\n" +
+	"\x0fjurisdiction_id\x18\x01 \x01(\tR\x0ejurisdictionId\x12+\n" +
+	"\x0fis_mask_mandate\x18\x02 \x01(\bH\x00R\risMaskMandate\x88\x01\x01\x12>\n" +
+	"\x19is_self_isolation_mandate\x18\x03 \x01(\bH\x01R\x16isSelfIsolationMandate\x88\x01\x01\x12>\n" +
+	"\x19is_self_reporting_mandate\x18\x04 \x01(\bH\x02R\x16isSelfReportingMandate\x88\x01\x01\x12$\n" +
+	"\vis_lockdown\x18\x05 \x01(\bH\x03R\n" +
+	"isLockdown\x88\x01\x01\x12(\n" +
+	"\rtest_strategy\x18\x06 \x01(\tH\x04R\ftestStrategy\x88\x01\x01\x12=\n" +
+	"\x18test_capacity_multiplier\x18\a \x01(\x01H\x05R\x16testCapacityMultiplier\x88\x01\x01\x12:\n" +
+	"\x16compliance_probability\x18\b \x01(\x01H\x06R\x15complianceProbability\x88\x01\x01\x12y\n" +
+	"\x17ventilation_improvement\x18\t \x03(\v2@.simulation.ApplyPolicyUpdatePayload.VentilationImprovementEntryR\x16ventilationImprovement\x12:\n" +
+	"\x17is_transit_mask_mandate\x18\n" +
+	" \x01(\bH\aR\x14isTransitMaskMandate\x88\x01\x01\x125\n" +
+	"\x14transit_capacity_cap\x18\v \x01(\x01H\bR\x12transitCapacityCap\x88\x01\x01\x125\n" +
+	"\x14outdoor_capacity_cap\x18\f \x01(\x01H\tR\x12outdoorCapacityCap\x88\x01\x01\x121\n" +
+	"\x12venue_capacity_cap\x18\r \x01(\x01H\n" +
+	"R\x10venueCapacityCap\x88\x01\x01\x12[\n" +
+	"\rclosed_spaces\x18\x0e \x03(\v26.simulation.ApplyPolicyUpdatePayload.ClosedSpacesEntryR\fclosedSpaces\x12=\n" +
+	"\x19is_work_from_home_mandate\x18\x0f \x01(\bH\vR\x15isWorkFromHomeMandate\x88\x01\x01\x122\n" +
+	"\x12compliance_fatigue\x18\x10 \x01(\x01H\fR\x11complianceFatigue\x88\x01\x01\x12,\n" +
+	"\x0frisk_perception\x18\x11 \x01(\x01H\rR\x0eriskPerception\x88\x01\x01\x1aI\n" +
+	"\x1bVentilationImprovementEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a?\n" +
+	"\x11ClosedSpacesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01B\x12\n" +
+	"\x10_is_mask_mandateB\x1c\n" +
+	"\x1a_is_self_isolation_mandateB\x1c\n" +
+	"\x1a_is_self_reporting_mandateB\x0e\n" +
+	"\f_is_lockdownB\x10\n" +
+	"\x0e_test_strategyB\x1b\n" +
+	"\x19_test_capacity_multiplierB\x19\n" +
+	"\x17_compliance_probabilityB\x1a\n" +
+	"\x18_is_transit_mask_mandateB\x17\n" +
+	"\x15_transit_capacity_capB\x17\n" +
+	"\x15_outdoor_capacity_capB\x15\n" +
+	"\x13_venue_capacity_capB\x1c\n" +
+	"\x1a_is_work_from_home_mandateB\x15\n" +
+	"\x13_compliance_fatigueB\x12\n" +
+	"\x10_risk_perception\"'\n" +
+	"\x0fSetSpeedPayload\x12\x14\n" +
+	"\x05speed\x18\x01 \x01(\x01R\x05speed\"%\n" +
+	"\vStepPayload\x12\x16\n" +
+	"\x06epochs\x18\x01 \x01(\x03R\x06epochs\"#\n" +
+	"\x0fRunUntilPayload\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x03R\x03day\"\xa6\x01\n" +
+	"\x11AgentStreamConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1f\n" +
+	"\vsample_rate\x18\x02 \x01(\x01R\n" +
+	"sampleRate\x12'\n" +
+	"\x0fjurisdiction_id\x18\x03 \x01(\tR\x0ejurisdictionId\x12-\n" +
+	"\x13max_events_per_hour\x18\x04 \x01(\x03R\x10maxEventsPerHour\"C\n" +
+	"\x18QueryJurisdictionPayload\x12'\n" +
+	"\x0fjurisdiction_id\x18\x01 \x01(\tR\x0ejurisdictionId\"$\n" +
+	"\x12QueryEntityPayload\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe0\x03\n" +
+	"\x0fCommandResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x129\n" +
+	"\x06policy\x18\x03 \x01(\v2\x1f.simulation.PolicyUpdatePayloadH\x00R\x06policy\x129\n" +
+	"\x06budget\x18\x04 \x01(\v2\x1f.simulation.BudgetUpdatePayloadH\x00R\x06budget\x121\n" +
+	"\x04time\x18\x05 \x01(\v2\x1b.simulation.EpochEndPayloadH\x00R\x04time\x12C\n" +
+	"\n" +
+	"population\x18\x06 \x01(\v2!.simulation.PopulationQueryResultH\x00R\n" +
+	"population\x124\n" +
+	"\x05space\x18\a \x01(\v2\x1c.simulation.SpaceQueryResultH\x00R\x05space\x124\n" +
+	"\x05agent\x18\b \x01(\v2\x1c.simulation.AgentQueryResultH\x00R\x05agent\x12>\n" +
+	"\vsimulations\x18\t \x01(\v2\x1a.simulation.SimulationListH\x00R\vsimulationsB\t\n" +
+	"\apayload\"\xd8\x01\n" +
+	"\x15PopulationQueryResult\x12'\n" +
+	"\x0fjurisdiction_id\x18\x01 \x01(\tR\x0ejurisdictionId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12E\n" +
+	"\x06counts\x18\x03 \x03(\v2-.simulation.PopulationQueryResult.CountsEntryR\x06counts\x1a9\n" +
+	"\vCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xf3\x01\n" +
+	"\x10SpaceQueryResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12'\n" +
+	"\x0fjurisdiction_id\x18\x03 \x01(\tR\x0ejurisdictionId\x12\x16\n" +
+	"\x06volume\x18\x04 \x01(\x01R\x06volume\x12&\n" +
+	"\x0fair_change_rate\x18\x05 \x01(\x01R\rairChangeRate\x124\n" +
+	"\x16total_infectious_doses\x18\x06 \x01(\x01R\x14totalInfectiousDoses\x12\x1c\n" +
+	"\toccupants\x18\a \x03(\tR\toccupants\"\xbe\x03\n" +
+	"\x10AgentQueryResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12,\n" +
+	"\x12state_change_epoch\x18\x03 \x01(\x03R\x10stateChangeEpoch\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\tR\n" +
+	"locationId\x12#\n" +
+	"\rlocation_type\x18\x05 \x01(\tR\flocationType\x12!\n" +
+	"\fhousehold_id\x18\x06 \x01(\tR\vhouseholdId\x12\x1b\n" +
+	"\toffice_id\x18\a \x01(\tR\bofficeId\x12'\n" +
+	"\x0fjurisdiction_id\x18\b \x01(\tR\x0ejurisdictionId\x122\n" +
+	"\x15has_infection_profile\x18\t \x01(\bR\x13hasInfectionProfile\x12*\n" +
+	"\x11has_self_reported\x18\n" +
+	" \x01(\bR\x0fhasSelfReported\x12'\n" +
+	"\x0finfection_count\x18\v \x01(\x03R\x0einfectionCount\x12\x1e\n" +
+	"\n" +
+	"occupation\x18\f \x01(\tR\n" +
+	"occupation\"N\n" +
+	"\x0eSimulationList\x12<\n" +
+	"\vsimulations\x18\x01 \x03(\v2\x1a.simulation.SimulationInfoR\vsimulations\"\xa1\x02\n" +
+	"\x0eSimulationInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06api_id\x18\x02 \x01(\tR\x05apiId\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x14\n" +
+	"\x05epoch\x18\x04 \x01(\x03R\x05epoch\x12\x1d\n" +
+	"\n" +
+	"num_agents\x18\x05 \x01(\x03R\tnumAgents\x12)\n" +
+	"\x10estimated_memory\x18\x06 \x01(\x03R\x0festimatedMemory\x127\n" +
+	"\tqueued_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bqueuedAt\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\"\x8f\f\n" +
+	"\x05Event\x12\x15\n" +
+	"\x06api_id\x18\x01 \x01(\tR\x05apiId\x12#\n" +
+	"\rsimulation_id\x18\x02 \x01(\tR\fsimulationId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12a\n" +
+	"\x16simulation_initialized\x18\x04 \x01(\v2(.simulation.SimulationInitializedPayloadH\x00R\x15simulationInitialized\x12b\n" +
+	"\x17simulation_state_update\x18\x05 \x01(\v2(.simulation.SimulationStateUpdatePayloadH\x00R\x15simulationStateUpdate\x12O\n" +
+	"\x10simulation_ended\x18\x06 \x01(\v2\".simulation.SimulationEndedPayloadH\x00R\x0fsimulationEnded\x12:\n" +
+	"\tepoch_end\x18\a \x01(\v2\x1b.simulation.EpochEndPayloadH\x00R\bepochEnd\x12R\n" +
+	"\x11command_processed\x18\b \x01(\v2#.simulation.CommandProcessedPayloadH\x00R\x10commandProcessed\x12S\n" +
+	"\x12agent_state_update\x18\t \x01(\v2#.simulation.AgentStateUpdatePayloadH\x00R\x10agentStateUpdate\x12\\\n" +
+	"\x15agent_location_update\x18\n" +
+	" \x01(\v2&.simulation.AgentLocationUpdatePayloadH\x00R\x13agentLocationUpdate\x12_\n" +
+	"\x16space_occupancy_update\x18\v \x01(\v2'.simulation.SpaceOccupancyUpdatePayloadH\x00R\x14spaceOccupancyUpdate\x12Y\n" +
+	"\x14space_testing_update\x18\f \x01(\v2%.simulation.SpaceTestingUpdatePayloadH\x00R\x12spaceTestingUpdate\x12F\n" +
+	"\rpolicy_update\x18\r \x01(\v2\x1f.simulation.PolicyUpdatePayloadH\x00R\fpolicyUpdate\x12F\n" +
+	"\rbudget_update\x18\x0e \x01(\v2\x1f.simulation.BudgetUpdatePayloadH\x00R\fbudgetUpdate\x12F\n" +
+	"\rcase_detected\x18\x0f \x01(\v2\x1f.simulation.CaseDetectedPayloadH\x00R\fcaseDetected\x12<\n" +
+	"\tadmission\x18\x10 \x01(\v2\x1c.simulation.AdmissionPayloadH\x00R\tadmission\x12_\n" +
+	"\x16simulation_init_failed\x18\x12 \x01(\v2'.simulation.SimulationInitFailedPayloadH\x00R\x14simulationInitFailed\x12P\n" +
+	"\x11agent_turned_away\x18\x13 \x01(\v2\".simulation.AgentTurnedAwayPayloadH\x00R\x0fagentTurnedAway\x12]\n" +
+	"\x16agent_worked_from_home\x18\x14 \x01(\v2&.simulation.AgentWorkedFromHomePayloadH\x00R\x13agentWorkedFromHome\x12R\n" +
+	"\x11compliance_update\x18\x15 \x01(\v2#.simulation.ComplianceUpdatePayloadH\x00R\x10complianceUpdate\x12\x18\n" +
+	"\aversion\x18\x11 \x01(\x05R\aversionB\t\n" +
+	"\apayload\"\x8a\x01\n" +
+	"\x1cSimulationInitializedPayload\x12>\n" +
+	"\rjurisdictions\x18\x01 \x03(\v2\x18.simulation.JurisdictionR\rjurisdictions\x12*\n" +
+	"\x06config\x18\x02 \x01(\v2\x12.simulation.ConfigR\x06config\"Z\n" +
+	"\x1bSimulationInitFailedPayload\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x16\n" +
+	"\x06errors\x18\x02 \x03(\tR\x06errors\"\x89\x01\n" +
+	"\x1cSimulationStateUpdatePayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12%\n" +
+	"\x0eprevious_state\x18\x03 \x01(\tR\rpreviousState\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x8c\x01\n" +
+	"\x16SimulationEndedPayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"t\n" +
+	"\x0fEpochEndPayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x1b\n" +
+	"\ttime_step\x18\x02 \x01(\x03R\btimeStep\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"^\n" +
+	"\x17CommandProcessedPayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12-\n" +
+	"\acommand\x18\x02 \x01(\v2\x13.simulation.CommandR\acommand\"\xd9\x01\n" +
+	"\x17AgentStateUpdatePayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12%\n" +
+	"\x0eprevious_state\x18\x04 \x01(\tR\rpreviousState\x122\n" +
+	"\x15has_infection_profile\x18\x05 \x01(\bR\x13hasInfectionProfile\x12'\n" +
+	"\x0finfection_count\x18\x06 \x01(\x03R\x0einfectionCount\"\x95\x01\n" +
+	"\x1aAgentLocationUpdatePayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\tR\n" +
+	"locationId\x120\n" +
+	"\x14previous_location_id\x18\x04 \x01(\tR\x12previousLocationId\"\xf4\x01\n" +
+	"\x1bSpaceOccupancyUpdatePayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12N\n" +
+	"\toccupants\x18\x03 \x03(\v20.simulation.SpaceOccupancyUpdatePayload.OccupantR\toccupants\x12-\n" +
+	"\x12dose_concentration\x18\x04 \x01(\x01R\x11doseConcentration\x1a0\n" +
+	"\bOccupant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"\xa3\x01\n" +
+	"\x19SpaceTestingUpdatePayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x1c\n" +
+	"\tpositives\x18\x02 \x01(\x03R\tpositives\x12\x1c\n" +
+	"\tnegatives\x18\x03 \x01(\x03R\tnegatives\x12\x18\n" +
+	"\abacklog\x18\x04 \x01(\x03R\abacklog\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x03R\bcapacity\"j\n" +
+	"\x13PolicyUpdatePayload\x12'\n" +
+	"\x0fjurisdiction_id\x18\x01 \x01(\tR\x0ejurisdictionId\x12*\n" +
+	"\x06policy\x18\x02 \x01(\v2\x12.simulation.PolicyR\x06policy\"<\n" +
+	"\x13BudgetUpdatePayload\x12%\n" +
+	"\x0ecurrent_budget\x18\x01 \x01(\x01R\rcurrentBudget\"w\n" +
+	"\x13CaseDetectedPayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12!\n" +
+	"\fsample_epoch\x18\x02 \x01(\x03R\vsampleEpoch\x12'\n" +
+	"\x0fjurisdiction_id\x18\x03 \x01(\tR\x0ejurisdictionId\"\xb9\x01\n" +
+	"\x16AgentTurnedAwayPayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x19\n" +
+	"\bspace_id\x18\x03 \x01(\tR\aspaceId\x12\x1d\n" +
+	"\n" +
+	"space_type\x18\x04 \x01(\tR\tspaceType\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12'\n" +
+	"\x0fjurisdiction_id\x18\x06 \x01(\tR\x0ejurisdictionId\"\x8a\x01\n" +
+	"\x1aAgentWorkedFromHomePayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"occupation\x18\x03 \x01(\tR\n" +
+	"occupation\x12&\n" +
+	"\x0fnext_move_epoch\x18\x04 \x01(\x03R\rnextMoveEpoch\"\xa2\x01\n" +
+	"\x17ComplianceUpdatePayload\x12\x14\n" +
+	"\x05epoch\x18\x01 \x01(\x03R\x05epoch\x12'\n" +
+	"\x0fjurisdiction_id\x18\x02 \x01(\tR\x0ejurisdictionId\x12'\n" +
+	"\x0fcompliance_rate\x18\x03 \x01(\x01R\x0ecomplianceRate\x12\x1f\n" +
+	"\vrecent_risk\x18\x04 \x01(\x01R\n" +
+	"recentRisk\"O\n" +
+	"\x10AdmissionPayload\x12#\n" +
+	"\rsimulation_id\x18\x01 \x01(\tR\fsimulationId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x90\x02\n" +
+	"\rMetricsUpdate\x12\x15\n" +
+	"\x06api_id\x18\x01 \x01(\tR\x05apiId\x12#\n" +
+	"\rsimulation_id\x18\x02 \x01(\tR\fsimulationId\x12R\n" +
+	"\rjurisdictions\x18\x03 \x03(\v2,.simulation.MetricsUpdate.JurisdictionsEntryR\rjurisdictions\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x1aU\n" +
+	"\x12JurisdictionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.simulation.MetricsR\x05value:\x028\x01\"\xc9\x06\n" +
+	"\aMetrics\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x03R\x03day\x12%\n" +
+	"\x0enew_infections\x18\x02 \x01(\x03R\rnewInfections\x121\n" +
+	"\x14new_hospitalizations\x18\x03 \x01(\x03R\x13newHospitalizations\x12%\n" +
+	"\x0enew_recoveries\x18\x04 \x01(\x03R\rnewRecoveries\x12\x1d\n" +
+	"\n" +
+	"new_deaths\x18\x05 \x01(\x03R\tnewDeaths\x12/\n" +
+	"\x13infected_population\x18\x06 \x01(\x03R\x12infectedPopulation\x123\n" +
+	"\x15infectious_population\x18\a \x01(\x03R\x14infectiousPopulation\x127\n" +
+	"\x17hospitalized_population\x18\b \x01(\x03R\x16hospitalizedPopulation\x12+\n" +
+	"\x11immune_population\x18\t \x01(\x03R\x10immunePopulation\x12'\n" +
+	"\x0fdead_population\x18\n" +
+	" \x01(\x03R\x0edeadPopulation\x12)\n" +
+	"\x10new_reinfections\x18\x13 \x01(\x03R\x0fnewReinfections\x12\x1b\n" +
+	"\tnew_tests\x18\v \x01(\x03R\bnewTests\x12,\n" +
+	"\x12new_positive_tests\x18\f \x01(\x03R\x10newPositiveTests\x12\x1f\n" +
+	"\vtotal_tests\x18\r \x01(\x03R\n" +
+	"totalTests\x120\n" +
+	"\x14total_positive_tests\x18\x0e \x01(\x03R\x12totalPositiveTests\x12!\n" +
+	"\ftest_backlog\x18\x0f \x01(\x03R\vtestBacklog\x12#\n" +
+	"\rtest_capacity\x18\x10 \x01(\x03R\ftestCapacity\x12\x1b\n" +
+	"\tnew_cases\x18\x11 \x01(\x03R\bnewCases\x12\x1f\n" +
+	"\vtotal_cases\x18\x12 \x01(\x03R\n" +
+	"totalCases\x12\x1f\n" +
+	"\vturned_away\x18\x14 \x01(\x03R\n" +
+	"turnedAway\x12'\n" +
+	"\x0fcompliance_rate\x18\x15 \x01(\x01R\x0ecomplianceRate2\xf6\x02\n" +
+	"\x11SimulationService\x12J\n" +
+	"\x0fStartSimulation\x12\x12.simulation.Config\x1a#.simulation.StartSimulationResponse\x12J\n" +
+	"\vSendCommand\x12\x1e.simulation.SendCommandRequest\x1a\x1b.simulation.CommandResponse\x12F\n" +
+	"\x12SendManagerCommand\x12\x13.simulation.Command\x1a\x1b.simulation.CommandResponse\x12;\n" +
+	"\x06Events\x12\x1c.simulation.SubscribeRequest\x1a\x11.simulation.Event0\x01\x12D\n" +
+	"\aMetrics\x12\x1c.simulation.SubscribeRequest\x1a\x19.simulation.MetricsUpdate0\x01BFZDgithub.com/CoralCoralCoralCoral/simulation-engine/proto/simulationpbb\x06proto3"
+
+var (
+	file_simulation_proto_rawDescOnce sync.Once
+	file_simulation_proto_rawDescData []byte
+)
+
+func file_simulation_proto_rawDescGZIP() []byte {
+	file_simulation_proto_rawDescOnce.Do(func() {
+		file_simulation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_simulation_proto_rawDesc), len(file_simulation_proto_rawDesc)))
+	})
+	return file_simulation_proto_rawDescData
+}
+
+var file_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_simulation_proto_goTypes = []any{
+	(*StartSimulationResponse)(nil),              // 0: simulation.StartSimulationResponse
+	(*SendCommandRequest)(nil),                   // 1: simulation.SendCommandRequest
+	(*SubscribeRequest)(nil),                     // 2: simulation.SubscribeRequest
+	(*Config)(nil),                               // 3: simulation.Config
+	(*InfectiousnessPoint)(nil),                  // 4: simulation.InfectiousnessPoint
+	(*Distribution)(nil),                         // 5: simulation.Distribution
+	(*Policy)(nil),                               // 6: simulation.Policy
+	(*Jurisdiction)(nil),                         // 7: simulation.Jurisdiction
+	(*Command)(nil),                              // 8: simulation.Command
+	(*ApplyPolicyUpdatePayload)(nil),             // 9: simulation.ApplyPolicyUpdatePayload
+	(*SetSpeedPayload)(nil),                      // 10: simulation.SetSpeedPayload
+	(*StepPayload)(nil),                          // 11: simulation.StepPayload
+	(*RunUntilPayload)(nil),                      // 12: simulation.RunUntilPayload
+	(*AgentStreamConfig)(nil),                    // 13: simulation.AgentStreamConfig
+	(*QueryJurisdictionPayload)(nil),             // 14: simulation.QueryJurisdictionPayload
+	(*QueryEntityPayload)(nil),                   // 15: simulation.QueryEntityPayload
+	(*CommandResponse)(nil),                      // 16: simulation.CommandResponse
+	(*PopulationQueryResult)(nil),                // 17: simulation.PopulationQueryResult
+	(*SpaceQueryResult)(nil),                     // 18: simulation.SpaceQueryResult
+	(*AgentQueryResult)(nil),                     // 19: simulation.AgentQueryResult
+	(*SimulationList)(nil),                       // 20: simulation.SimulationList
+	(*SimulationInfo)(nil),                       // 21: simulation.SimulationInfo
+	(*Event)(nil),                                // 22: simulation.Event
+	(*SimulationInitializedPayload)(nil),         // 23: simulation.SimulationInitializedPayload
+	(*SimulationInitFailedPayload)(nil),          // 24: simulation.SimulationInitFailedPayload
+	(*SimulationStateUpdatePayload)(nil),         // 25: simulation.SimulationStateUpdatePayload
+	(*SimulationEndedPayload)(nil),               // 26: simulation.SimulationEndedPayload
+	(*EpochEndPayload)(nil),                      // 27: simulation.EpochEndPayload
+	(*CommandProcessedPayload)(nil),              // 28: simulation.CommandProcessedPayload
+	(*AgentStateUpdatePayload)(nil),              // 29: simulation.AgentStateUpdatePayload
+	(*AgentLocationUpdatePayload)(nil),           // 30: simulation.AgentLocationUpdatePayload
+	(*SpaceOccupancyUpdatePayload)(nil),          // 31: simulation.SpaceOccupancyUpdatePayload
+	(*SpaceTestingUpdatePayload)(nil),            // 32: simulation.SpaceTestingUpdatePayload
+	(*PolicyUpdatePayload)(nil),                  // 33: simulation.PolicyUpdatePayload
+	(*BudgetUpdatePayload)(nil),                  // 34: simulation.BudgetUpdatePayload
+	(*CaseDetectedPayload)(nil),                  // 35: simulation.CaseDetectedPayload
+	(*AgentTurnedAwayPayload)(nil),               // 36: simulation.AgentTurnedAwayPayload
+	(*AgentWorkedFromHomePayload)(nil),           // 37: simulation.AgentWorkedFromHomePayload
+	(*ComplianceUpdatePayload)(nil),              // 38: simulation.ComplianceUpdatePayload
+	(*AdmissionPayload)(nil),                     // 39: simulation.AdmissionPayload
+	(*MetricsUpdate)(nil),                        // 40: simulation.MetricsUpdate
+	(*Metrics)(nil),                              // 41: simulation.Metrics
+	nil,                                          // 42: simulation.Config.DistributionsEntry
+	nil,                                          // 43: simulation.Policy.VentilationImprovementEntry
+	nil,                                          // 44: simulation.Policy.ClosedSpacesEntry
+	nil,                                          // 45: simulation.ApplyPolicyUpdatePayload.VentilationImprovementEntry
+	nil,                                          // 46: simulation.ApplyPolicyUpdatePayload.ClosedSpacesEntry
+	nil,                                          // 47: simulation.PopulationQueryResult.CountsEntry
+	(*SpaceOccupancyUpdatePayload_Occupant)(nil), // 48: simulation.SpaceOccupancyUpdatePayload.Occupant
+	nil,                           // 49: simulation.MetricsUpdate.JurisdictionsEntry
+	(*timestamppb.Timestamp)(nil), // 50: google.protobuf.Timestamp
+}
+var file_simulation_proto_depIdxs = []int32{
+	8,  // 0: simulation.SendCommandRequest.command:type_name -> simulation.Command
+	13, // 1: simulation.Config.agent_stream:type_name -> simulation.AgentStreamConfig
+	4,  // 2: simulation.Config.infectiousness_curve:type_name -> simulation.InfectiousnessPoint
+	42, // 3: simulation.Config.distributions:type_name -> simulation.Config.DistributionsEntry
+	43, // 4: simulation.Policy.ventilation_improvement:type_name -> simulation.Policy.VentilationImprovementEntry
+	44, // 5: simulation.Policy.closed_spaces:type_name -> simulation.Policy.ClosedSpacesEntry
+	6,  // 6: simulation.Jurisdiction.policy:type_name -> simulation.Policy
+	9,  // 7: simulation.Command.apply_policy_update:type_name -> simulation.ApplyPolicyUpdatePayload
+	10, // 8: simulation.Command.set_speed:type_name -> simulation.SetSpeedPayload
+	11, // 9: simulation.Command.step:type_name -> simulation.StepPayload
+	12, // 10: simulation.Command.run_until:type_name -> simulation.RunUntilPayload
+	14, // 11: simulation.Command.query_jurisdiction:type_name -> simulation.QueryJurisdictionPayload
+	15, // 12: simulation.Command.query_entity:type_name -> simulation.QueryEntityPayload
+	13, // 13: simulation.Command.set_agent_stream:type_name -> simulation.AgentStreamConfig
+	45, // 14: simulation.ApplyPolicyUpdatePayload.ventilation_improvement:type_name -> simulation.ApplyPolicyUpdatePayload.VentilationImprovementEntry
+	46, // 15: simulation.ApplyPolicyUpdatePayload.closed_spaces:type_name -> simulation.ApplyPolicyUpdatePayload.ClosedSpacesEntry
+	33, // 16: simulation.CommandResponse.policy:type_name -> simulation.PolicyUpdatePayload
+	34, // 17: simulation.CommandResponse.budget:type_name -> simulation.BudgetUpdatePayload
+	27, // 18: simulation.CommandResponse.time:type_name -> simulation.EpochEndPayload
+	17, // 19: simulation.CommandResponse.population:type_name -> simulation.PopulationQueryResult
+	18, // 20: simulation.CommandResponse.space:type_name -> simulation.SpaceQueryResult
+	19, // 21: simulation.CommandResponse.agent:type_name -> simulation.AgentQueryResult
+	20, // 22: simulation.CommandResponse.simulations:type_name -> simulation.SimulationList
+	47, // 23: simulation.PopulationQueryResult.counts:type_name -> simulation.PopulationQueryResult.CountsEntry
+	21, // 24: simulation.SimulationList.simulations:type_name -> simulation.SimulationInfo
+	50, // 25: simulation.SimulationInfo.queued_at:type_name -> google.protobuf.Timestamp
+	50, // 26: simulation.SimulationInfo.started_at:type_name -> google.protobuf.Timestamp
+	23, // 27: simulation.Event.simulation_initialized:type_name -> simulation.SimulationInitializedPayload
+	25, // 28: simulation.Event.simulation_state_update:type_name -> simulation.SimulationStateUpdatePayload
+	26, // 29: simulation.Event.simulation_ended:type_name -> simulation.SimulationEndedPayload
+	27, // 30: simulation.Event.epoch_end:type_name -> simulation.EpochEndPayload
+	28, // 31: simulation.Event.command_processed:type_name -> simulation.CommandProcessedPayload
+	29, // 32: simulation.Event.agent_state_update:type_name -> simulation.AgentStateUpdatePayload
+	30, // 33: simulation.Event.agent_location_update:type_name -> simulation.AgentLocationUpdatePayload
+	31, // 34: simulation.Event.space_occupancy_update:type_name -> simulation.SpaceOccupancyUpdatePayload
+	32, // 35: simulation.Event.space_testing_update:type_name -> simulation.SpaceTestingUpdatePayload
+	33, // 36: simulation.Event.policy_update:type_name -> simulation.PolicyUpdatePayload
+	34, // 37: simulation.Event.budget_update:type_name -> simulation.BudgetUpdatePayload
+	35, // 38: simulation.Event.case_detected:type_name -> simulation.CaseDetectedPayload
+	39, // 39: simulation.Event.admission:type_name -> simulation.AdmissionPayload
+	24, // 40: simulation.Event.simulation_init_failed:type_name -> simulation.SimulationInitFailedPayload
+	36, // 41: simulation.Event.agent_turned_away:type_name -> simulation.AgentTurnedAwayPayload
+	37, // 42: simulation.Event.agent_worked_from_home:type_name -> simulation.AgentWorkedFromHomePayload
+	38, // 43: simulation.Event.compliance_update:type_name -> simulation.ComplianceUpdatePayload
+	7,  // 44: simulation.SimulationInitializedPayload.jurisdictions:type_name -> simulation.Jurisdiction
+	3,  // 45: simulation.SimulationInitializedPayload.config:type_name -> simulation.Config
+	50, // 46: simulation.SimulationEndedPayload.time:type_name -> google.protobuf.Timestamp
+	50, // 47: simulation.EpochEndPayload.time:type_name -> google.protobuf.Timestamp
+	8,  // 48: simulation.CommandProcessedPayload.command:type_name -> simulation.Command
+	48, // 49: simulation.SpaceOccupancyUpdatePayload.occupants:type_name -> simulation.SpaceOccupancyUpdatePayload.Occupant
+	6,  // 50: simulation.PolicyUpdatePayload.policy:type_name -> simulation.Policy
+	49, // 51: simulation.MetricsUpdate.jurisdictions:type_name -> simulation.MetricsUpdate.JurisdictionsEntry
+	5,  // 52: simulation.Config.DistributionsEntry.value:type_name -> simulation.Distribution
+	41, // 53: simulation.MetricsUpdate.JurisdictionsEntry.value:type_name -> simulation.Metrics
+	3,  // 54: simulation.SimulationService.StartSimulation:input_type -> simulation.Config
+	1,  // 55: simulation.SimulationService.SendCommand:input_type -> simulation.SendCommandRequest
+	8,  // 56: simulation.SimulationService.SendManagerCommand:input_type -> simulation.Command
+	2,  // 57: simulation.SimulationService.Events:input_type -> simulation.SubscribeRequest
+	2,  // 58: simulation.SimulationService.Metrics:input_type -> simulation.SubscribeRequest
+	0,  // 59: simulation.SimulationService.StartSimulation:output_type -> simulation.StartSimulationResponse
+	16, // 60: simulation.SimulationService.SendCommand:output_type -> simulation.CommandResponse
+	16, // 61: simulation.SimulationService.SendManagerCommand:output_type -> simulation.CommandResponse
+	22, // 62: simulation.SimulationService.Events:output_type -> simulation.Event
+	40, // 63: simulation.SimulationService.Metrics:output_type -> simulation.MetricsUpdate
+	59, // [59:64] is the sub-list for method output_type
+	54, // [54:59] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
+}
+
+func init() { file_simulation_proto_init() }
+func file_simulation_proto_init() {
+	if File_simulation_proto != nil {
+		return
+	}
+	file_simulation_proto_msgTypes[3].OneofWrappers = []any{}
+	file_simulation_proto_msgTypes[6].OneofWrappers = []any{}
+	file_simulation_proto_msgTypes[8].OneofWrappers = []any{
+		(*Command_ApplyPolicyUpdate)(nil),
+		(*Command_SetSpeed)(nil),
+		(*Command_Step)(nil),
+		(*Command_RunUntil)(nil),
+		(*Command_QueryJurisdiction)(nil),
+		(*Command_QueryEntity)(nil),
+		(*Command_SetAgentStream)(nil),
+	}
+	file_simulation_proto_msgTypes[9].OneofWrappers = []any{}
+	file_simulation_proto_msgTypes[16].OneofWrappers = []any{
+		(*CommandResponse_Policy)(nil),
+		(*CommandResponse_Budget)(nil),
+		(*CommandResponse_Time)(nil),
+		(*CommandResponse_Population)(nil),
+		(*CommandResponse_Space)(nil),
+		(*CommandResponse_Agent)(nil),
+		(*CommandResponse_Simulations)(nil),
+	}
+	file_simulation_proto_msgTypes[22].OneofWrappers = []any{
+		(*Event_SimulationInitialized)(nil),
+		(*Event_SimulationStateUpdate)(nil),
+		(*Event_SimulationEnded)(nil),
+		(*Event_EpochEnd)(nil),
+		(*Event_CommandProcessed)(nil),
+		(*Event_AgentStateUpdate)(nil),
+		(*Event_AgentLocationUpdate)(nil),
+		(*Event_SpaceOccupancyUpdate)(nil),
+		(*Event_SpaceTestingUpdate)(nil),
+		(*Event_PolicyUpdate)(nil),
+		(*Event_BudgetUpdate)(nil),
+		(*Event_CaseDetected)(nil),
+		(*Event_Admission)(nil),
+		(*Event_SimulationInitFailed)(nil),
+		(*Event_AgentTurnedAway)(nil),
+		(*Event_AgentWorkedFromHome)(nil),
+		(*Event_ComplianceUpdate)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_simulation_proto_rawDesc), len(file_simulation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   50,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_simulation_proto_goTypes,
+		DependencyIndexes: file_simulation_proto_depIdxs,
+		MessageInfos:      file_simulation_proto_msgTypes,
+	}.Build()
+	File_simulation_proto = out.File
+	file_simulation_proto_goTypes = nil
+	file_simulation_proto_depIdxs = nil
+}
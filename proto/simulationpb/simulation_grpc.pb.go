@@ -0,0 +1,316 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: simulation.proto
+
+package simulationpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SimulationService_StartSimulation_FullMethodName    = "/simulation.SimulationService/StartSimulation"
+	SimulationService_SendCommand_FullMethodName        = "/simulation.SimulationService/SendCommand"
+	SimulationService_SendManagerCommand_FullMethodName = "/simulation.SimulationService/SendManagerCommand"
+	SimulationService_Events_FullMethodName             = "/simulation.SimulationService/Events"
+	SimulationService_Metrics_FullMethodName            = "/simulation.SimulationService/Metrics"
+)
+
+// SimulationServiceClient is the client API for SimulationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The typed contract of the simulation engine. Messages mirror the JSON
+// carried by the AMQP and HTTP transports: field names match the json tags
+// of the corresponding Go types, and enumerations such as agent states or
+// command types are carried as the same strings.
+type SimulationServiceClient interface {
+	// StartSimulation hands a config to the engine. The simulation id is
+	// generated when the config doesn't carry one. The api id scoping the
+	// simulation's notifications is read from the x-api-id metadata and
+	// generated when absent.
+	StartSimulation(ctx context.Context, in *Config, opts ...grpc.CallOption) (*StartSimulationResponse, error)
+	// SendCommand delivers a command to a running simulation. Queries are
+	// answered in the response, other commands are acknowledged once the
+	// simulation has picked them up.
+	SendCommand(ctx context.Context, in *SendCommandRequest, opts ...grpc.CallOption) (*CommandResponse, error)
+	// SendManagerCommand delivers list_simulations and kill_simulation.
+	SendManagerCommand(ctx context.Context, in *Command, opts ...grpc.CallOption) (*CommandResponse, error)
+	// Events streams the simulation events of the matching simulations. The
+	// server sends headers once the subscription is in place, so a client
+	// can wait for them before starting a simulation. A stream subscribed to
+	// a single simulation ends after its simulation_ended event.
+	Events(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// Metrics streams the daily metrics of the matching simulations.
+	Metrics(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MetricsUpdate], error)
+}
+
+type simulationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulationServiceClient(cc grpc.ClientConnInterface) SimulationServiceClient {
+	return &simulationServiceClient{cc}
+}
+
+func (c *simulationServiceClient) StartSimulation(ctx context.Context, in *Config, opts ...grpc.CallOption) (*StartSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartSimulationResponse)
+	err := c.cc.Invoke(ctx, SimulationService_StartSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) SendCommand(ctx context.Context, in *SendCommandRequest, opts ...grpc.CallOption) (*CommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, SimulationService_SendCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) SendManagerCommand(ctx context.Context, in *Command, opts ...grpc.CallOption) (*CommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, SimulationService_SendManagerCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) Events(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SimulationService_ServiceDesc.Streams[0], SimulationService_Events_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_EventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *simulationServiceClient) Metrics(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MetricsUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SimulationService_ServiceDesc.Streams[1], SimulationService_Metrics_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, MetricsUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_MetricsClient = grpc.ServerStreamingClient[MetricsUpdate]
+
+// SimulationServiceServer is the server API for SimulationService service.
+// All implementations must embed UnimplementedSimulationServiceServer
+// for forward compatibility.
+//
+// The typed contract of the simulation engine. Messages mirror the JSON
+// carried by the AMQP and HTTP transports: field names match the json tags
+// of the corresponding Go types, and enumerations such as agent states or
+// command types are carried as the same strings.
+type SimulationServiceServer interface {
+	// StartSimulation hands a config to the engine. The simulation id is
+	// generated when the config doesn't carry one. The api id scoping the
+	// simulation's notifications is read from the x-api-id metadata and
+	// generated when absent.
+	StartSimulation(context.Context, *Config) (*StartSimulationResponse, error)
+	// SendCommand delivers a command to a running simulation. Queries are
+	// answered in the response, other commands are acknowledged once the
+	// simulation has picked them up.
+	SendCommand(context.Context, *SendCommandRequest) (*CommandResponse, error)
+	// SendManagerCommand delivers list_simulations and kill_simulation.
+	SendManagerCommand(context.Context, *Command) (*CommandResponse, error)
+	// Events streams the simulation events of the matching simulations. The
+	// server sends headers once the subscription is in place, so a client
+	// can wait for them before starting a simulation. A stream subscribed to
+	// a single simulation ends after its simulation_ended event.
+	Events(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
+	// Metrics streams the daily metrics of the matching simulations.
+	Metrics(*SubscribeRequest, grpc.ServerStreamingServer[MetricsUpdate]) error
+	mustEmbedUnimplementedSimulationServiceServer()
+}
+
+// UnimplementedSimulationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSimulationServiceServer struct{}
+
+func (UnimplementedSimulationServiceServer) StartSimulation(context.Context, *Config) (*StartSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSimulation not implemented")
+}
+func (UnimplementedSimulationServiceServer) SendCommand(context.Context, *SendCommandRequest) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendCommand not implemented")
+}
+func (UnimplementedSimulationServiceServer) SendManagerCommand(context.Context, *Command) (*CommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendManagerCommand not implemented")
+}
+func (UnimplementedSimulationServiceServer) Events(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (UnimplementedSimulationServiceServer) Metrics(*SubscribeRequest, grpc.ServerStreamingServer[MetricsUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method Metrics not implemented")
+}
+func (UnimplementedSimulationServiceServer) mustEmbedUnimplementedSimulationServiceServer() {}
+func (UnimplementedSimulationServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeSimulationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulationServiceServer will
+// result in compilation errors.
+type UnsafeSimulationServiceServer interface {
+	mustEmbedUnimplementedSimulationServiceServer()
+}
+
+func RegisterSimulationServiceServer(s grpc.ServiceRegistrar, srv SimulationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSimulationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SimulationService_ServiceDesc, srv)
+}
+
+func _SimulationService_StartSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).StartSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_StartSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).StartSimulation(ctx, req.(*Config))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_SendCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).SendCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_SendCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).SendCommand(ctx, req.(*SendCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_SendManagerCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Command)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).SendManagerCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_SendManagerCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).SendManagerCommand(ctx, req.(*Command))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulationServiceServer).Events(m, &grpc.GenericServerStream[SubscribeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_EventsServer = grpc.ServerStreamingServer[Event]
+
+func _SimulationService_Metrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulationServiceServer).Metrics(m, &grpc.GenericServerStream[SubscribeRequest, MetricsUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_MetricsServer = grpc.ServerStreamingServer[MetricsUpdate]
+
+// SimulationService_ServiceDesc is the grpc.ServiceDesc for SimulationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimulationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "simulation.SimulationService",
+	HandlerType: (*SimulationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartSimulation",
+			Handler:    _SimulationService_StartSimulation_Handler,
+		},
+		{
+			MethodName: "SendCommand",
+			Handler:    _SimulationService_SendCommand_Handler,
+		},
+		{
+			MethodName: "SendManagerCommand",
+			Handler:    _SimulationService_SendManagerCommand_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _SimulationService_Events_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Metrics",
+			Handler:       _SimulationService_Metrics_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "simulation.proto",
+}
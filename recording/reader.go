@@ -0,0 +1,91 @@
+package recording
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+)
+
+type Reader struct {
+	file          *os.File
+	reader        *gzip.Reader
+	decoder       *json.Decoder
+	event_decoder *model.EventDecoder
+	header        Header
+}
+
+// Open reads the header of the recording at path. Its events are read
+// with Next or Replay.
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	recording := &Reader{
+		file:          file,
+		reader:        reader,
+		decoder:       json.NewDecoder(reader),
+		event_decoder: model.NewEventDecoder(),
+	}
+
+	if err := recording.decoder.Decode(&recording.header); err != nil {
+		recording.Close()
+		return nil, fmt.Errorf("invalid recording header: %w", err)
+	}
+
+	if recording.header.Version > model.ProtocolVersion {
+		recording.Close()
+		return nil, fmt.Errorf("recording version %d is newer than supported version %d", recording.header.Version, model.ProtocolVersion)
+	}
+
+	return recording, nil
+}
+
+func (recording *Reader) Config() model.Config {
+	return recording.header.Config
+}
+
+// Next returns the next recorded event, or io.EOF after the last one.
+func (recording *Reader) Next() (*logger.Event, error) {
+	var record model.EventRecord
+	if err := recording.decoder.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return recording.event_decoder.Decode(record)
+}
+
+// Replay logs the remaining events to logger_ in the order they were
+// recorded. The logger must be broadcasting.
+func (recording *Reader) Replay(logger_ *logger.Logger) error {
+	for {
+		event, err := recording.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		logger_.Log(*event)
+	}
+}
+
+func (recording *Reader) Close() error {
+	recording.reader.Close()
+
+	return recording.file.Close()
+}
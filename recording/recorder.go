@@ -0,0 +1,98 @@
+// Package recording writes the events of a simulation to a gzipped file of
+// JSON lines and reads them back, so that subscribers can be run against a
+// finished simulation without simulating it again.
+//
+// The first line of a recording is a Header with the simulation's config.
+// Every following line is a model.EventRecord. Commands are recorded as the
+// CommandProcessed events the simulation logs for them, which carry the
+// epoch they were applied in. Queries don't change the simulation and
+// aren't recorded.
+package recording
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+)
+
+type Header struct {
+	Version int          `json:"version"`
+	Config  model.Config `json:"config"`
+}
+
+type Recorder struct {
+	file    *os.File
+	writer  *gzip.Writer
+	encoder *json.Encoder
+	failed  bool
+}
+
+// NewRecorder writes a recording of the simulation configured by config to
+// the file at path. Recordings are never overwritten, so it fails with an
+// error satisfying errors.Is(err, fs.ErrExist) if the file already exists.
+func NewRecorder(path string, config *model.Config) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := gzip.NewWriter(file)
+	recorder := &Recorder{
+		file:    file,
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+
+	if err := recorder.encoder.Encode(Header{Version: model.ProtocolVersion, Config: *config}); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return recorder, nil
+}
+
+func (recorder *Recorder) NewEventSubscriber() func(event *logger.Event) {
+	return func(event *logger.Event) {
+		if recorder.failed {
+			return
+		}
+
+		if err := recorder.record(event); err != nil {
+			// a recording with gaps is misleading, so stop at the first error
+			slog.Error("failed to record event, stopping recording", "path", recorder.file.Name(), "event_type", event.Type, "error", err)
+			recorder.failed = true
+		}
+	}
+}
+
+func (recorder *Recorder) record(event *logger.Event) error {
+	record, err := model.NewEventRecord(event)
+	if err != nil {
+		return err
+	}
+
+	if err := recorder.encoder.Encode(record); err != nil {
+		return err
+	}
+
+	// flush daily so that a crash loses at most a day of events
+	if payload, ok := event.Payload.(model.EpochEndPayload); ok && (payload.Epoch*payload.TimeStep)%(24*60*60*1000) == 0 {
+		return recorder.writer.Flush()
+	}
+
+	return nil
+}
+
+// Close completes the recording. Call it once the simulation has ended.
+func (recorder *Recorder) Close() error {
+	if err := recorder.writer.Close(); err != nil {
+		recorder.file.Close()
+		return err
+	}
+
+	return recorder.file.Close()
+}
@@ -0,0 +1,96 @@
+package recording
+
+import (
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingIsReplayedInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "simulation.jsonl.gz")
+	config := model.Config{Id: uuid.New(), TimeStep: 15 * 60 * 1000, NumAgents: 1000}
+
+	recorder, err := NewRecorder(path, &config)
+	if err != nil {
+		t.Fatalf("Test failed because the recording couldn't be created: %s", err)
+	}
+
+	record := recorder.NewEventSubscriber()
+	record(&logger.Event{Type: model.EpochEnd, Payload: model.EpochEndPayload{Epoch: 1, TimeStep: config.TimeStep}})
+	record(&logger.Event{
+		Type:    model.CommandProcessed,
+		Payload: model.CommandProcessedPayload{Epoch: 1, Command: model.Command{Type: model.Step, Payload: &model.StepPayload{Epochs: 4}}},
+	})
+	record(&logger.Event{Type: model.SimulationEnded, Payload: model.SimulationEndedPayload{Epoch: 2, State: model.Finished}})
+
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Test failed because the recording couldn't be completed: %s", err)
+	}
+
+	reader, err := Open(path)
+	if err != nil {
+		t.Fatalf("Test failed because the recording couldn't be opened: %s", err)
+	}
+	defer reader.Close()
+
+	assert.Equal(t, config, reader.Config(), "Expected the config to be recorded")
+
+	replayed := make([]*logger.Event, 0)
+	logger_ := logger.NewLogger()
+	logger_.Subscribe(func(event *logger.Event) {
+		replayed = append(replayed, event)
+	})
+
+	go logger_.Broadcast()
+
+	if err := reader.Replay(logger_); err != nil {
+		t.Fatalf("Test failed because the recording couldn't be replayed: %s", err)
+	}
+
+	logger_.Close()
+
+	assert.Len(t, replayed, 3, "Expected every recorded event to be replayed")
+	assert.Equal(t, model.EpochEndPayload{Epoch: 1, TimeStep: config.TimeStep}, replayed[0].Payload, "Expected payloads to be decoded to their types")
+	assert.Equal(t, int64(4), replayed[1].Payload.(model.CommandProcessedPayload).Command.Payload.(*model.StepPayload).Epochs, "Expected commands to be decoded with their payloads")
+	assert.Equal(t, model.SimulationEnded, replayed[2].Type, "Expected events to be replayed in order")
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err, "Expected the recording to be exhausted")
+}
+
+func TestEarlierRecordingsAreNotOverwritten(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "simulation.jsonl.gz")
+	config := model.Config{Id: uuid.New(), TimeStep: 15 * 60 * 1000, NumAgents: 1000}
+
+	recorder, err := NewRecorder(path, &config)
+	if err != nil {
+		t.Fatalf("Test failed because the recording couldn't be created: %s", err)
+	}
+
+	recorder.NewEventSubscriber()(&logger.Event{Type: model.EpochEnd, Payload: model.EpochEndPayload{Epoch: 1, TimeStep: config.TimeStep}})
+
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Test failed because the recording couldn't be completed: %s", err)
+	}
+
+	_, err = NewRecorder(path, &config)
+	assert.ErrorIs(t, err, fs.ErrExist, "Expected recording to an existing file to fail")
+
+	reader, err := Open(path)
+	if err != nil {
+		t.Fatalf("Test failed because the recording couldn't be opened: %s", err)
+	}
+	defer reader.Close()
+
+	_, err = reader.Next()
+	assert.NoError(t, err, "Expected the event of the earlier recording to be kept")
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,122 @@
+// Command replay runs the metrics aggregation, and optionally the budget,
+// over a recorded simulation and writes the resulting notifications to
+// stdout as JSON lines. With -metrics-file the metrics are also exported
+// to a CSV or Parquet file, picked by its extension.
+//
+//	go run ./replay [-budget] [-metrics-file metrics.csv] <recording>
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/export"
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/messaging"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/CoralCoralCoralCoral/simulation-engine/recording"
+	"github.com/google/uuid"
+)
+
+func main() {
+	with_budget := flag.Bool("budget", false, "Recompute the budget and write its final value")
+	metrics_path := flag.String("metrics-file", "", "Export the metrics to this .csv or .parquet file")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("usage: replay [-budget] [-metrics-file metrics.csv] <recording>")
+	}
+
+	reader, err := recording.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("couldn't open recording: %s", err)
+	}
+	defer reader.Close()
+
+	config := reader.Config()
+	logger_ := logger.NewLogger()
+	encoder := json.NewEncoder(os.Stdout)
+
+	transport := messaging.NewMemoryTransport()
+	notifications := transport.Listen()
+
+	replayed := make(chan struct{})
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+
+		for {
+			select {
+			case notification := <-notifications:
+				write(encoder, notification.Notification)
+			case <-replayed:
+				// publishing blocks until notifications are received, so
+				// whatever is left is already buffered
+				for len(notifications) > 0 {
+					write(encoder, (<-notifications).Notification)
+				}
+
+				return
+			}
+		}
+	}()
+
+	metrics_tx := messaging.NewMetricsTx(transport, uuid.Nil, &config)
+	logger_.Subscribe(metrics_tx.NewEventSubscriber())
+
+	if *metrics_path != "" {
+		format, err := export.ParseFormat(strings.TrimPrefix(filepath.Ext(*metrics_path), "."))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		metrics_file, err := export.NewMetricsFile(*metrics_path, format)
+		if err != nil {
+			log.Fatalf("couldn't create metrics file: %s", err)
+		}
+		defer func() {
+			if err := metrics_file.Close(); err != nil {
+				log.Printf("couldn't complete metrics file: %s", err)
+			}
+		}()
+
+		logger_.Subscribe(metrics_file.NewEventSubscriber())
+	}
+
+	var budget *model.BudgetConfig
+	if *with_budget {
+		budget = model.NewBudgetConfig(&config, logger_)
+		logger_.Subscribe(budget.NewEventSubscriber())
+	}
+
+	go logger_.Broadcast()
+
+	err = reader.Replay(logger_)
+	logger_.Close()
+	close(replayed)
+	<-written
+
+	metrics_tx.Close()
+	transport.Close()
+
+	if err != nil {
+		log.Fatalf("couldn't replay recording: %s", err)
+	}
+
+	if budget != nil {
+		write(encoder, messaging.NewNotification(messaging.EventNotification, &logger.Event{
+			Type:    model.BudgetUpdate,
+			Payload: budget.Update(),
+		}))
+	}
+}
+
+func write(encoder *json.Encoder, notification messaging.Notification) {
+	if err := encoder.Encode(notification); err != nil {
+		log.Fatalf("couldn't write notification: %s", err)
+	}
+}
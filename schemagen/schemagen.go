@@ -0,0 +1,510 @@
+// schemagen writes the JSON Schemas of the messages exchanged with clients
+// by reflecting over the Go types that produce them.
+//
+//	go run ./schemagen -out schemas
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/geo"
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/manager"
+	"github.com/CoralCoralCoralCoral/simulation-engine/messaging"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+)
+
+//go:generate go run . -out ../schemas
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+type Schema map[string]interface{}
+
+// the payload of every event type sent to clients
+var event_payloads = map[logger.EventType]interface{}{
+	model.SimulationInitialized: model.SimulationInitializedPayload{},
+	model.SimulationStateUpdate: model.SimulationStateUpdatePayload{},
+	model.SimulationEnded:       model.SimulationEndedPayload{},
+	model.SimulationInitFailed:  model.SimulationInitFailedPayload{},
+	model.EpochEnd:              model.EpochEndPayload{},
+	model.CommandProcessed:      model.CommandProcessedPayload{},
+	model.AgentStateUpdate:      model.AgentStateUpdatePayload{},
+	model.AgentLocationUpdate:   model.AgentLocationUpdatePayload{},
+	model.SpaceOccupancyUpdate:  model.SpaceOccupancyUpdatePayload{},
+	model.SpaceTestingUpdate:    model.SpaceTestingUpdatePayload{},
+	model.PolicyUpdate:          model.PolicyUpdatePayload{},
+	model.BudgetUpdate:          model.BudgetUpdatePayload{},
+	model.CaseDetected:          model.CaseDetectedPayload{},
+	model.AgentTurnedAway:       model.AgentTurnedAwayPayload{},
+	model.AgentWorkedFromHome:   model.AgentWorkedFromHomePayload{},
+	model.ComplianceUpdate:      model.ComplianceUpdatePayload{},
+	manager.SimulationQueued:    manager.AdmissionPayload{},
+	manager.SimulationRejected:  manager.AdmissionPayload{},
+}
+
+// the payload of every command type. nil means the command takes no payload
+var command_payloads = map[model.CommandType]interface{}{
+	model.Quit:              nil,
+	model.Pause:             nil,
+	model.Resume:            nil,
+	model.ApplyPolicyUpdate: model.ApplyPolicyUpdatePayload{},
+	model.SetSpeed:          model.SetSpeedPayload{},
+	model.Step:              model.StepPayload{},
+	model.RunUntil:          model.RunUntilPayload{},
+	model.QueryPolicy:       model.QueryJurisdictionPayload{},
+	model.QueryBudget:       nil,
+	model.QueryTime:         nil,
+	model.QueryPopulation:   model.QueryJurisdictionPayload{},
+	model.QuerySpace:        model.QueryEntityPayload{},
+	model.QueryAgent:        model.QueryEntityPayload{},
+	model.ListSimulations:   nil,
+	model.KillSimulation:    model.QueryEntityPayload{},
+	model.SetAgentStream:    model.AgentStreamConfig{},
+}
+
+// the payload of the response to every command type that answers with one
+var response_payloads = map[model.CommandType]interface{}{
+	model.QueryPolicy:     model.PolicyUpdatePayload{},
+	model.QueryBudget:     model.BudgetUpdatePayload{},
+	model.QueryTime:       model.EpochEndPayload{},
+	model.QueryPopulation: model.PopulationQueryResult{},
+	model.QuerySpace:      model.SpaceQueryResult{},
+	model.QueryAgent:      model.AgentQueryResult{},
+	model.ListSimulations: []manager.SimulationInfo{},
+}
+
+// string types with a closed set of values. reflection can't find constants
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(model.AgentState("")): {
+		string(model.Susceptible), string(model.Infected), string(model.Infectious),
+		string(model.Hospitalized), string(model.Dead), string(model.Immune),
+	},
+	reflect.TypeOf(model.SpaceType("")): {
+		string(model.Household), string(model.Office), string(model.SocialSpace), string(model.HealthCareSpace),
+		string(model.Transit), string(model.Outdoor),
+	},
+	reflect.TypeOf(model.Occupation("")): {
+		string(model.KeyWorker), string(model.RemoteWorker), string(model.OnSiteWorker),
+	},
+	reflect.TypeOf(model.TurnAwayReason("")): {
+		string(model.TurnedAwayClosed), string(model.TurnedAwayFull),
+	},
+	reflect.TypeOf(model.SimulationState("")): {
+		string(model.Initializing), string(model.Running), string(model.Paused),
+		string(model.Finished), string(model.Failed), string(manager.QueuedState),
+	},
+	reflect.TypeOf(model.TestStrategy("")): {
+		string(model.TestEveryone), string(model.TestSymptomatic), string(model.TestNone),
+	},
+	reflect.TypeOf(model.CommandType("")): sortedKeys(command_payloads),
+	reflect.TypeOf(model.DistributionType("")): {
+		string(model.NormalDistribution), string(model.LogNormalDistribution), string(model.GammaDistribution),
+		string(model.WeibullDistribution), string(model.TruncatedNormalDistribution), string(model.FixedDistribution),
+	},
+	reflect.TypeOf(model.Parameter("")): parameterNames(),
+}
+
+func main() {
+	out := flag.String("out", "schemas", "directory to write the schemas to")
+	flag.Parse()
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatalf("failed to create %s: %s", *out, err)
+	}
+
+	for name, schema := range Schemas() {
+		data, err := Encode(schema)
+		if err != nil {
+			log.Fatalf("failed to encode %s: %s", name, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(*out, name), data, 0o644); err != nil {
+			log.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+}
+
+// Schemas returns every schema keyed by its file name
+func Schemas() map[string]Schema {
+	return map[string]Schema{
+		"config.schema.json":       configSchema(),
+		"command.schema.json":      commandSchema(),
+		"event.schema.json":        eventSchema(),
+		"metrics.schema.json":      root("metrics.schema.json", reflect.TypeOf(messaging.JuristictionMetrics{})),
+		"notification.schema.json": notificationSchema(),
+	}
+}
+
+func Encode(schema Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// generator collects the definitions of the named struct types it meets
+type generator struct {
+	defs Schema
+}
+
+func newGenerator() *generator {
+	return &generator{defs: make(Schema)}
+}
+
+func root(id string, t reflect.Type) Schema {
+	g := newGenerator()
+
+	return g.finish(id, t.Name(), g.inline(t))
+}
+
+func (g *generator) finish(id, title string, schema Schema) Schema {
+	schema["$schema"] = draft
+	schema["$id"] = id
+	schema["title"] = title
+	schema["x-protocol-version"] = model.ProtocolVersion
+
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+
+	return schema
+}
+
+// schema refers to named structs through $defs and describes everything else inline
+func (g *generator) schema(t reflect.Type) Schema {
+	if t.Kind() == reflect.Struct && t.Name() != "" && !isScalar(t) {
+		if _, ok := g.defs[t.Name()]; !ok {
+			// reserve the name first in case the type refers to itself
+			g.defs[t.Name()] = Schema{}
+			g.defs[t.Name()] = g.inline(t)
+		}
+
+		return Schema{"$ref": "#/$defs/" + t.Name()}
+	}
+
+	return g.inline(t)
+}
+
+func (g *generator) inline(t reflect.Type) Schema {
+	switch t {
+	case reflect.TypeOf(uuid.UUID{}):
+		return Schema{"type": "string", "format": "uuid"}
+	case reflect.TypeOf(time.Time{}):
+		return Schema{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(geo.Feature{}):
+		return Schema{"type": "object", "description": "GeoJSON feature"}
+	}
+
+	if values, ok := enums[t]; ok {
+		return Schema{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Schema{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Interface:
+		return Schema{}
+	case reflect.Pointer:
+		return Schema{"anyOf": []Schema{g.schema(t.Elem()), {"type": "null"}}}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		schema := Schema{"type": "object", "additionalProperties": g.schema(t.Elem())}
+		if values, ok := enums[t.Key()]; ok {
+			schema["propertyNames"] = Schema{"enum": values}
+		}
+
+		return schema
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		panic(fmt.Sprintf("no schema for %s", t))
+	}
+}
+
+// object describes the json encoding of a struct. fields that are always
+// encoded are required, except for pointers which clients may leave out
+func (g *generator) object(t reflect.Type) Schema {
+	properties := make(Schema)
+	required := make([]string, 0)
+
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = g.schema(field.Type)
+
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Pointer {
+			required = append(required, name)
+		}
+	}
+
+	return Schema{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// configSchema describes Config, whose fields may be left out to take the
+// value of its preset
+func configSchema() Schema {
+	schema := root("config.schema.json", reflect.TypeOf(model.Config{}))
+	schema["required"] = []string{}
+
+	data, err := json.Marshal(model.DefaultConfig())
+	if err != nil {
+		panic(err)
+	}
+
+	var defaults map[string]interface{}
+	if err := json.Unmarshal(data, &defaults); err != nil {
+		panic(err)
+	}
+
+	properties := schema["properties"].(Schema)
+	properties["preset"].(Schema)["enum"] = model.Presets()
+	properties["overrides"] = Schema{
+		"type":        "object",
+		"description": "fields of the config that take precedence over the others",
+	}
+
+	// ids have no default, and nested objects are described by their own definition
+	for name, property := range properties {
+		if _, ok := property.(Schema)["$ref"]; !ok && defaults[name] != nil && name != "id" {
+			property.(Schema)["default"] = defaults[name]
+		}
+	}
+
+	return schema
+}
+
+// commandSchema describes Command, whose payload depends on its type
+func commandSchema() Schema {
+	g := newGenerator()
+
+	variants := make([]Schema, 0, len(command_payloads))
+	for _, command_type := range sortedKeys(command_payloads) {
+		payload := Schema{"type": "null"}
+		if example := command_payloads[model.CommandType(command_type)]; example != nil {
+			payload = g.schema(reflect.TypeOf(example))
+		}
+
+		variants = append(variants, Schema{
+			"properties": Schema{
+				"type":    Schema{"const": command_type},
+				"payload": payload,
+			},
+		})
+	}
+
+	return g.finish("command.schema.json", "Command", Schema{
+		"type": "object",
+		"properties": Schema{
+			"version": versionSchema("omitted by clients that predate versioning, which are treated as version 1"),
+			"type":    Schema{"type": "string"},
+			"payload": Schema{},
+		},
+		"required": []string{"type"},
+		"oneOf":    variants,
+	})
+}
+
+// eventSchema describes logger.Event, including the deprecated names that
+// are still accepted for some event types
+func eventSchema() Schema {
+	g := newGenerator()
+
+	return g.finish("event.schema.json", "Event", g.event())
+}
+
+func (g *generator) event() Schema {
+	names := make(map[string]logger.EventType)
+	for event_type := range event_payloads {
+		names[string(event_type)] = event_type
+	}
+
+	deprecated := model.DeprecatedEventTypes()
+	for old := range deprecated {
+		names[string(old)] = old
+	}
+
+	variants := make([]Schema, 0, len(names))
+	for _, name := range sortedKeys(names) {
+		event_type := names[name]
+
+		variant := Schema{
+			"properties": Schema{
+				"type":    Schema{"const": name},
+				"payload": g.schema(reflect.TypeOf(event_payloads[model.CanonicalEventType(event_type)])),
+			},
+		}
+
+		if current, ok := deprecated[event_type]; ok {
+			variant["deprecated"] = true
+			variant["description"] = fmt.Sprintf("deprecated name of %s", current)
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"type":    Schema{"type": "string"},
+			"payload": Schema{},
+		},
+		"required": []string{"type", "payload"},
+		"oneOf":    variants,
+	}
+}
+
+// notificationSchema describes the envelope of everything sent to clients
+func notificationSchema() Schema {
+	g := newGenerator()
+
+	g.defs["Event"] = g.event()
+	g.defs["QueryResponse"] = g.queryResponse()
+
+	variants := []Schema{
+		{"properties": Schema{
+			"type":    Schema{"const": string(messaging.EventNotification)},
+			"payload": Schema{"$ref": "#/$defs/Event"},
+		}},
+		{"properties": Schema{
+			"type":    Schema{"const": string(messaging.EventBatchNotification)},
+			"payload": Schema{"type": "array", "items": Schema{"$ref": "#/$defs/Event"}},
+		}},
+		{"properties": Schema{
+			// the events of an AgentEventBatch refer to the Event definition above
+			"type":    Schema{"const": string(messaging.AgentEventsNotification)},
+			"payload": g.schema(reflect.TypeOf(messaging.AgentEventBatch{})),
+		}},
+		{"properties": Schema{
+			"type":    Schema{"const": string(messaging.MetricsNotification)},
+			"payload": g.schema(reflect.TypeOf(messaging.JuristictionMetrics{})),
+		}},
+		{"properties": Schema{
+			"type":    Schema{"const": string(messaging.QueryResponseNotification)},
+			"payload": Schema{"$ref": "#/$defs/QueryResponse"},
+		}},
+	}
+
+	return g.finish("notification.schema.json", "Notification", Schema{
+		"type": "object",
+		"properties": Schema{
+			"version": versionSchema(""),
+			"type":    Schema{"type": "string"},
+			"payload": Schema{},
+		},
+		"required": []string{"version", "type", "payload"},
+		"oneOf":    variants,
+	})
+}
+
+func (g *generator) queryResponse() Schema {
+	variants := make([]Schema, 0, len(response_payloads)+1)
+	without_payload := make([]string, 0)
+
+	for _, command_type := range sortedKeys(command_payloads) {
+		example, ok := response_payloads[model.CommandType(command_type)]
+		if !ok {
+			without_payload = append(without_payload, command_type)
+			continue
+		}
+
+		variants = append(variants, Schema{
+			"properties": Schema{
+				"type":    Schema{"const": command_type},
+				"payload": Schema{"anyOf": []Schema{g.schema(reflect.TypeOf(example)), {"type": "null"}}},
+			},
+		})
+	}
+
+	variants = append(variants, Schema{
+		"properties": Schema{
+			"type":    Schema{"enum": without_payload},
+			"payload": Schema{"type": "null"},
+		},
+	})
+
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"type":    Schema{"type": "string"},
+			"payload": Schema{},
+			"error":   Schema{"type": "string", "description": "set when the command failed, in which case the payload is null"},
+		},
+		"required": []string{"type", "payload"},
+		"oneOf":    variants,
+	}
+}
+
+func versionSchema(description string) Schema {
+	schema := Schema{"type": "integer", "minimum": 1, "maximum": model.ProtocolVersion}
+	if description != "" {
+		schema["description"] = description
+	}
+
+	return schema
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(uuid.UUID{}), reflect.TypeOf(time.Time{}), reflect.TypeOf(geo.Feature{}):
+		return true
+	default:
+		return false
+	}
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, string(key))
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func parameterNames() []string {
+	names := make([]string, 0)
+	for _, parameter := range model.Parameters() {
+		names = append(names, string(parameter))
+	}
+
+	return names
+}
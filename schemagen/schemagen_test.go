@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemasAreUpToDate(t *testing.T) {
+	for name, schema := range Schemas() {
+		expected, err := Encode(schema)
+		if err != nil {
+			t.Fatalf("Test failed because %s couldn't be encoded: %s", name, err)
+		}
+
+		actual, err := os.ReadFile(filepath.Join("..", "schemas", name))
+		if err != nil {
+			t.Fatalf("Test failed because %s couldn't be read: %s", name, err)
+		}
+
+		assert.Equal(t, string(expected), string(actual), "Expected %s to match the Go types, run go generate ./schemagen", name)
+	}
+}
@@ -0,0 +1,165 @@
+// Package telemetry reports the health of the engine to Prometheus: how
+// fast each simulation runs, whether its subscribers keep up, how long
+// commands take and how often notifications fail to publish, next to the
+// usual Go runtime and process metrics.
+//
+// Series of a simulation are labelled by its id and removed when it ends,
+// so a stalled simulation shows as a rate of 0 on simulation_epochs_total.
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var registry = prometheus.NewRegistry()
+
+var epochs = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "simulation_epochs_total",
+	Help: "Epochs simulated, use its rate for epochs per second.",
+}, []string{"simulation_id"})
+
+var command_duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "simulation_command_duration_seconds",
+	Help:    "Time until a command is picked up by its simulation, or a query is answered.",
+	Buckets: prometheus.ExponentialBuckets(0.0005, 4, 10),
+}, []string{"simulation_id", "command_type"})
+
+var publish_errors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_publish_errors_total",
+	Help: "Notifications that couldn't be published.",
+}, []string{"transport"})
+
+var live = &simulations{
+	tracked: make(map[uuid.UUID]*model.Simulation),
+
+	live: prometheus.NewDesc("simulations_live", "Simulations currently running.", nil, nil),
+	queue_depth: prometheus.NewDesc(
+		"simulation_logger_queue_depth",
+		"Events waiting for or being handled by a subscriber of the simulation's logger, by subscription order.",
+		[]string{"simulation_id", "subscriber"}, nil,
+	),
+	backlog: prometheus.NewDesc(
+		"simulation_logger_backlog",
+		"Events waiting to be broadcast to the subscribers of the simulation's logger.",
+		[]string{"simulation_id"}, nil,
+	),
+}
+
+// simulations reads the logger queues of the tracked simulations whenever
+// they are scraped
+type simulations struct {
+	mu      sync.Mutex
+	tracked map[uuid.UUID]*model.Simulation
+
+	live        *prometheus.Desc
+	queue_depth *prometheus.Desc
+	backlog     *prometheus.Desc
+}
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		epochs,
+		command_duration,
+		publish_errors,
+		live,
+	)
+}
+
+// Track reports on sim until the returned function is called. It must be
+// called before the simulation starts.
+func Track(sim *model.Simulation) (untrack func()) {
+	sim_id := sim.Id()
+	epoch_counter := epochs.WithLabelValues(sim_id.String())
+
+	sim.Subscribe(func(event *logger.Event) {
+		if event.Type == model.EpochEnd {
+			epoch_counter.Inc()
+		}
+	})
+
+	live.mu.Lock()
+	live.tracked[sim_id] = sim
+	live.mu.Unlock()
+
+	return func() {
+		live.mu.Lock()
+		delete(live.tracked, sim_id)
+		live.mu.Unlock()
+
+		epochs.DeleteLabelValues(sim_id.String())
+		command_duration.DeletePartialMatch(prometheus.Labels{"simulation_id": sim_id.String()})
+	}
+}
+
+// TimeCommands wraps the command handlers of sim to record how long each
+// command takes
+func TimeCommands(sim *model.Simulation) (handler func(command model.Command), query_handler func(command model.Command) model.QueryResponse) {
+	sim_id := sim.Id().String()
+
+	handler = func(command model.Command) {
+		defer observeCommand(sim_id, command, time.Now())
+		sim.SendCommand(command)
+	}
+
+	query_handler = func(command model.Command) model.QueryResponse {
+		defer observeCommand(sim_id, command, time.Now())
+		return sim.SendQuery(command)
+	}
+
+	return handler, query_handler
+}
+
+func observeCommand(sim_id string, command model.Command, start time.Time) {
+	command_duration.WithLabelValues(sim_id, string(command.Type)).Observe(time.Since(start).Seconds())
+}
+
+// PublishFailed counts a notification that the named transport couldn't publish
+func PublishFailed(transport string) {
+	publish_errors.WithLabelValues(transport).Inc()
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Serve exposes the metrics at /metrics on addr. It blocks like
+// http.ListenAndServe.
+func Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	return http.ListenAndServe(addr, mux)
+}
+
+func (collector *simulations) Describe(descs chan<- *prometheus.Desc) {
+	descs <- collector.live
+	descs <- collector.queue_depth
+	descs <- collector.backlog
+}
+
+func (collector *simulations) Collect(metrics chan<- prometheus.Metric) {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	metrics <- prometheus.MustNewConstMetric(collector.live, prometheus.GaugeValue, float64(len(collector.tracked)))
+
+	for sim_id, sim := range collector.tracked {
+		for subscriber, depth := range sim.QueueDepths() {
+			metrics <- prometheus.MustNewConstMetric(collector.queue_depth, prometheus.GaugeValue, float64(depth), sim_id.String(), strconv.Itoa(subscriber))
+		}
+
+		metrics <- prometheus.MustNewConstMetric(collector.backlog, prometheus.GaugeValue, float64(sim.LogBacklog()), sim_id.String())
+	}
+}
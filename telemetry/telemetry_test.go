@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CoralCoralCoralCoral/simulation-engine/logger"
+	"github.com/CoralCoralCoralCoral/simulation-engine/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T) string {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("Test failed because the metrics couldn't be read: %s", err)
+	}
+
+	return string(body)
+}
+
+func TestTrackedSimulationsAreReportedUntilUntracked(t *testing.T) {
+	sim := model.NewSimulation(model.Config{Id: uuid.New()}, nil)
+	sim.Subscribe(func(event *logger.Event) {})
+
+	untrack := Track(&sim)
+	PublishFailed("amqp")
+
+	metrics := scrape(t)
+
+	assert.Contains(t, metrics, "simulations_live 1", "Expected the simulation to be counted as live")
+	assert.Contains(t, metrics, `simulation_logger_queue_depth{simulation_id="`+sim.Id().String()+`",subscriber="1"} 0`, "Expected a queue depth per subscriber")
+	assert.Contains(t, metrics, `notification_publish_errors_total{transport="amqp"} 1`, "Expected publish errors to be counted")
+	assert.Contains(t, metrics, "go_goroutines", "Expected the Go runtime metrics to be reported")
+
+	untrack()
+
+	metrics = scrape(t)
+
+	assert.Contains(t, metrics, "simulations_live 0", "Expected the simulation not to be counted once untracked")
+	assert.NotContains(t, metrics, sim.Id().String(), "Expected the simulation's series to be removed once untracked")
+}